@@ -0,0 +1,270 @@
+// Package bench compares the containers of skale with container/list and slices.
+//
+// Every implementation is wrapped in a Sequence, and the benchmarks of the package run the same operations on each of
+// them, for several sizes and element types. The sub-benchmarks are named op/type=T/n=N/impl=I, for example
+// BenchmarkPushBack/type=int/n=256/impl=skale, and cmd/skalebench turns the output of go test into a table:
+//
+//	go test -run '^$' -bench . ./bench | go run ./cmd/skalebench
+//
+// A new container joins the comparison by adding an adapter to Impls.
+package bench
+
+import (
+	"container/list"
+
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+// Sequence is the set of operations measured by the benchmarks.
+type Sequence[T any] interface {
+	Len() int
+	PushBack(v T)
+	PushFront(v T)
+	// PushBackBulk pushes values at the back, in order, the best way the implementation can.
+	PushBackBulk(values ...T)
+	// PushBackSeq pushes a copy of the values of other, a sequence of the same implementation, at the back.
+	PushBackSeq(other Sequence[T])
+	PopBack() (T, bool)
+	PopFront() (T, bool)
+	// Walk calls f on every value from the front to the back, until f returns false.
+	Walk(f func(v T) bool)
+	// PositionAt returns a position on the i-th value, 0 <= i < Len().
+	PositionAt(i int) Position[T]
+}
+
+// Position is a position in a Sequence, used to insert and remove in the middle of it.
+type Position[T any] interface {
+	// InsertAfter inserts v after the position, which does not move.
+	InsertAfter(v T)
+	// RemoveAfter removes the value after the position, which does not move. Return false if there is none.
+	RemoveAfter() (T, bool)
+}
+
+// Impl is an implementation of Sequence.
+type Impl[T any] struct {
+	Name string
+	New  func() Sequence[T]
+}
+
+// Impls returns the implementations compared by the benchmarks.
+func Impls[T any]() []Impl[T] {
+	return []Impl[T]{
+		{Name: "skale", New: func() Sequence[T] { return &skaleList[T]{} }},
+		{Name: "stdlist", New: func() Sequence[T] { return &stdList[T]{} }},
+		{Name: "slice", New: func() Sequence[T] { return &ring[T]{} }},
+	}
+}
+
+// skaleList adapts linkedlist.List.
+type skaleList[T any] struct {
+	l linkedlist.List[T]
+}
+
+func (s *skaleList[T]) Len() int                 { return s.l.Len() }
+func (s *skaleList[T]) PushBack(v T)             { s.l.PushBack(v) }
+func (s *skaleList[T]) PushFront(v T)            { s.l.PushFront(v) }
+func (s *skaleList[T]) PushBackBulk(values ...T) { s.l.PushBackBulk(values...) }
+
+func (s *skaleList[T]) PushBackSeq(other Sequence[T]) {
+	s.l.PushBackList(&other.(*skaleList[T]).l)
+}
+
+func (s *skaleList[T]) PopBack() (T, bool)  { return nodeValue(s.l.PopBack()) }
+func (s *skaleList[T]) PopFront() (T, bool) { return nodeValue(s.l.PopFront()) }
+
+func (s *skaleList[T]) Walk(f func(v T) bool) {
+	s.l.WalkAscending(func(n *linkedlist.Node[T]) bool { return f(n.Value) })
+}
+
+func (s *skaleList[T]) PositionAt(i int) Position[T] {
+	c := s.l.FrontCursor()
+	for ; i > 0; i-- {
+		c.MoveNext()
+	}
+	return skalePosition[T]{&s.l, c}
+}
+
+func nodeValue[T any](n *linkedlist.Node[T]) (T, bool) {
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+	return n.Value, true
+}
+
+type skalePosition[T any] struct {
+	l *linkedlist.List[T]
+	c *linkedlist.Cursor[T]
+}
+
+func (p skalePosition[T]) InsertAfter(v T)        { p.l.InsertAfter(v, p.c) }
+func (p skalePosition[T]) RemoveAfter() (T, bool) { return nodeValue(p.l.RemoveAfter(p.c)) }
+
+// stdList adapts container/list, storing values of type T in the interface values of the elements.
+type stdList[T any] struct {
+	l list.List
+}
+
+func (s *stdList[T]) Len() int      { return s.l.Len() }
+func (s *stdList[T]) PushBack(v T)  { s.l.PushBack(v) }
+func (s *stdList[T]) PushFront(v T) { s.l.PushFront(v) }
+func (s *stdList[T]) Walk(f func(v T) bool) {
+	for e := s.l.Front(); e != nil && f(e.Value.(T)); e = e.Next() {
+	}
+}
+
+func (s *stdList[T]) PushBackBulk(values ...T) {
+	for _, v := range values {
+		s.l.PushBack(v)
+	}
+}
+
+func (s *stdList[T]) PushBackSeq(other Sequence[T]) {
+	s.l.PushBackList(&other.(*stdList[T]).l)
+}
+
+func (s *stdList[T]) PopBack() (T, bool)  { return s.remove(s.l.Back()) }
+func (s *stdList[T]) PopFront() (T, bool) { return s.remove(s.l.Front()) }
+
+func (s *stdList[T]) remove(e *list.Element) (T, bool) {
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	return s.l.Remove(e).(T), true
+}
+
+func (s *stdList[T]) PositionAt(i int) Position[T] {
+	e := s.l.Front()
+	for ; i > 0; i-- {
+		e = e.Next()
+	}
+	return stdPosition[T]{s, e}
+}
+
+type stdPosition[T any] struct {
+	s *stdList[T]
+	e *list.Element
+}
+
+func (p stdPosition[T]) InsertAfter(v T)        { p.s.l.InsertAfter(v, p.e) }
+func (p stdPosition[T]) RemoveAfter() (T, bool) { return p.s.remove(p.e.Next()) }
+
+// ring is a deque stored in a growable ring buffer, the usual way to build a deque on a slice.
+// Inserting and removing in the middle shift the values after the position.
+type ring[T any] struct {
+	buf  []T
+	head int // index of the front value in buf
+	n    int
+}
+
+func (r *ring[T]) Len() int { return r.n }
+
+// at returns the index in buf of the i-th value.
+func (r *ring[T]) at(i int) int {
+	return (r.head + i) % len(r.buf)
+}
+
+// grow makes room for one more value.
+func (r *ring[T]) grow() {
+	if r.n < len(r.buf) {
+		return
+	}
+	buf := make([]T, 2*len(r.buf)+1)
+	for i := 0; i < r.n; i++ {
+		buf[i] = r.buf[r.at(i)]
+	}
+	r.buf, r.head = buf, 0
+}
+
+func (r *ring[T]) PushBack(v T) {
+	r.grow()
+	r.buf[r.at(r.n)] = v
+	r.n++
+}
+
+func (r *ring[T]) PushFront(v T) {
+	r.grow()
+	r.head = (r.head + len(r.buf) - 1) % len(r.buf)
+	r.buf[r.head] = v
+	r.n++
+}
+
+func (r *ring[T]) PushBackBulk(values ...T) {
+	for _, v := range values {
+		r.PushBack(v)
+	}
+}
+
+func (r *ring[T]) PushBackSeq(other Sequence[T]) {
+	o := other.(*ring[T])
+	for i := 0; i < o.n; i++ {
+		r.PushBack(o.buf[o.at(i)])
+	}
+}
+
+func (r *ring[T]) PopBack() (T, bool) {
+	var zero T
+	if r.n == 0 {
+		return zero, false
+	}
+	r.n--
+	i := r.at(r.n)
+	v := r.buf[i]
+	r.buf[i] = zero
+	return v, true
+}
+
+func (r *ring[T]) PopFront() (T, bool) {
+	var zero T
+	if r.n == 0 {
+		return zero, false
+	}
+	v := r.buf[r.head]
+	r.buf[r.head] = zero
+	r.head = (r.head + 1) % len(r.buf)
+	r.n--
+	return v, true
+}
+
+func (r *ring[T]) Walk(f func(v T) bool) {
+	for i := 0; i < r.n && f(r.buf[r.at(i)]); i++ {
+	}
+}
+
+func (r *ring[T]) PositionAt(i int) Position[T] {
+	return &ringPosition[T]{r, i}
+}
+
+// insert inserts v at index i, shifting the values after it.
+func (r *ring[T]) insert(i int, v T) {
+	r.PushBack(v)
+	for j := r.n - 1; j > i; j-- {
+		r.buf[r.at(j)], r.buf[r.at(j-1)] = r.buf[r.at(j-1)], r.buf[r.at(j)]
+	}
+}
+
+// remove removes the value at index i, shifting the values after it.
+func (r *ring[T]) remove(i int) T {
+	v := r.buf[r.at(i)]
+	for j := i; j < r.n-1; j++ {
+		r.buf[r.at(j)] = r.buf[r.at(j+1)]
+	}
+	r.PopBack()
+	return v
+}
+
+type ringPosition[T any] struct {
+	r *ring[T]
+	i int
+}
+
+func (p *ringPosition[T]) InsertAfter(v T) { p.r.insert(p.i+1, v) }
+
+func (p *ringPosition[T]) RemoveAfter() (T, bool) {
+	if p.i+1 >= p.r.n {
+		var zero T
+		return zero, false
+	}
+	return p.r.remove(p.i + 1), true
+}
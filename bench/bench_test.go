@@ -0,0 +1,263 @@
+package bench
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// sizes are the lengths of the sequences benchmarked.
+var sizes = []int{16, 256, 4096}
+
+// large is a value type too large to be stored inline in an interface value.
+type large struct {
+	a [8]int64
+}
+
+func intValue(i int) int       { return i }
+func stringValue(i int) string { return strconv.Itoa(i) }
+func largeValue(i int) large   { return large{a: [8]int64{int64(i)}} }
+
+func values[T any](n int, value func(i int) T) []T {
+	vs := make([]T, n)
+	for i := range vs {
+		vs[i] = value(i)
+	}
+	return vs
+}
+
+// fill returns a new sequence of impl holding vs.
+func fill[T any](impl Impl[T], vs []T) Sequence[T] {
+	s := impl.New()
+	for _, v := range vs {
+		s.PushBack(v)
+	}
+	return s
+}
+
+// benchFunc measures one operation on sequences of impl, with the values vs.
+type benchFunc[T any] func(b *testing.B, impl Impl[T], vs []T)
+
+// run runs f for every implementation and size, with values of type T.
+func run[T any](b *testing.B, typ string, value func(i int) T, f benchFunc[T]) {
+	for _, n := range sizes {
+		vs := values(n, value)
+		for _, impl := range Impls[T]() {
+			b.Run(fmt.Sprintf("type=%s/n=%d/impl=%s", typ, n, impl.Name), func(b *testing.B) {
+				f(b, impl, vs)
+			})
+		}
+	}
+}
+
+// benchmark runs an operation with every element type.
+func benchmark(b *testing.B, fi benchFunc[int], fs benchFunc[string], fl benchFunc[large]) {
+	run(b, "int", intValue, fi)
+	run(b, "string", stringValue, fs)
+	run(b, "large", largeValue, fl)
+}
+
+func pushBack[T any](b *testing.B, impl Impl[T], vs []T) {
+	for i := 0; i < b.N; i++ {
+		s := impl.New()
+		for _, v := range vs {
+			s.PushBack(v)
+		}
+	}
+}
+
+func BenchmarkPushBack(b *testing.B) {
+	benchmark(b, pushBack[int], pushBack[string], pushBack[large])
+}
+
+func pushFront[T any](b *testing.B, impl Impl[T], vs []T) {
+	for i := 0; i < b.N; i++ {
+		s := impl.New()
+		for _, v := range vs {
+			s.PushFront(v)
+		}
+	}
+}
+
+func BenchmarkPushFront(b *testing.B) {
+	benchmark(b, pushFront[int], pushFront[string], pushFront[large])
+}
+
+func popBack[T any](b *testing.B, impl Impl[T], vs []T) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		s := fill(impl, vs)
+		b.StartTimer()
+		for _, ok := s.PopBack(); ok; _, ok = s.PopBack() {
+		}
+	}
+}
+
+func BenchmarkPopBack(b *testing.B) {
+	benchmark(b, popBack[int], popBack[string], popBack[large])
+}
+
+func popFront[T any](b *testing.B, impl Impl[T], vs []T) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		s := fill(impl, vs)
+		b.StartTimer()
+		for _, ok := s.PopFront(); ok; _, ok = s.PopFront() {
+		}
+	}
+}
+
+func BenchmarkPopFront(b *testing.B) {
+	benchmark(b, popFront[int], popFront[string], popFront[large])
+}
+
+// queue measures a FIFO of len(vs) values in steady state: every operation pops the front and pushes it back.
+func queue[T any](b *testing.B, impl Impl[T], vs []T) {
+	s := fill(impl, vs)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v, _ := s.PopFront()
+		s.PushBack(v)
+	}
+}
+
+func BenchmarkQueue(b *testing.B) {
+	benchmark(b, queue[int], queue[string], queue[large])
+}
+
+// cursorInsert inserts len(vs) values after a position in the middle of a sequence of len(vs) values.
+func cursorInsert[T any](b *testing.B, impl Impl[T], vs []T) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		s := fill(impl, vs)
+		p := s.PositionAt(len(vs) / 2)
+		b.StartTimer()
+		for _, v := range vs {
+			p.InsertAfter(v)
+		}
+	}
+}
+
+func BenchmarkCursorInsert(b *testing.B) {
+	benchmark(b, cursorInsert[int], cursorInsert[string], cursorInsert[large])
+}
+
+// cursorRemove removes the len(vs)/2 values after a position at the start of a sequence of len(vs) values.
+func cursorRemove[T any](b *testing.B, impl Impl[T], vs []T) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		s := fill(impl, vs)
+		p := s.PositionAt(0)
+		b.StartTimer()
+		for j := 0; j < len(vs)/2; j++ {
+			p.RemoveAfter()
+		}
+	}
+}
+
+func BenchmarkCursorRemove(b *testing.B) {
+	benchmark(b, cursorRemove[int], cursorRemove[string], cursorRemove[large])
+}
+
+func pushBackBulk[T any](b *testing.B, impl Impl[T], vs []T) {
+	for i := 0; i < b.N; i++ {
+		impl.New().PushBackBulk(vs...)
+	}
+}
+
+func BenchmarkPushBackBulk(b *testing.B) {
+	benchmark(b, pushBackBulk[int], pushBackBulk[string], pushBackBulk[large])
+}
+
+// pushBackList appends a copy of a sequence of len(vs) values to an empty sequence.
+func pushBackList[T any](b *testing.B, impl Impl[T], vs []T) {
+	other := fill(impl, vs)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		impl.New().PushBackSeq(other)
+	}
+}
+
+func BenchmarkPushBackList(b *testing.B) {
+	benchmark(b, pushBackList[int], pushBackList[string], pushBackList[large])
+}
+
+func walk[T any](b *testing.B, impl Impl[T], vs []T) {
+	s := fill(impl, vs)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n := 0
+		s.Walk(func(v T) bool {
+			n++
+			return true
+		})
+		if n != len(vs) {
+			b.Fatalf("Walk visited %d values, want %d", n, len(vs))
+		}
+	}
+}
+
+func BenchmarkWalk(b *testing.B) {
+	benchmark(b, walk[int], walk[string], walk[large])
+}
+
+// contents returns the values of s.
+func contents[T any](s Sequence[T]) []T {
+	var vs []T
+	s.Walk(func(v T) bool {
+		vs = append(vs, v)
+		return true
+	})
+	return vs
+}
+
+// TestImpls checks that the implementations agree, so that the benchmarks compare the same work.
+func TestImpls(t *testing.T) {
+	var want []int
+	for _, impl := range Impls[int]() {
+		s := impl.New()
+		for i := 0; i < 10; i++ {
+			s.PushBack(i)
+			s.PushFront(-i)
+		}
+		s.PushBackBulk(100, 101, 102)
+		s.PushBackSeq(fill(impl, []int{200, 201}))
+
+		p := s.PositionAt(3)
+		p.InsertAfter(300)
+		p.InsertAfter(301)
+		if v, ok := p.RemoveAfter(); !ok || v != 301 {
+			t.Errorf("%s: RemoveAfter() = %d, %v, want 301, true", impl.Name, v, ok)
+		}
+		if _, ok := s.PositionAt(s.Len() - 1).RemoveAfter(); ok {
+			t.Errorf("%s: RemoveAfter() at the back = _, true, want false", impl.Name)
+		}
+
+		for i := 0; i < 3; i++ {
+			s.PopFront()
+			s.PopBack()
+		}
+
+		got := contents(s)
+		if s.Len() != len(got) {
+			t.Errorf("%s: Len() = %d, want %d", impl.Name, s.Len(), len(got))
+		}
+		if want == nil {
+			want = got
+			continue
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s: contents = %v, want %v", impl.Name, got, want)
+		}
+	}
+
+	for _, impl := range Impls[int]() {
+		s := impl.New()
+		if _, ok := s.PopFront(); ok {
+			t.Errorf("%s: PopFront() of an empty sequence = _, true, want false", impl.Name)
+		}
+		if _, ok := s.PopBack(); ok {
+			t.Errorf("%s: PopBack() of an empty sequence = _, true, want false", impl.Name)
+		}
+	}
+}
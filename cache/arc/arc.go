@@ -0,0 +1,264 @@
+// Package arc implements the Adaptive Replacement Cache (ARC) of Megiddo and Modha.
+//
+// ARC keeps two lists of resident entries: T1 for keys seen once recently and T2 for keys seen at least twice.
+// Two ghost lists, B1 and B2, remember the keys recently evicted from T1 and T2, without their values.
+// A hit in a ghost list shows which of recency or frequency would have kept the key, and the cache adapts
+// the target size of T1 accordingly. This makes ARC resistant to scans while keeping LRU behavior on recency
+// friendly workloads.
+//
+// Structure is not thread safe.
+package arc
+
+import "github.com/nnhatnam/skale/list/linkedlist"
+
+// where identifies the list an entry is linked in.
+type where int
+
+const (
+	inT1 where = iota
+	inT2
+	inB1
+	inB2
+)
+
+// entry is a key tracked by the cache. value is only meaningful for resident entries (T1 and T2).
+type entry[K comparable, V any] struct {
+	value V
+	node  *linkedlist.Cursor[K]
+	where where
+}
+
+// Cache is a fixed size ARC cache. Every list keeps its most recently used key at the front.
+type Cache[K comparable, V any] struct {
+	capacity int
+	onEvict  func(key K, value V)
+
+	p              int // target size of T1
+	t1, t2, b1, b2 *linkedlist.List[K]
+	items          map[K]*entry[K, V]
+}
+
+// New returns an empty ARC cache that holds at most capacity entries. The ghost lists track up to capacity more keys.
+// onEvict, if not nil, is called with every resident entry evicted to make room for a new one.
+// It panics if capacity is not positive.
+func New[K comparable, V any](capacity int, onEvict func(key K, value V)) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("arc: capacity must be positive")
+	}
+	return &Cache[K, V]{
+		capacity: capacity,
+		onEvict:  onEvict,
+		t1:       linkedlist.New[K](),
+		t2:       linkedlist.New[K](),
+		b1:       linkedlist.New[K](),
+		b2:       linkedlist.New[K](),
+		items:    make(map[K]*entry[K, V], 2*capacity),
+	}
+}
+
+// list returns the list of w.
+func (c *Cache[K, V]) list(w where) *linkedlist.List[K] {
+	switch w {
+	case inT1:
+		return c.t1
+	case inT2:
+		return c.t2
+	case inB1:
+		return c.b1
+	}
+	return c.b2
+}
+
+// Len returns the number of resident entries.
+func (c *Cache[K, V]) Len() int {
+	return c.t1.Len() + c.t2.Len()
+}
+
+// Cap returns the maximum number of resident entries.
+func (c *Cache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// resident returns the entry of key if it is resident.
+func (c *Cache[K, V]) resident(key K) (*entry[K, V], bool) {
+	e, ok := c.items[key]
+	if !ok || e.where == inB1 || e.where == inB2 {
+		return nil, false
+	}
+	return e, true
+}
+
+// Contains reports whether key is resident, without recording an access.
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.resident(key)
+	return ok
+}
+
+// Peek returns the value of key without recording an access.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	if e, ok := c.resident(key); ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Get returns the value of key. A hit promotes the key to the front of T2.
+// The complexity is O(1).
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.resident(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.moveTo(e, inT2)
+	return e.value, true
+}
+
+// Put sets the value of key. A key found in a ghost list adapts the target size of T1 before being made resident.
+// The complexity is O(1).
+func (c *Cache[K, V]) Put(key K, value V) {
+	e, ok := c.items[key]
+	if ok {
+		switch e.where {
+		case inT1, inT2:
+			e.value = value
+			c.moveTo(e, inT2)
+			return
+
+		case inB1:
+			// recency would have kept the key: grow T1
+			delta := 1
+			if c.b1.Len() < c.b2.Len() {
+				delta = c.b2.Len() / c.b1.Len()
+			}
+			c.p = min(c.capacity, c.p+delta)
+			c.replace(false)
+
+		case inB2:
+			// frequency would have kept the key: shrink T1
+			delta := 1
+			if c.b2.Len() < c.b1.Len() {
+				delta = c.b1.Len() / c.b2.Len()
+			}
+			c.p = max(0, c.p-delta)
+			c.replace(true)
+		}
+		e.value = value
+		c.moveTo(e, inT2)
+		return
+	}
+
+	// a new key: make room in the directory of 2*capacity keys
+	switch l1 := c.t1.Len() + c.b1.Len(); {
+	case l1 == c.capacity:
+		if c.t1.Len() < c.capacity {
+			c.dropGhost(c.b1)
+			c.replace(false)
+		} else {
+			c.evict(c.t1, nil)
+		}
+	case c.Len()+c.b1.Len()+c.b2.Len() >= c.capacity:
+		if c.Len()+c.b1.Len()+c.b2.Len() >= 2*c.capacity {
+			c.dropGhost(c.b2)
+		}
+		c.replace(false)
+	}
+
+	c.t1.PushFront(key)
+	c.items[key] = &entry[K, V]{value: value, node: c.t1.FrontCursor(), where: inT1}
+}
+
+// Remove removes key from the cache, ghost lists included. The eviction callback is not called.
+// Return false if key is not resident.
+// The complexity is O(1).
+func (c *Cache[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	e.node.Remove(linkedlist.AdvanceNext)
+	delete(c.items, key)
+	return e.where == inT1 || e.where == inT2
+}
+
+// Purge removes every entry from the cache, ghost lists included. The eviction callback is not called.
+func (c *Cache[K, V]) Purge() {
+	c.p = 0
+	c.t1.Init()
+	c.t2.Init()
+	c.b1.Init()
+	c.b2.Init()
+	c.items = make(map[K]*entry[K, V], 2*c.capacity)
+}
+
+// moveTo moves e to the front of the list w.
+func (c *Cache[K, V]) moveTo(e *entry[K, V], w where) {
+	c.list(w).AdoptFront(e.node)
+	e.where = w
+}
+
+// replace evicts the back of T1 or T2 into its ghost list, following the target size of T1.
+// inB2 tells whether the key being inserted was found in B2. It does nothing if the cache is not full.
+func (c *Cache[K, V]) replace(inB2 bool) {
+	if c.Len() < c.capacity {
+		return
+	}
+	t1 := c.t1.Len()
+	if t1 > 0 && (t1 > c.p || inB2 && t1 == c.p) {
+		c.evict(c.t1, c.b1)
+	} else if c.t2.Len() > 0 {
+		c.evict(c.t2, c.b2)
+	} else if t1 > 0 {
+		c.evict(c.t1, c.b1)
+	}
+}
+
+// evict evicts the back of the resident list from, and remembers its key in the ghost list ghost, if not nil.
+func (c *Cache[K, V]) evict(from, ghost *linkedlist.List[K]) {
+	back := from.BackCursor()
+	if back.Node() == nil {
+		return
+	}
+	key := back.Value()
+	e := c.items[key]
+	value := e.value
+
+	if ghost == nil {
+		e.node.Remove(linkedlist.AdvanceNext)
+		delete(c.items, key)
+	} else {
+		var zero V
+		e.value = zero
+		if ghost == c.b1 {
+			c.moveTo(e, inB1)
+		} else {
+			c.moveTo(e, inB2)
+		}
+	}
+
+	if c.onEvict != nil {
+		c.onEvict(key, value)
+	}
+}
+
+// dropGhost forgets the least recent key of a ghost list.
+func (c *Cache[K, V]) dropGhost(ghost *linkedlist.List[K]) {
+	if n := ghost.PopBack(); n != nil {
+		delete(c.items, n.Value)
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,208 @@
+package arc
+
+import (
+	"fmt"
+	"testing"
+)
+
+// checkCache verifies the ARC invariants of c: the resident and directory size bounds, the target size range,
+// and every key being linked in the list its entry points to.
+func checkCache[K comparable, V any](t *testing.T, c *Cache[K, V]) {
+	t.Helper()
+
+	if c.Len() > c.capacity {
+		t.Errorf("|T1|+|T2| = %d, want <= %d", c.Len(), c.capacity)
+	}
+	if l1 := c.t1.Len() + c.b1.Len(); l1 > c.capacity {
+		t.Errorf("|T1|+|B1| = %d, want <= %d", l1, c.capacity)
+	}
+	if all := c.Len() + c.b1.Len() + c.b2.Len(); all > 2*c.capacity {
+		t.Errorf("directory size = %d, want <= %d", all, 2*c.capacity)
+	}
+	if c.p < 0 || c.p > c.capacity {
+		t.Errorf("p = %d, want in [0, %d]", c.p, c.capacity)
+	}
+
+	n := 0
+	for _, w := range []where{inT1, inT2, inB1, inB2} {
+		k := c.list(w).Cursor()
+		for k.MoveNext() != nil {
+			e, ok := c.items[k.Value()]
+			if !ok {
+				t.Errorf("key %v is in list %d but not in the map", k.Value(), w)
+				continue
+			}
+			if e.where != w || !e.node.Equal(k) {
+				t.Errorf("entry of key %v does not point to its node in list %d", k.Value(), w)
+			}
+			n++
+		}
+	}
+	if n != len(c.items) {
+		t.Errorf("lists hold %d keys, map holds %d", n, len(c.items))
+	}
+}
+
+func TestCache(t *testing.T) {
+	c := New[string, int](2, nil)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	checkCache(t, c)
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("c.Get(a) = %v, %v, want 1, true", v, ok)
+	}
+	if _, ok := c.Get("z"); ok {
+		t.Errorf("c.Get(z) = _, true, want false")
+	}
+	if c.items["a"].where != inT2 {
+		t.Errorf("a hit did not move the key to T2")
+	}
+
+	// b was seen once: it is evicted to B1
+	c.Put("c", 3)
+	checkCache(t, c)
+	if c.Contains("b") || !c.Contains("a") || !c.Contains("c") {
+		t.Errorf("c.Put(c) evicted the wrong key")
+	}
+	if c.items["b"].where != inB1 {
+		t.Errorf("b was not remembered in B1")
+	}
+	if _, ok := c.Peek("b"); ok {
+		t.Errorf("c.Peek(b) = _, true for a ghost key")
+	}
+
+	c.Put("a", 10)
+	if v, _ := c.Peek("a"); v != 10 {
+		t.Errorf("c.Peek(a) = %d, want 10", v)
+	}
+
+	if !c.Remove("a") || c.Remove("a") {
+		t.Errorf("c.Remove(a) twice = false/true, want true/false")
+	}
+	if c.Remove("b") {
+		t.Errorf("c.Remove(b) = true for a ghost key")
+	}
+	checkCache(t, c)
+	if c.Len() != 1 || c.Cap() != 2 {
+		t.Errorf("c.Len(), c.Cap() = %d, %d, want 1, 2", c.Len(), c.Cap())
+	}
+
+	c.Purge()
+	checkCache(t, c)
+	if c.Len() != 0 || len(c.items) != 0 {
+		t.Errorf("c.Len() = %d after Purge, want 0", c.Len())
+	}
+}
+
+func TestCacheGhostHit(t *testing.T) {
+	c := New[int, int](4, nil)
+	for i := 0; i < 4; i++ {
+		c.Put(i, i)
+	}
+	c.Get(3)
+
+	// T1 holds 0, 1 and 2 while the cache is full: its least recent key goes to B1
+	c.Put(4, 4)
+	checkCache(t, c)
+	if e, ok := c.items[0]; !ok || e.where != inB1 {
+		t.Fatalf("key 0 was not evicted to B1")
+	}
+
+	// a hit in B1 grows the target size of T1 and makes the key frequent
+	c.Put(0, 0)
+	checkCache(t, c)
+	if c.p != 1 {
+		t.Errorf("c.p = %d after a B1 hit, want 1", c.p)
+	}
+	if c.items[0].where != inT2 {
+		t.Errorf("a B1 hit did not move the key to T2")
+	}
+
+	// push 0 out of T2 with frequent keys, then hit it in B2: the target size shrinks
+	for i := 10; c.items[0].where != inB2; i++ {
+		c.Put(i, i)
+		c.Get(i)
+	}
+	checkCache(t, c)
+	p := c.p
+	c.Put(0, 0)
+	checkCache(t, c)
+	if p > 0 && c.p >= p {
+		t.Errorf("c.p = %d after a B2 hit, want < %d", c.p, p)
+	}
+	if c.items[0].where != inT2 {
+		t.Errorf("a B2 hit did not move the key to T2")
+	}
+}
+
+func TestCacheScanResistance(t *testing.T) {
+	c := New[string, int](8, nil)
+
+	hot := []string{"h0", "h1", "h2", "h3"}
+	for i := 0; i < 3; i++ {
+		for _, k := range hot {
+			if _, ok := c.Get(k); !ok {
+				c.Put(k, 0)
+			}
+		}
+	}
+
+	// a long scan of keys used once only flows through T1
+	for i := 0; i < 100; i++ {
+		c.Put(fmt.Sprint("scan", i), i)
+		checkCache(t, c)
+	}
+	for _, k := range hot {
+		if !c.Contains(k) {
+			t.Errorf("hot key %s was flushed by a scan", k)
+		}
+	}
+}
+
+func TestCacheEvictCallback(t *testing.T) {
+	var evicted []string
+	c := New(2, func(k string, v int) { evicted = append(evicted, k) })
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Remove("a") // not an eviction
+	c.Put("c", 3)
+	c.Put("d", 4)
+
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Errorf("evicted = %v, want [b]", evicted)
+	}
+	checkCache(t, c)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(0) did not panic")
+		}
+	}()
+	New[int, int](0, nil)
+}
+
+func TestCacheRandom(t *testing.T) {
+	c := New[int, int](16, nil)
+	x := uint32(1)
+	for i := 0; i < 5000; i++ {
+		x = x*1664525 + 1013904223
+		k := int(x>>16) % 48
+		switch x >> 30 {
+		case 0:
+			c.Remove(k)
+		default:
+			if v, ok := c.Get(k); ok && v != k {
+				t.Fatalf("c.Get(%d) = %d", k, v)
+			} else if !ok {
+				c.Put(k, k)
+			}
+		}
+		if i%97 == 0 {
+			checkCache(t, c)
+		}
+	}
+	checkCache(t, c)
+}
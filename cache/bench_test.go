@@ -0,0 +1,66 @@
+package cache_test
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/nnhatnam/skale/cache"
+	"github.com/nnhatnam/skale/cache/arc"
+	"github.com/nnhatnam/skale/cache/lfu"
+	"github.com/nnhatnam/skale/cache/twoq"
+)
+
+var (
+	_ cache.Cache[string, int] = (*lfu.Cache[string, int])(nil)
+	_ cache.Cache[string, int] = (*arc.Cache[string, int])(nil)
+	_ cache.Cache[string, int] = (*twoq.Cache[string, int])(nil)
+)
+
+// traces selects the access logs replayed by BenchmarkTrace, e.g.
+//
+//	go test ./cache -run '^$' -bench Trace -traces '/data/traces/*.trace'
+var traces = flag.String("traces", "testdata/*.trace", "glob of the access traces replayed by BenchmarkTrace")
+
+// policies are the caches compared by BenchmarkTrace.
+var policies = []struct {
+	name string
+	new  func(capacity int) cache.Cache[string, int]
+}{
+	{"lfu", func(n int) cache.Cache[string, int] { return lfu.New[string, int](n, nil) }},
+	{"arc", func(n int) cache.Cache[string, int] { return arc.New[string, int](n, nil) }},
+	{"2q", func(n int) cache.Cache[string, int] { return twoq.New[string, int](n, nil) }},
+}
+
+// BenchmarkTrace replays every trace against every policy and a range of capacities,
+// and reports the hit ratio next to the time per replay.
+func BenchmarkTrace(b *testing.B) {
+	files, err := filepath.Glob(*traces)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if len(files) == 0 {
+		b.Skipf("no trace matches %q", *traces)
+	}
+
+	load := func(string) int { return 0 }
+	for _, file := range files {
+		keys, err := cache.ReadTraceFile(file)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, capacity := range []int{16, 64, 256} {
+			for _, p := range policies {
+				name := fmt.Sprintf("%s/cap=%d/%s", filepath.Base(file), capacity, p.name)
+				b.Run(name, func(b *testing.B) {
+					var s cache.Stats
+					for i := 0; i < b.N; i++ {
+						s = cache.Replay(p.new(capacity), keys, load)
+					}
+					b.ReportMetric(100*s.HitRatio(), "hit%")
+				})
+			}
+		}
+	}
+}
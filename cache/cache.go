@@ -0,0 +1,30 @@
+// Package cache defines the interface shared by the skale cache implementations, and helpers to replay
+// recorded access traces against them.
+//
+// The implementations live in sub-packages:
+//
+//	cache/lfu   least frequently used, O(1)
+//	cache/arc   adaptive replacement cache
+//	cache/twoq  2Q
+//	cache/ttl   entries expiring after a time to live
+package cache
+
+// Cache is a fixed size key-value cache with a replacement policy.
+type Cache[K comparable, V any] interface {
+	// Get returns the value of key and records the access.
+	Get(key K) (V, bool)
+	// Put sets the value of key, evicting an entry if the cache is full.
+	Put(key K, value V)
+	// Remove removes key from the cache and reports whether it was present.
+	Remove(key K) bool
+	// Contains reports whether key is in the cache, without recording an access.
+	Contains(key K) bool
+	// Peek returns the value of key, without recording an access.
+	Peek(key K) (V, bool)
+	// Len returns the number of entries in the cache.
+	Len() int
+	// Cap returns the maximum number of entries of the cache.
+	Cap() int
+	// Purge removes every entry from the cache.
+	Purge()
+}
@@ -0,0 +1,259 @@
+// Package lfu implements a least frequently used (LFU) cache with O(1) operations.
+//
+// Entries are grouped in frequency buckets. The buckets are kept in a linked list ordered by frequency, and each bucket
+// holds a linked list of the keys that have been accessed that many times. An access moves the key node to the next
+// bucket, so Get, Put, Remove and eviction all run in constant time.
+//
+// Structure is not thread safe.
+package lfu
+
+import "github.com/nnhatnam/skale/list/linkedlist"
+
+// TieBreak selects the entry to evict among the entries with the lowest frequency.
+type TieBreak int
+
+const (
+	// EvictLRU evicts the least recently used entry of the lowest frequency.
+	EvictLRU TieBreak = iota
+	// EvictMRU evicts the most recently used entry of the lowest frequency.
+	EvictMRU
+)
+
+// Options configures a Cache.
+type Options struct {
+	// TieBreak selects the entry to evict among the least frequently used ones. The default is EvictLRU.
+	TieBreak TieBreak
+
+	// AgingPeriod, if positive, halves every frequency each AgingPeriod accesses (Get hits and Put),
+	// so that entries that were hot a long time ago can be evicted. Zero disables aging.
+	AgingPeriod int
+}
+
+// bucket holds the keys accessed freq times, most recently used first.
+type bucket[K comparable] struct {
+	freq int
+	keys linkedlist.List[K]
+}
+
+// entry is the cached value of a key, with cursors to its bucket and to its node in the bucket.
+type entry[K comparable, V any] struct {
+	value  V
+	bucket *linkedlist.Cursor[*bucket[K]]
+	node   *linkedlist.Cursor[K]
+}
+
+// Cache is a fixed size LFU cache.
+type Cache[K comparable, V any] struct {
+	capacity int
+	onEvict  func(key K, value V)
+	opts     Options
+
+	items   map[K]*entry[K, V]
+	buckets *linkedlist.List[*bucket[K]] // ordered by ascending frequency
+	ticks   int                          // accesses since the last aging
+}
+
+// New returns an empty LFU cache that holds at most capacity entries, with the default options.
+// onEvict, if not nil, is called with every entry evicted to make room for a new one.
+// It panics if capacity is not positive.
+func New[K comparable, V any](capacity int, onEvict func(key K, value V)) *Cache[K, V] {
+	return NewWithOptions(capacity, onEvict, Options{})
+}
+
+// NewWithOptions is like New but configures the cache with opts.
+func NewWithOptions[K comparable, V any](capacity int, onEvict func(key K, value V), opts Options) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("lfu: capacity must be positive")
+	}
+	return &Cache[K, V]{
+		capacity: capacity,
+		onEvict:  onEvict,
+		opts:     opts,
+		items:    make(map[K]*entry[K, V], capacity),
+		buckets:  linkedlist.New[*bucket[K]](),
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Cap returns the maximum number of entries of the cache.
+func (c *Cache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Contains reports whether key is in the cache, without counting an access.
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.items[key]
+	return ok
+}
+
+// Peek returns the value of key without counting an access.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	if e, ok := c.items[key]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Frequency returns the access count of key, as adjusted by aging.
+func (c *Cache[K, V]) Frequency(key K) (int, bool) {
+	if e, ok := c.items[key]; ok {
+		return e.bucket.Value().freq, true
+	}
+	return 0, false
+}
+
+// Get returns the value of key and counts an access.
+// The complexity is O(1).
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.touch(e)
+	c.tick()
+	return e.value, true
+}
+
+// Put sets the value of key and counts an access. If the cache is full, the least frequently used entry is evicted first.
+// The complexity is O(1).
+func (c *Cache[K, V]) Put(key K, value V) {
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.touch(e)
+		c.tick()
+		return
+	}
+
+	if len(c.items) >= c.capacity {
+		c.evict()
+	}
+
+	// new keys go to the bucket of frequency 1, at the front of the bucket list
+	first := c.buckets.FrontCursor()
+	if c.buckets.Len() == 0 || first.Value().freq != 1 {
+		c.buckets.PushFront(&bucket[K]{freq: 1})
+		first = c.buckets.FrontCursor()
+	}
+	b := first.Value()
+	b.keys.PushFront(key)
+
+	c.items[key] = &entry[K, V]{value: value, bucket: first, node: b.keys.FrontCursor()}
+	c.tick()
+}
+
+// Remove removes key from the cache. The eviction callback is not called. Return false if key is not in the cache.
+// The complexity is O(1).
+func (c *Cache[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.unlink(e)
+	delete(c.items, key)
+	return true
+}
+
+// Purge removes every entry from the cache. The eviction callback is not called.
+func (c *Cache[K, V]) Purge() {
+	c.items = make(map[K]*entry[K, V], c.capacity)
+	c.buckets.Init()
+	c.ticks = 0
+}
+
+// unlink removes the node of e from its bucket, and the bucket from the bucket list if it becomes empty.
+func (c *Cache[K, V]) unlink(e *entry[K, V]) {
+	b := e.bucket.Value()
+	e.node.Remove(linkedlist.AdvanceNext)
+	if b.keys.Len() == 0 {
+		c.buckets.RemoveAt(e.bucket)
+	}
+}
+
+// touch moves e to the bucket of the next frequency.
+func (c *Cache[K, V]) touch(e *entry[K, V]) {
+	b := e.bucket.Value()
+	next := e.bucket.CloneNext()
+	if next.Node() == nil || next.Value().freq != b.freq+1 {
+		c.buckets.InsertAfter(&bucket[K]{freq: b.freq + 1}, e.bucket)
+		next = e.bucket.CloneNext()
+	}
+
+	next.Value().keys.AdoptFront(e.node)
+	if b.keys.Len() == 0 {
+		c.buckets.RemoveAt(e.bucket)
+	}
+	e.bucket = next
+}
+
+// evict removes the entry chosen by the tie-break policy among the least frequently used ones.
+func (c *Cache[K, V]) evict() {
+	first := c.buckets.FrontCursor()
+	if first.Node() == nil {
+		return
+	}
+	b := first.Value()
+
+	var key K
+	if c.opts.TieBreak == EvictMRU {
+		key = b.keys.Front().Value
+	} else {
+		key = b.keys.Back().Value
+	}
+
+	e := c.items[key]
+	c.unlink(e)
+	delete(c.items, key)
+	if c.onEvict != nil {
+		c.onEvict(key, e.value)
+	}
+}
+
+// tick counts an access, and ages the cache when the aging period is reached.
+func (c *Cache[K, V]) tick() {
+	if c.opts.AgingPeriod <= 0 {
+		return
+	}
+	c.ticks++
+	if c.ticks >= c.opts.AgingPeriod {
+		c.Age()
+	}
+}
+
+// Age halves the frequency of every entry, keeping it at least 1. Buckets that end up with the same frequency are merged,
+// the keys of the formerly more frequent bucket being considered more recently used.
+// It is called automatically when Options.AgingPeriod is set.
+// The complexity is O(n).
+func (c *Cache[K, V]) Age() {
+	c.ticks = 0
+
+	var prev *linkedlist.Cursor[*bucket[K]]
+	cur := c.buckets.FrontCursor()
+	for cur.Node() != nil {
+		b := cur.Value()
+		b.freq /= 2
+		if b.freq < 1 {
+			b.freq = 1
+		}
+
+		if prev == nil || prev.Value().freq != b.freq {
+			prev = cur.Clone()
+			cur.MoveNext()
+			continue
+		}
+
+		// merge b into prev: b's keys go to the front, in order
+		into := prev.Value()
+		for k := b.keys.BackCursor(); k.Node() != nil; k = b.keys.BackCursor() {
+			e := c.items[k.Value()]
+			into.keys.AdoptFront(e.node)
+			e.bucket = prev.Clone()
+		}
+		c.buckets.RemoveAt(cur) // cur moves to the next bucket
+	}
+}
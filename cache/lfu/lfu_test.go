@@ -0,0 +1,199 @@
+package lfu
+
+import "testing"
+
+// checkCache verifies the bucket structure of c: ascending distinct frequencies, no empty bucket,
+// and every entry referencing the bucket and node it is linked in.
+func checkCache[K comparable, V any](t *testing.T, c *Cache[K, V]) {
+	t.Helper()
+
+	n := 0
+	last := 0
+	b := c.buckets.Cursor()
+	for b.MoveNext() != nil {
+		bk := b.Value()
+		if bk.freq <= last {
+			t.Errorf("bucket frequencies not ascending: %d after %d", bk.freq, last)
+		}
+		last = bk.freq
+		if bk.keys.Len() == 0 {
+			t.Errorf("bucket %d is empty", bk.freq)
+		}
+
+		k := bk.keys.Cursor()
+		for k.MoveNext() != nil {
+			e, ok := c.items[k.Value()]
+			if !ok {
+				t.Errorf("key %v is in bucket %d but not in the map", k.Value(), bk.freq)
+				continue
+			}
+			if !e.node.Equal(k) || e.bucket.Value() != bk {
+				t.Errorf("entry of key %v does not point to its node in bucket %d", k.Value(), bk.freq)
+			}
+			n++
+		}
+	}
+
+	if n != c.Len() {
+		t.Errorf("buckets hold %d keys, c.Len() = %d", n, c.Len())
+	}
+}
+
+func TestCache(t *testing.T) {
+	c := New[string, int](2, nil)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	checkCache(t, c)
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("c.Get(a) = %v, %v, want 1, true", v, ok)
+	}
+	if _, ok := c.Get("z"); ok {
+		t.Errorf("c.Get(z) = _, true, want false")
+	}
+
+	// b has the lowest frequency
+	c.Put("c", 3)
+	checkCache(t, c)
+	if c.Contains("b") || !c.Contains("a") || !c.Contains("c") {
+		t.Errorf("c.Put(c) evicted the wrong key")
+	}
+
+	if f, _ := c.Frequency("a"); f != 2 {
+		t.Errorf("c.Frequency(a) = %d, want 2", f)
+	}
+
+	c.Put("a", 10)
+	if v, _ := c.Peek("a"); v != 10 {
+		t.Errorf("c.Peek(a) = %d, want 10", v)
+	}
+	if f, _ := c.Frequency("a"); f != 3 {
+		t.Errorf("c.Frequency(a) = %d after Put, want 3", f)
+	}
+
+	if !c.Remove("a") || c.Remove("a") {
+		t.Errorf("c.Remove(a) twice = false/true, want true/false")
+	}
+	checkCache(t, c)
+	if c.Len() != 1 || c.Cap() != 2 {
+		t.Errorf("c.Len(), c.Cap() = %d, %d, want 1, 2", c.Len(), c.Cap())
+	}
+
+	c.Purge()
+	checkCache(t, c)
+	if c.Len() != 0 {
+		t.Errorf("c.Len() = %d after Purge, want 0", c.Len())
+	}
+}
+
+func TestCacheEvictCallback(t *testing.T) {
+	var evicted []string
+	c := New(2, func(k string, v int) { evicted = append(evicted, k) })
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Remove("a") // not an eviction
+	c.Put("c", 3)
+	c.Put("d", 4)
+
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Errorf("evicted = %v, want [b]", evicted)
+	}
+}
+
+func TestCacheTieBreak(t *testing.T) {
+	lru := New[string, int](3, nil)
+	mru := NewWithOptions[string, int](3, nil, Options{TieBreak: EvictMRU})
+
+	for _, c := range []*Cache[string, int]{lru, mru} {
+		c.Put("a", 1)
+		c.Put("b", 2)
+		c.Put("c", 3)
+		c.Put("d", 4)
+		checkCache(t, c)
+	}
+
+	if lru.Contains("a") || !lru.Contains("c") {
+		t.Errorf("EvictLRU did not evict the least recently used key")
+	}
+	if mru.Contains("c") || !mru.Contains("a") {
+		t.Errorf("EvictMRU did not evict the most recently used key")
+	}
+}
+
+func TestCacheAging(t *testing.T) {
+	c := New[string, int](2, nil)
+	c.Put("hot", 1)
+	for i := 0; i < 9; i++ {
+		c.Get("hot")
+	}
+	c.Put("warm", 2)
+	for i := 0; i < 4; i++ {
+		c.Get("warm")
+	}
+
+	c.Age()
+	checkCache(t, c)
+	if f, _ := c.Frequency("hot"); f != 5 {
+		t.Errorf("c.Frequency(hot) = %d after Age, want 5", f)
+	}
+	if f, _ := c.Frequency("warm"); f != 2 {
+		t.Errorf("c.Frequency(warm) = %d after Age, want 2", f)
+	}
+
+	// buckets that end up with the same frequency are merged
+	c.Age()
+	c.Age()
+	c.Age()
+	checkCache(t, c)
+	if c.buckets.Len() != 1 {
+		t.Errorf("c.buckets.Len() = %d, want 1", c.buckets.Len())
+	}
+
+	// the formerly hotter key is considered more recently used and kept
+	c.Put("new", 3)
+	if !c.Contains("hot") || c.Contains("warm") {
+		t.Errorf("eviction after aging dropped the wrong key")
+	}
+	checkCache(t, c)
+}
+
+func TestCacheAgingPeriod(t *testing.T) {
+	c := NewWithOptions[int, int](2, nil, Options{AgingPeriod: 4})
+	c.Put(1, 1)
+	c.Get(1)
+	c.Get(1)
+	if f, _ := c.Frequency(1); f != 3 {
+		t.Errorf("c.Frequency(1) = %d, want 3", f)
+	}
+	c.Get(1) // 4th access: frequencies are halved
+	if f, _ := c.Frequency(1); f != 2 {
+		t.Errorf("c.Frequency(1) = %d after the aging period, want 2", f)
+	}
+	checkCache(t, c)
+}
+
+func TestCacheCursorsSurviveMoves(t *testing.T) {
+	c := New[int, int](4, nil)
+	for i := 0; i < 4; i++ {
+		c.Put(i, i)
+	}
+	n := c.items[2].node.Node()
+	for i := 0; i < 3; i++ {
+		c.Get(2)
+	}
+
+	// the key node is moved between buckets, never reallocated
+	if c.items[2].node.Node() != n {
+		t.Errorf("the node of key 2 was reallocated")
+	}
+	checkCache(t, c)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(0) did not panic")
+		}
+	}()
+	New[int, int](0, nil)
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Stats holds the outcome of a trace replay.
+type Stats struct {
+	Hits   int
+	Misses int
+}
+
+// Accesses returns the number of replayed accesses.
+func (s Stats) Accesses() int {
+	return s.Hits + s.Misses
+}
+
+// HitRatio returns the fraction of accesses that hit the cache. Return 0 if nothing was replayed.
+func (s Stats) HitRatio() float64 {
+	if s.Accesses() == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Accesses())
+}
+
+// String returns the stats as "hits/accesses (ratio%)".
+func (s Stats) String() string {
+	return fmt.Sprintf("%d/%d (%.2f%%)", s.Hits, s.Accesses(), 100*s.HitRatio())
+}
+
+// ReadTrace reads an access trace: one access per line, the key being the first whitespace separated field.
+// Blank lines and lines starting with '#' are ignored, so traces can carry comments and extra columns.
+func ReadTrace(r io.Reader) ([]string, error) {
+	var keys []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		keys = append(keys, strings.Fields(line)[0])
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ReadTraceFile reads the access trace stored in file name. See ReadTrace for the format.
+func ReadTraceFile(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadTrace(f)
+}
+
+// Replay replays the accesses of a trace against c, as a read-through cache would: every key is looked up with Get,
+// and missing keys are loaded with load and stored with Put.
+func Replay[K comparable, V any](c Cache[K, V], keys []K, load func(key K) V) Stats {
+	var s Stats
+	for _, k := range keys {
+		if _, ok := c.Get(k); ok {
+			s.Hits++
+			continue
+		}
+		s.Misses++
+		c.Put(k, load(k))
+	}
+	return s
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+)
+
+// fifo is a minimal Cache evicting the oldest key, used to test Replay.
+type fifo struct {
+	cap   int
+	keys  []string
+	items map[string]int
+}
+
+func (f *fifo) Get(key string) (int, bool) {
+	v, ok := f.items[key]
+	return v, ok
+}
+
+func (f *fifo) Put(key string, value int) {
+	if _, ok := f.items[key]; !ok {
+		if len(f.keys) == f.cap {
+			delete(f.items, f.keys[0])
+			f.keys = f.keys[1:]
+		}
+		f.keys = append(f.keys, key)
+	}
+	f.items[key] = value
+}
+
+func (f *fifo) Remove(key string) bool      { panic("not used") }
+func (f *fifo) Contains(key string) bool    { panic("not used") }
+func (f *fifo) Peek(key string) (int, bool) { panic("not used") }
+func (f *fifo) Len() int                    { return len(f.items) }
+func (f *fifo) Cap() int                    { return f.cap }
+func (f *fifo) Purge()                      { panic("not used") }
+
+func TestReadTrace(t *testing.T) {
+	in := "# a comment\na 1\n\n  b\tget\nc\n# another\na\n"
+	keys, err := ReadTrace(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadTrace() error = %v", err)
+	}
+	if got, want := strings.Join(keys, ","), "a,b,c,a"; got != want {
+		t.Errorf("ReadTrace() = %v, want %v", got, want)
+	}
+
+	keys, err = ReadTraceFile("testdata/zipf-scan.trace")
+	if err != nil {
+		t.Fatalf("ReadTraceFile() error = %v", err)
+	}
+	if len(keys) == 0 {
+		t.Errorf("ReadTraceFile() read no access")
+	}
+	if _, err := ReadTraceFile("testdata/missing.trace"); err == nil {
+		t.Errorf("ReadTraceFile(missing) error = nil")
+	}
+}
+
+func TestReplay(t *testing.T) {
+	c := &fifo{cap: 2, items: map[string]int{}}
+	loads := 0
+	s := Replay[string, int](c, []string{"a", "b", "a", "c", "a", "b"}, func(string) int {
+		loads++
+		return loads
+	})
+
+	if s.Hits != 1 || s.Misses != 5 || loads != 5 {
+		t.Errorf("Replay() = %+v with %d loads, want 1 hit, 5 misses and 5 loads", s, loads)
+	}
+	if s.Accesses() != 6 {
+		t.Errorf("s.Accesses() = %d, want 6", s.Accesses())
+	}
+	if got, want := s.String(), "1/6 (16.67%)"; got != want {
+		t.Errorf("s.String() = %q, want %q", got, want)
+	}
+	if r := (Stats{}).HitRatio(); r != 0 {
+		t.Errorf("Stats{}.HitRatio() = %v, want 0", r)
+	}
+}
@@ -0,0 +1,350 @@
+// Package ttl implements a cache whose entries expire after a time to live.
+//
+// Entries are kept in a linked list ordered by expiry time, soonest first, so expired entries are always at the front
+// of the list and are collected without scanning the whole cache. Expired entries are removed lazily when they are
+// looked up, by DeleteExpired, and by an optional janitor goroutine that calls DeleteExpired periodically.
+// When the cache is full, the entry that expires the soonest is evicted.
+//
+// Unlike the other caches of skale, Cache is safe for concurrent use, because the janitor runs concurrently with its
+// owner.
+package ttl
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+// Clock tells the current time. It lets tests control the expiry of entries without sleeping.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock of the system, used when Options.Clock is nil.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Options configures a Cache.
+type Options struct {
+	// Clock tells the time used to compute expiry times. The default is SystemClock.
+	Clock Clock
+
+	// JanitorInterval, if positive, starts a goroutine that removes the expired entries at this interval.
+	// The janitor uses real time to wake up, whatever the Clock. It is stopped by Cache.Stop.
+	JanitorInterval time.Duration
+}
+
+// entry is a cached value. A zero expires means the entry never expires.
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+	node    *linkedlist.Cursor[*entry[K, V]]
+}
+
+// expiresBefore reports whether e expires strictly before t. Entries that never expire sort last.
+func (e *entry[K, V]) expiresBefore(t time.Time) bool {
+	if e.expires.IsZero() {
+		return false
+	}
+	return t.IsZero() || e.expires.Before(t)
+}
+
+// expired reports whether e has expired at now.
+func (e *entry[K, V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// Cache is a fixed size cache of entries with a time to live.
+type Cache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	onEvict  func(key K, value V)
+	clock    Clock
+
+	items  map[K]*entry[K, V]
+	expiry *linkedlist.List[*entry[K, V]] // ordered by ascending expiry time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// New returns an empty cache that holds at most capacity entries, each expiring ttl after it was put, with the default
+// options. A ttl that is not positive means that entries do not expire.
+// onEvict, if not nil, is called with every entry that expires or is evicted to make room for a new one. It is called
+// without holding the cache lock, so it may use the cache.
+// It panics if capacity is not positive.
+func New[K comparable, V any](capacity int, ttl time.Duration, onEvict func(key K, value V)) *Cache[K, V] {
+	return NewWithOptions(capacity, ttl, onEvict, Options{})
+}
+
+// NewWithOptions is like New but configures the cache with opts. If opts.JanitorInterval is positive, Stop must be
+// called to release the janitor goroutine.
+func NewWithOptions[K comparable, V any](capacity int, ttl time.Duration, onEvict func(key K, value V), opts Options) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("ttl: capacity must be positive")
+	}
+	clock := opts.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	c := &Cache[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		onEvict:  onEvict,
+		clock:    clock,
+		items:    make(map[K]*entry[K, V], capacity),
+		expiry:   linkedlist.New[*entry[K, V]](),
+		stop:     make(chan struct{}),
+	}
+	if opts.JanitorInterval > 0 {
+		go c.janitor(opts.JanitorInterval)
+	}
+	return c
+}
+
+// janitor removes the expired entries every interval, until the cache is stopped.
+func (c *Cache[K, V]) janitor(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			c.DeleteExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the janitor goroutine. The cache can still be used, expired entries being removed lazily.
+// It does nothing if the cache has no janitor or is already stopped.
+func (c *Cache[K, V]) Stop() {
+	c.stopOnce.Do(func() { close(c.stop) })
+}
+
+// Len returns the number of entries in the cache, including the expired entries that are not removed yet.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Cap returns the maximum number of entries of the cache.
+func (c *Cache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// lookup returns the entry of key if it has not expired. An expired entry is removed and appended to evicted.
+func (c *Cache[K, V]) lookup(key K, evicted []*entry[K, V]) (*entry[K, V], []*entry[K, V]) {
+	e, ok := c.items[key]
+	if !ok {
+		return nil, evicted
+	}
+	if e.expired(c.clock.Now()) {
+		c.unlink(e)
+		return nil, append(evicted, e)
+	}
+	return e, evicted
+}
+
+// Get returns the value of key. An expired entry is removed and reported as missing.
+// The complexity is O(1).
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	e, evicted := c.lookup(key, nil)
+	var v V
+	if e != nil {
+		v = e.value
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	return v, e != nil
+}
+
+// Peek is like Get. Reading a value does not change its expiry, so both are the same for this cache.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	return c.Get(key)
+}
+
+// Contains reports whether key is in the cache and has not expired.
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.Get(key)
+	return ok
+}
+
+// TTL returns the time left before key expires, and 0 if key never expires.
+// Return false if key is not in the cache or has expired.
+func (c *Cache[K, V]) TTL(key K) (time.Duration, bool) {
+	c.mu.Lock()
+	e, evicted := c.lookup(key, nil)
+	var d time.Duration
+	if e != nil && !e.expires.IsZero() {
+		d = e.expires.Sub(c.clock.Now())
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	return d, e != nil
+}
+
+// Put sets the value of key, expiring after the ttl of the cache. See PutWithTTL.
+func (c *Cache[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.ttl)
+}
+
+// PutWithTTL sets the value of key, expiring ttl from now. A ttl that is not positive means that the entry does not
+// expire. If the cache is full, the expired entries are removed, then, if none was, the entry that expires the soonest
+// is evicted.
+// The complexity is O(1) when the entries are put with the same ttl, O(n) in the worst case.
+func (c *Cache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	now := c.clock.Now()
+	expires := c.expiresAt(now, ttl)
+
+	var evicted []*entry[K, V]
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		e.expires = expires
+		c.reorder(e)
+	} else {
+		if len(c.items) >= c.capacity {
+			evicted = c.deleteExpired(now, evicted)
+		}
+		if len(c.items) >= c.capacity {
+			front := c.expiry.Front().Value
+			c.unlink(front)
+			evicted = append(evicted, front)
+		}
+		e := &entry[K, V]{key: key, value: value, expires: expires}
+		c.link(e)
+		c.items[key] = e
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+}
+
+// Extend resets the time to live of key to ttl from now. A ttl that is not positive means that the entry does not
+// expire anymore. Return false if key is not in the cache or has expired.
+// The complexity is O(1) when the entries are put with the same ttl, O(n) in the worst case.
+func (c *Cache[K, V]) Extend(key K, ttl time.Duration) bool {
+	c.mu.Lock()
+	e, evicted := c.lookup(key, nil)
+	if e != nil {
+		e.expires = c.expiresAt(c.clock.Now(), ttl)
+		c.reorder(e)
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	return e != nil
+}
+
+// Remove removes key from the cache. The eviction callback is not called. Return false if key is not in the cache.
+// The complexity is O(1).
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.unlink(e)
+	return true
+}
+
+// Purge removes every entry from the cache. The eviction callback is not called.
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[K]*entry[K, V], c.capacity)
+	c.expiry.Init()
+}
+
+// DeleteExpired removes the expired entries and returns how many were removed.
+// The complexity is O(k), where k is the number of expired entries.
+func (c *Cache[K, V]) DeleteExpired() int {
+	c.mu.Lock()
+	evicted := c.deleteExpired(c.clock.Now(), nil)
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	return len(evicted)
+}
+
+// deleteExpired removes the entries expired at now from the front of the expiry list, and appends them to evicted.
+func (c *Cache[K, V]) deleteExpired(now time.Time, evicted []*entry[K, V]) []*entry[K, V] {
+	for n := c.expiry.Front(); n != nil && n.Value.expired(now); n = c.expiry.Front() {
+		c.unlink(n.Value)
+		evicted = append(evicted, n.Value)
+	}
+	return evicted
+}
+
+// expiresAt returns the expiry time of an entry put at now with ttl.
+func (c *Cache[K, V]) expiresAt(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
+
+// position returns a cursor to the last entry, other than skip, that expires before or with e, walking from the back
+// of the expiry list. The cursor points to the sentinel if e expires before every other entry.
+func (c *Cache[K, V]) position(e, skip *entry[K, V]) *linkedlist.Cursor[*entry[K, V]] {
+	cur := c.expiry.Cursor()
+	for n := cur.MovePrev(); n != nil; n = cur.MovePrev() {
+		if n.Value != skip && !e.expiresBefore(n.Value.expires) {
+			break
+		}
+	}
+	return cur
+}
+
+// link inserts e in the expiry list, after the entries expiring before or with it.
+func (c *Cache[K, V]) link(e *entry[K, V]) {
+	mark := c.position(e, nil)
+	if mark.Node() == nil {
+		c.expiry.PushFront(e)
+		e.node = c.expiry.FrontCursor()
+		return
+	}
+	c.expiry.InsertAfter(e, mark)
+	e.node = mark.CloneNext()
+}
+
+// reorder moves e to its place in the expiry list after its expiry time changed.
+func (c *Cache[K, V]) reorder(e *entry[K, V]) {
+	mark := c.position(e, e)
+	if mark.Node() == nil {
+		c.expiry.MoveToFront(e.node)
+	} else {
+		c.expiry.MoveAfter(e.node, mark)
+	}
+}
+
+// unlink removes e from the expiry list and the map.
+func (c *Cache[K, V]) unlink(e *entry[K, V]) {
+	e.node.Remove(linkedlist.AdvanceNext)
+	delete(c.items, e.key)
+}
+
+// notify calls the eviction callback with the evicted entries. It must be called without holding the lock.
+func (c *Cache[K, V]) notify(evicted []*entry[K, V]) {
+	if c.onEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		c.onEvict(e.key, e.value)
+	}
+}
@@ -0,0 +1,223 @@
+package ttl
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nnhatnam/skale/cache"
+)
+
+var _ cache.Cache[string, int] = (*Cache[string, int])(nil)
+
+// fakeClock is a Clock that only moves when advanced.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// checkCache verifies that the expiry list of c is ordered, never expiring entries last, and matches the map.
+func checkCache[K comparable, V any](t *testing.T, c *Cache[K, V]) {
+	t.Helper()
+
+	n := 0
+	var last *entry[K, V]
+	cur := c.expiry.Cursor()
+	for cur.MoveNext() != nil {
+		e := cur.Value()
+		if last != nil && e.expiresBefore(last.expires) {
+			t.Errorf("entry %v expires before %v but follows it", e.key, last.key)
+		}
+		if c.items[e.key] != e || !e.node.Equal(cur) {
+			t.Errorf("entry of key %v does not point to its node", e.key)
+		}
+		last = e
+		n++
+	}
+	if n != len(c.items) {
+		t.Errorf("expiry list holds %d entries, map holds %d", n, len(c.items))
+	}
+}
+
+func TestCache(t *testing.T) {
+	clock := newFakeClock()
+	c := NewWithOptions[string, int](4, time.Minute, nil, Options{Clock: clock})
+
+	c.Put("a", 1)
+	clock.Advance(20 * time.Second)
+	c.Put("b", 2)
+	checkCache(t, c)
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("c.Get(a) = %v, %v, want 1, true", v, ok)
+	}
+	if d, ok := c.TTL("a"); !ok || d != 40*time.Second {
+		t.Errorf("c.TTL(a) = %v, %v, want 40s, true", d, ok)
+	}
+
+	// a expires, b does not: lazy expiry on Get
+	clock.Advance(40 * time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("c.Get(a) = _, true after expiry")
+	}
+	if c.Len() != 1 || !c.Contains("b") {
+		t.Errorf("c.Len() = %d after lazy expiry, want 1", c.Len())
+	}
+	checkCache(t, c)
+
+	if !c.Remove("b") || c.Remove("b") {
+		t.Errorf("c.Remove(b) twice = false/true, want true/false")
+	}
+	c.Put("c", 3)
+	c.Purge()
+	if c.Len() != 0 || c.Cap() != 4 {
+		t.Errorf("c.Len(), c.Cap() = %d, %d after Purge, want 0, 4", c.Len(), c.Cap())
+	}
+	checkCache(t, c)
+}
+
+func TestCacheOrder(t *testing.T) {
+	clock := newFakeClock()
+	c := NewWithOptions[string, int](8, time.Minute, nil, Options{Clock: clock})
+
+	c.PutWithTTL("forever", 0, 0)
+	c.PutWithTTL("long", 1, time.Hour)
+	c.Put("minute", 2)
+	c.PutWithTTL("short", 3, time.Second)
+	checkCache(t, c)
+
+	if got := c.expiry.Front().Value.key; got != "short" {
+		t.Errorf("front of the expiry list = %s, want short", got)
+	}
+	if got := c.expiry.Back().Value.key; got != "forever" {
+		t.Errorf("back of the expiry list = %s, want forever", got)
+	}
+	if d, ok := c.TTL("forever"); !ok || d != 0 {
+		t.Errorf("c.TTL(forever) = %v, %v, want 0, true", d, ok)
+	}
+
+	// updating a value resets its expiry
+	c.Put("short", 4)
+	checkCache(t, c)
+	if d, _ := c.TTL("short"); d != time.Minute {
+		t.Errorf("c.TTL(short) = %v after Put, want 1m", d)
+	}
+
+	clock.Advance(2 * time.Hour)
+	if n := c.DeleteExpired(); n != 3 {
+		t.Errorf("c.DeleteExpired() = %d, want 3", n)
+	}
+	if !c.Contains("forever") || c.Len() != 1 {
+		t.Errorf("c.DeleteExpired() removed an entry that does not expire")
+	}
+	checkCache(t, c)
+}
+
+func TestCacheExtend(t *testing.T) {
+	clock := newFakeClock()
+	c := NewWithOptions[string, int](4, time.Minute, nil, Options{Clock: clock})
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	clock.Advance(50 * time.Second)
+	if !c.Extend("a", time.Minute) {
+		t.Errorf("c.Extend(a) = false, want true")
+	}
+	checkCache(t, c)
+	if got := c.expiry.Back().Value.key; got != "a" {
+		t.Errorf("back of the expiry list = %s after Extend, want a", got)
+	}
+
+	clock.Advance(30 * time.Second)
+	if c.Contains("b") || !c.Contains("a") {
+		t.Errorf("Extend did not keep a alive past b")
+	}
+	if c.Extend("b", time.Minute) || c.Extend("z", time.Minute) {
+		t.Errorf("c.Extend() = true for an expired or missing key")
+	}
+
+	if !c.Extend("a", 0) {
+		t.Errorf("c.Extend(a, 0) = false, want true")
+	}
+	clock.Advance(time.Hour)
+	if !c.Contains("a") {
+		t.Errorf("a expired after being extended forever")
+	}
+	checkCache(t, c)
+}
+
+func TestCacheEvict(t *testing.T) {
+	clock := newFakeClock()
+	var evicted []string
+	c := NewWithOptions(2, time.Minute, func(k string, v int) { evicted = append(evicted, k) }, Options{Clock: clock})
+
+	// a full cache evicts the entry that expires the soonest
+	c.PutWithTTL("a", 1, time.Hour)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	checkCache(t, c)
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Errorf("evicted = %v, want [b]", evicted)
+	}
+
+	// expired entries are collected first, and reported
+	clock.Advance(2 * time.Minute)
+	c.Put("d", 4)
+	if len(evicted) != 2 || evicted[1] != "c" || !c.Contains("a") {
+		t.Errorf("evicted = %v, want [b c]", evicted)
+	}
+
+	c.Remove("a") // not an eviction
+	if len(evicted) != 2 {
+		t.Errorf("evicted = %v after Remove, want [b c]", evicted)
+	}
+	checkCache(t, c)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(0) did not panic")
+		}
+	}()
+	New[int, int](0, time.Minute, nil)
+}
+
+func TestCacheJanitor(t *testing.T) {
+	clock := newFakeClock()
+	expired := make(chan string, 1)
+	c := NewWithOptions(2, time.Minute, func(k string, v int) { expired <- k }, Options{
+		Clock:           clock,
+		JanitorInterval: time.Millisecond,
+	})
+	defer c.Stop()
+
+	c.Put("a", 1)
+	clock.Advance(time.Hour)
+
+	select {
+	case k := <-expired:
+		if k != "a" {
+			t.Errorf("the janitor expired %s, want a", k)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the janitor did not remove the expired entry")
+	}
+
+	c.Stop()
+	c.Stop() // no-op
+}
@@ -0,0 +1,201 @@
+// Package twoq implements the 2Q cache replacement policy of Johnson and Shasha.
+//
+// New keys enter A1in, a FIFO of resident entries. Keys evicted from A1in are remembered, without their values,
+// in the ghost FIFO A1out. A key that is requested again while in A1out has proven to be reused and is promoted
+// to Am, an LRU list holding the bulk of the cache. A scan only flows through A1in and cannot flush Am.
+//
+// Structure is not thread safe.
+package twoq
+
+import "github.com/nnhatnam/skale/list/linkedlist"
+
+const (
+	// DefaultRecentRatio is the default share of the capacity given to A1in.
+	DefaultRecentRatio = 0.25
+	// DefaultGhostRatio is the default size of A1out, relative to the capacity.
+	DefaultGhostRatio = 0.5
+)
+
+// where identifies the list an entry is linked in.
+type where int
+
+const (
+	inA1in where = iota
+	inA1out
+	inAm
+)
+
+// entry is a key tracked by the cache. value is only meaningful for resident entries (A1in and Am).
+type entry[K comparable, V any] struct {
+	value V
+	node  *linkedlist.Cursor[K]
+	where where
+}
+
+// Cache is a fixed size 2Q cache. Every list keeps its newest key at the front.
+type Cache[K comparable, V any] struct {
+	capacity int
+	kin      int // target size of A1in
+	kout     int // maximum size of A1out
+	onEvict  func(key K, value V)
+
+	a1in, a1out, am *linkedlist.List[K]
+	items           map[K]*entry[K, V]
+}
+
+// New returns an empty 2Q cache that holds at most capacity entries, with the default ratios.
+// onEvict, if not nil, is called with every resident entry evicted to make room for a new one.
+// It panics if capacity is not positive.
+func New[K comparable, V any](capacity int, onEvict func(key K, value V)) *Cache[K, V] {
+	return NewWithRatios(capacity, onEvict, DefaultRecentRatio, DefaultGhostRatio)
+}
+
+// NewWithRatios is like New but sizes A1in to recentRatio*capacity and A1out to ghostRatio*capacity.
+// It panics if capacity is not positive, or if a ratio is negative or recentRatio is greater than 1.
+func NewWithRatios[K comparable, V any](capacity int, onEvict func(key K, value V), recentRatio, ghostRatio float64) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("twoq: capacity must be positive")
+	}
+	if recentRatio < 0 || recentRatio > 1 || ghostRatio < 0 {
+		panic("twoq: invalid ratio")
+	}
+	return &Cache[K, V]{
+		capacity: capacity,
+		kin:      int(float64(capacity) * recentRatio),
+		kout:     int(float64(capacity) * ghostRatio),
+		onEvict:  onEvict,
+		a1in:     linkedlist.New[K](),
+		a1out:    linkedlist.New[K](),
+		am:       linkedlist.New[K](),
+		items:    make(map[K]*entry[K, V], capacity),
+	}
+}
+
+// Len returns the number of resident entries.
+func (c *Cache[K, V]) Len() int {
+	return c.a1in.Len() + c.am.Len()
+}
+
+// Cap returns the maximum number of resident entries.
+func (c *Cache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// resident returns the entry of key if it is resident.
+func (c *Cache[K, V]) resident(key K) (*entry[K, V], bool) {
+	e, ok := c.items[key]
+	if !ok || e.where == inA1out {
+		return nil, false
+	}
+	return e, true
+}
+
+// Contains reports whether key is resident, without recording an access.
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.resident(key)
+	return ok
+}
+
+// Peek returns the value of key without recording an access.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	if e, ok := c.resident(key); ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Get returns the value of key. A hit in Am moves the key to the front of Am, a hit in A1in leaves it in place.
+// The complexity is O(1).
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.resident(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if e.where == inAm {
+		c.am.MoveToFront(e.node)
+	}
+	return e.value, true
+}
+
+// Put sets the value of key. A new key enters A1in, a key remembered in A1out is promoted to Am.
+// The complexity is O(1).
+func (c *Cache[K, V]) Put(key K, value V) {
+	e, ok := c.items[key]
+	if ok && e.where != inA1out {
+		if e.where == inAm {
+			c.am.MoveToFront(e.node)
+		}
+		e.value = value
+		return
+	}
+
+	// the ghost is dropped before reclaiming, so that paging A1in out cannot push it out of A1out meanwhile
+	to, w := c.a1in, inA1in
+	if ok {
+		e.node.Remove(linkedlist.AdvanceNext)
+		delete(c.items, key)
+		to, w = c.am, inAm
+	}
+
+	c.reclaim()
+	to.PushFront(key)
+	c.items[key] = &entry[K, V]{value: value, node: to.FrontCursor(), where: w}
+}
+
+// Remove removes key from the cache, A1out included. The eviction callback is not called.
+// Return false if key is not resident.
+// The complexity is O(1).
+func (c *Cache[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	e.node.Remove(linkedlist.AdvanceNext)
+	delete(c.items, key)
+	return e.where != inA1out
+}
+
+// Purge removes every entry from the cache, A1out included. The eviction callback is not called.
+func (c *Cache[K, V]) Purge() {
+	c.a1in.Init()
+	c.a1out.Init()
+	c.am.Init()
+	c.items = make(map[K]*entry[K, V], c.capacity)
+}
+
+// reclaim frees a slot if the cache is full: A1in is paged out to A1out when it is over its target size,
+// otherwise the least recently used entry of Am is evicted.
+func (c *Cache[K, V]) reclaim() {
+	if c.Len() < c.capacity {
+		return
+	}
+
+	if c.a1in.Len() > c.kin || c.am.Len() == 0 {
+		back := c.a1in.BackCursor()
+		key := back.Value()
+		e := c.items[key]
+		value := e.value
+
+		var zero V
+		e.value = zero
+		c.a1out.AdoptFront(e.node)
+		e.where = inA1out
+		if c.a1out.Len() > c.kout {
+			delete(c.items, c.a1out.PopBack().Value)
+		}
+
+		if c.onEvict != nil {
+			c.onEvict(key, value)
+		}
+		return
+	}
+
+	n := c.am.PopBack()
+	e := c.items[n.Value]
+	delete(c.items, n.Value)
+	if c.onEvict != nil {
+		c.onEvict(n.Value, e.value)
+	}
+}
@@ -0,0 +1,181 @@
+package twoq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+// checkCache verifies the 2Q invariants of c: the size bounds of the resident entries and of A1out,
+// and every key being linked in the list its entry points to.
+func checkCache[K comparable, V any](t *testing.T, c *Cache[K, V]) {
+	t.Helper()
+
+	if c.Len() > c.capacity {
+		t.Errorf("|A1in|+|Am| = %d, want <= %d", c.Len(), c.capacity)
+	}
+	if c.a1out.Len() > c.kout {
+		t.Errorf("|A1out| = %d, want <= %d", c.a1out.Len(), c.kout)
+	}
+
+	n := 0
+	for w, l := range []*linkedlist.List[K]{inA1in: c.a1in, inA1out: c.a1out, inAm: c.am} {
+		k := l.Cursor()
+		for k.MoveNext() != nil {
+			e, ok := c.items[k.Value()]
+			if !ok {
+				t.Errorf("key %v is in list %d but not in the map", k.Value(), w)
+				continue
+			}
+			if e.where != where(w) || !e.node.Equal(k) {
+				t.Errorf("entry of key %v does not point to its node in list %d", k.Value(), w)
+			}
+			n++
+		}
+	}
+	if n != len(c.items) {
+		t.Errorf("lists hold %d keys, map holds %d", n, len(c.items))
+	}
+}
+
+func TestCache(t *testing.T) {
+	c := New[string, int](4, nil)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	checkCache(t, c)
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("c.Get(a) = %v, %v, want 1, true", v, ok)
+	}
+	if _, ok := c.Get("z"); ok {
+		t.Errorf("c.Get(z) = _, true, want false")
+	}
+	if c.items["a"].where != inA1in {
+		t.Errorf("a hit in A1in moved the key")
+	}
+
+	c.Put("a", 10)
+	if v, _ := c.Peek("a"); v != 10 {
+		t.Errorf("c.Peek(a) = %d, want 10", v)
+	}
+
+	if !c.Remove("a") || c.Remove("a") {
+		t.Errorf("c.Remove(a) twice = false/true, want true/false")
+	}
+	checkCache(t, c)
+	if c.Len() != 1 || c.Cap() != 4 {
+		t.Errorf("c.Len(), c.Cap() = %d, %d, want 1, 4", c.Len(), c.Cap())
+	}
+
+	c.Purge()
+	checkCache(t, c)
+	if c.Len() != 0 || len(c.items) != 0 {
+		t.Errorf("c.Len() = %d after Purge, want 0", c.Len())
+	}
+}
+
+func TestCachePromotion(t *testing.T) {
+	// A1in holds 1 key, A1out 2
+	c := NewWithRatios[int, int](4, nil, 0.25, 0.5)
+	for i := 0; i < 5; i++ {
+		c.Put(i, i)
+	}
+	checkCache(t, c)
+	if c.items[0].where != inA1out || c.Contains(0) {
+		t.Fatalf("key 0 was not paged out to A1out")
+	}
+
+	// a key requested again while in A1out is promoted to Am
+	c.Put(0, 0)
+	checkCache(t, c)
+	if e := c.items[0]; e.where != inAm || e.value != 0 {
+		t.Errorf("key 0 was not promoted to Am")
+	}
+
+	// the oldest ghost is promoted even when paging A1in out overflows A1out
+	c = NewWithRatios[int, int](2, nil, 0.5, 0.5)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3) // 1 goes to A1out
+	c.Put(1, 1)
+	checkCache(t, c)
+	if !c.Contains(1) || c.items[1].where != inAm {
+		t.Errorf("key 1 was not promoted to Am")
+	}
+}
+
+func TestCacheScanResistance(t *testing.T) {
+	c := New[string, int](8, nil)
+
+	// make the hot keys reach Am
+	hot := []string{"h0", "h1", "h2", "h3"}
+	for i := 0; i < 4; i++ {
+		for _, k := range hot {
+			if _, ok := c.Get(k); !ok {
+				c.Put(k, 0)
+			}
+		}
+		for j := 0; j < 4; j++ {
+			c.Put(fmt.Sprint("warm", i, j), 0)
+		}
+	}
+
+	// a long scan of keys used once only flows through A1in
+	for i := 0; i < 100; i++ {
+		c.Put(fmt.Sprint("scan", i), i)
+		checkCache(t, c)
+	}
+	for _, k := range hot {
+		if !c.Contains(k) {
+			t.Errorf("hot key %s was flushed by a scan", k)
+		}
+	}
+}
+
+func TestCacheEvictCallback(t *testing.T) {
+	var evicted []string
+	c := NewWithRatios(2, func(k string, v int) { evicted = append(evicted, k) }, 0.5, 1)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Remove("a") // not an eviction
+	c.Put("c", 3)
+	c.Put("d", 4) // b is paged out to A1out: its value is evicted
+
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Errorf("evicted = %v, want [b]", evicted)
+	}
+	checkCache(t, c)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(0) did not panic")
+		}
+	}()
+	New[int, int](0, nil)
+}
+
+func TestCacheRandom(t *testing.T) {
+	c := New[int, int](16, nil)
+	x := uint32(1)
+	for i := 0; i < 5000; i++ {
+		x = x*1664525 + 1013904223
+		k := int(x>>16) % 48
+		switch x >> 30 {
+		case 0:
+			c.Remove(k)
+		default:
+			if v, ok := c.Get(k); ok && v != k {
+				t.Fatalf("c.Get(%d) = %d", k, v)
+			} else if !ok {
+				c.Put(k, k)
+			}
+		}
+		if i%97 == 0 {
+			checkCache(t, c)
+		}
+	}
+	checkCache(t, c)
+}
@@ -0,0 +1,203 @@
+// Command skalebench summarizes the output of the benchmarks of package bench in a table, with one row per benchmark
+// and one column per implementation.
+//
+// Usage:
+//
+//	skalebench [-metric ns/op] [-base skale] [file ...]
+//
+// The benchmark output is read from the files, or from the standard input if none is given, for example:
+//
+//	go test -run '^$' -bench . ./bench | skalebench
+//
+// The implementation of a benchmark is taken from the impl=name element of its name. Results of repeated runs
+// (go test -count) are averaged. Every cell also shows its ratio to the base implementation.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+func main() {
+	metric := flag.String("metric", "ns/op", "the metric to compare: ns/op, B/op, allocs/op or any custom metric")
+	base := flag.String("base", "skale", "the implementation the others are compared to")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: skalebench [-metric ns/op] [-base skale] [file ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	t := newTable()
+	if flag.NArg() == 0 {
+		if err := t.read(os.Stdin); err != nil {
+			fatal(err)
+		}
+	}
+	for _, name := range flag.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			fatal(err)
+		}
+		err = t.read(f)
+		f.Close()
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	if err := t.write(os.Stdout, *metric, *base); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "skalebench:", err)
+	os.Exit(1)
+}
+
+// cell accumulates the results of a benchmark, by metric.
+type cell struct {
+	sum   map[string]float64
+	count map[string]int
+}
+
+// mean returns the average of metric.
+func (c *cell) mean(metric string) (float64, bool) {
+	if c == nil || c.count[metric] == 0 {
+		return 0, false
+	}
+	return c.sum[metric] / float64(c.count[metric]), true
+}
+
+// table holds the results by benchmark and implementation, in the order they were first seen.
+type table struct {
+	rows  []string
+	impls []string
+	cells map[string]map[string]*cell // by row, then implementation
+}
+
+func newTable() *table {
+	return &table{cells: make(map[string]map[string]*cell)}
+}
+
+// read adds the benchmark results of the output of go test read from r. Other lines are ignored.
+func (t *table) read(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		// Benchmark<name>-<procs> <iterations> <value> <unit> [<value> <unit>...]
+		if len(fields) < 4 || len(fields)%2 != 0 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+
+		row, impl := splitName(fields[0])
+		c := t.cell(row, impl)
+		for i := 2; i < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+			c.sum[fields[i+1]] += v
+			c.count[fields[i+1]]++
+		}
+	}
+	return s.Err()
+}
+
+// cell returns the cell of row and impl, adding them to the table if needed.
+func (t *table) cell(row, impl string) *cell {
+	cells, ok := t.cells[row]
+	if !ok {
+		cells = make(map[string]*cell)
+		t.cells[row] = cells
+		t.rows = append(t.rows, row)
+	}
+
+	known := false
+	for _, i := range t.impls {
+		known = known || i == impl
+	}
+	if !known {
+		t.impls = append(t.impls, impl)
+	}
+
+	c, ok := cells[impl]
+	if !ok {
+		c = &cell{sum: make(map[string]float64), count: make(map[string]int)}
+		cells[impl] = c
+	}
+	return c
+}
+
+// splitName returns the name of a benchmark without its Benchmark prefix, GOMAXPROCS suffix and impl element,
+// and the implementation named by the impl element, or "-" if there is none.
+func splitName(name string) (row, impl string) {
+	name = strings.TrimPrefix(name, "Benchmark")
+	if i := strings.LastIndexByte(name, '-'); i >= 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			name = name[:i]
+		}
+	}
+
+	impl = "-"
+	var elems []string
+	for _, e := range strings.Split(name, "/") {
+		if v := strings.TrimPrefix(e, "impl="); v != e {
+			impl = v
+			continue
+		}
+		elems = append(elems, e)
+	}
+	return strings.Join(elems, "/"), impl
+}
+
+// write writes the table of metric to w, comparing every implementation to base.
+func (t *table) write(w io.Writer, metric, base string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "%s\t%s\t\n", metric, strings.Join(t.impls, "\t"))
+
+	for _, row := range t.rows {
+		cells := t.cells[row]
+		b, hasBase := cells[base].mean(metric)
+
+		line := []string{row}
+		for _, impl := range t.impls {
+			v, ok := cells[impl].mean(metric)
+			switch {
+			case !ok:
+				line = append(line, "-")
+			case impl == base || !hasBase || b == 0:
+				line = append(line, format(v, metric))
+			default:
+				line = append(line, fmt.Sprintf("%s (%.2fx)", format(v, metric), v/b))
+			}
+		}
+		fmt.Fprintf(tw, "%s\t\n", strings.Join(line, "\t"))
+	}
+	return tw.Flush()
+}
+
+// format formats value v of metric, with 3 significant digits. Durations are scaled to a readable unit.
+func format(v float64, metric string) string {
+	unit := ""
+	if metric == "ns/op" {
+		unit = "ns"
+		for _, u := range []string{"µs", "ms", "s"} {
+			if v < 1000 {
+				break
+			}
+			v /= 1000
+			unit = u
+		}
+	}
+	return strconv.FormatFloat(v, 'g', 3, 64) + unit
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const output = `goos: linux
+goarch: amd64
+pkg: github.com/nnhatnam/skale/bench
+BenchmarkWalk/type=int/n=16/impl=skale-8         	 1000	       100.0 ns/op	      16 B/op	       1 allocs/op
+BenchmarkWalk/type=int/n=16/impl=stdlist-8       	 1000	       300.0 ns/op	      32 B/op	       2 allocs/op
+BenchmarkWalk/type=int/n=16/impl=skale-8         	 1000	       200.0 ns/op	      16 B/op	       1 allocs/op
+BenchmarkWalk/type=int/n=4096/impl=slice-8       	 1000	     12345 ns/op
+BenchmarkOther-8                                 	 1000	       5.5 ns/op
+PASS
+ok  	github.com/nnhatnam/skale/bench	1.234s
+`
+
+func TestSplitName(t *testing.T) {
+	tests := []struct {
+		name, row, impl string
+	}{
+		{"BenchmarkWalk/type=int/n=16/impl=skale-8", "Walk/type=int/n=16", "skale"},
+		{"BenchmarkWalk/impl=slice/n=16", "Walk/n=16", "slice"},
+		{"BenchmarkFoo-bar", "Foo-bar", "-"},
+		{"BenchmarkFoo", "Foo", "-"},
+	}
+	for _, tt := range tests {
+		if row, impl := splitName(tt.name); row != tt.row || impl != tt.impl {
+			t.Errorf("splitName(%q) = %q, %q, want %q, %q", tt.name, row, impl, tt.row, tt.impl)
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+	tb := newTable()
+	if err := tb.read(strings.NewReader(output)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := strings.Join(tb.rows, ","), "Walk/type=int/n=16,Walk/type=int/n=4096,Other"; got != want {
+		t.Errorf("rows = %s, want %s", got, want)
+	}
+	if got, want := strings.Join(tb.impls, ","), "skale,stdlist,slice,-"; got != want {
+		t.Errorf("impls = %s, want %s", got, want)
+	}
+	if v, _ := tb.cells["Walk/type=int/n=16"]["skale"].mean("ns/op"); v != 150 {
+		t.Errorf("mean of skale = %v, want 150", v)
+	}
+
+	var b bytes.Buffer
+	if err := tb.write(&b, "ns/op", "skale"); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("table has %d lines, want 4:\n%s", len(lines), b.String())
+	}
+	for i, want := range [][]string{
+		{"ns/op", "skale", "stdlist", "slice", "-"},
+		{"Walk/type=int/n=16", "150ns", "300ns (2.00x)", "-", "-"},
+		{"Walk/type=int/n=4096", "-", "-", "12.3µs", "-"},
+		{"Other", "-", "-", "-", "5.5ns"},
+	} {
+		fields := strings.Split(strings.Join(strings.Fields(lines[i]), " "), " ")
+		if got := strings.Join(fields, " "); got != strings.Join(want, " ") {
+			t.Errorf("line %d = %q, want %q", i, got, strings.Join(want, " "))
+		}
+	}
+
+	b.Reset()
+	tb.write(&b, "allocs/op", "skale")
+	if !strings.Contains(b.String(), "2 (2.00x)") {
+		t.Errorf("allocs/op table misses the stdlist ratio:\n%s", b.String())
+	}
+}
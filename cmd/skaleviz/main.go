@@ -0,0 +1,103 @@
+// Command skaleviz renders a linked list, read as a JSON array, as Graphviz DOT or as a line of ASCII.
+//
+// Usage:
+//
+//	skaleviz [-format ascii|dot] [-cursors 0,2] [file]
+//
+// The list is read from file, or from the standard input if no file is given. -cursors places cursors on the values
+// at the given zero based indexes, -1 being the sentinel node. For example:
+//
+//	echo '[1, 2, 3, 4]' | skaleviz -cursors 2
+//	[1]<->[2]<->(3)<->[4]
+//
+//	skaleviz -format dot list.json | dot -Tsvg > list.svg
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+func main() {
+	format := flag.String("format", "ascii", "output format: ascii or dot")
+	positions := flag.String("cursors", "", "comma separated indexes of the values to place cursors on, -1 for the sentinel node")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: skaleviz [-format ascii|dot] [-cursors 0,2] [file]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(os.Stdout, *format, *positions, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "skaleviz:", err)
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer, format, positions string, args []string) error {
+	var in io.Reader = os.Stdin
+	switch len(args) {
+	case 0:
+	case 1:
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	default:
+		return fmt.Errorf("too many arguments")
+	}
+
+	var values []any
+	dec := json.NewDecoder(in)
+	dec.UseNumber() // keep numbers as written
+	if err := dec.Decode(&values); err != nil {
+		return fmt.Errorf("reading the list: %w", err)
+	}
+	l := linkedlist.From(values...)
+
+	cursors, err := placeCursors(l, positions)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "ascii":
+		if err := linkedlist.WriteASCII(w, l, cursors...); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w)
+		return err
+	case "dot":
+		return linkedlist.WriteDOT(w, l, cursors...)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// placeCursors returns cursors of l at the comma separated indexes of positions.
+func placeCursors(l *linkedlist.List[any], positions string) ([]*linkedlist.Cursor[any], error) {
+	if positions == "" {
+		return nil, nil
+	}
+
+	var cursors []*linkedlist.Cursor[any]
+	for _, s := range strings.Split(positions, ",") {
+		i, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || i < -1 || i >= l.Len() {
+			return nil, fmt.Errorf("invalid cursor index %q for a list of %d values", s, l.Len())
+		}
+		c := l.Cursor()
+		for ; i >= 0; i-- {
+			c.MoveNext()
+		}
+		cursors = append(cursors, c)
+	}
+	return cursors, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "list.json")
+	if err := os.WriteFile(file, []byte(`[1, "two", 3.5, 4]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := run(&b, "ascii", "2, -1", []string{file}); err != nil {
+		t.Fatalf("run(ascii) error = %v", err)
+	}
+	if want := "()<->[1]<->[two]<->(3.5)<->[4]\n"; b.String() != want {
+		t.Errorf("run(ascii) = %q, want %q", b.String(), want)
+	}
+
+	b.Reset()
+	if err := run(&b, "dot", "0", []string{file}); err != nil {
+		t.Fatalf("run(dot) error = %v", err)
+	}
+	if !strings.HasPrefix(b.String(), "digraph list {") || !strings.Contains(b.String(), "c0 -> n0") {
+		t.Errorf("run(dot) = %q, want a DOT graph with cursor c0 on n0", b.String())
+	}
+
+	for _, tt := range []struct{ format, cursors string }{
+		{"svg", ""},
+		{"ascii", "4"},
+		{"ascii", "-2"},
+		{"ascii", "x"},
+	} {
+		if err := run(&b, tt.format, tt.cursors, []string{file}); err == nil {
+			t.Errorf("run(%q, %q) succeeded, want an error", tt.format, tt.cursors)
+		}
+	}
+	if err := run(&b, "ascii", "", []string{file, file}); err == nil {
+		t.Errorf("run with two files succeeded, want an error")
+	}
+}
@@ -0,0 +1,74 @@
+// Package container defines the interfaces shared by the skale containers, so that code can switch between them.
+//
+// Containers hand out their own element type E rather than bare values: *linkedlist.Node[T] for a list,
+// *rbtree.Node[K, V] for a tree map, or the value itself. The interfaces are parameterized by E, and the zero value
+// of E always means that there is no element, like a nil node or a false boolean.
+//
+// The package containertest provides a conformance suite for every interface.
+package container
+
+// Sequence is an ordered collection that grows at both ends and shrinks at the front.
+// Front, Back and PopFront return the zero value of E when the sequence is empty.
+type Sequence[T any, E comparable] interface {
+	// Len returns the number of values in the sequence.
+	Len() int
+	// PushBack appends v at the back of the sequence.
+	PushBack(v T)
+	// PushFront prepends v at the front of the sequence.
+	PushFront(v T)
+	// PopFront removes the front element and returns it.
+	PopFront() E
+	// Front returns the front element.
+	Front() E
+	// Back returns the back element.
+	Back() E
+}
+
+// Deque is a Sequence that also shrinks at the back.
+type Deque[T any, E comparable] interface {
+	Sequence[T, E]
+	// PopBack removes the back element and returns it.
+	PopBack() E
+}
+
+// Iterable is a collection that can be walked in both directions. The walks stop when f returns false.
+type Iterable[E any] interface {
+	// WalkAscending calls f on every element, from the first to the last.
+	WalkAscending(f func(e E) bool)
+	// WalkDescending calls f on every element, from the last to the first.
+	WalkDescending(f func(e E) bool)
+}
+
+// BidiCursor points into a container, to an element or to a sentinel position that stands before the first element
+// and after the last one. MoveNext and MovePrev move the cursor and return the element it reaches, the zero value of
+// E when it reaches the sentinel position; from the sentinel position they wrap around to the first and last element.
+type BidiCursor[T any, E comparable] interface {
+	// Value returns the value of the element the cursor points to.
+	Value() T
+	// MoveNext moves the cursor to the next element.
+	MoveNext() E
+	// MovePrev moves the cursor to the previous element.
+	MovePrev() E
+	// IsValid reports whether the cursor can still be used.
+	IsValid() bool
+	// Close closes the cursor, which is not valid anymore.
+	Close()
+}
+
+// OrderedSet is a set of values kept in the order of a comparator. Equal values are the same member.
+type OrderedSet[T any] interface {
+	// Len returns the number of members.
+	Len() int
+	// Put adds v, replacing an equal member, which it returns.
+	Put(v T) (T, bool)
+	// Delete removes the member equal to v, and returns it.
+	Delete(v T) (T, bool)
+	// Contains reports whether a member is equal to v.
+	Contains(v T) bool
+	// Min returns the smallest member.
+	Min() (T, bool)
+	// Max returns the largest member.
+	Max() (T, bool)
+	// Range calls f on the members in [lo, hi) in ascending order, until f returns false.
+	Range(lo, hi T, f func(v T) bool)
+}
@@ -0,0 +1,388 @@
+// Package containertest implements conformance tests for the interfaces of package container.
+//
+// A container runs the suite from its own tests, for example:
+//
+//	func TestDequeConformance(t *testing.T) {
+//		containertest.TestDeque(t,
+//			func() container.Deque[int, *linkedlist.Node[int]] { return linkedlist.New[int]() },
+//			func(n *linkedlist.Node[int]) int { return n.Value })
+//	}
+//
+// The cases are ported from the list and cursor tests of package linkedlist.
+//
+// Machine runs model-based tests: random sequences of operations, applied to a container and to a simple reference
+// model such as a slice, are checked step by step. The operations are drawn from bytes, so that a machine can run from
+// a native fuzz target as well as from a random source. DequeMachine is a ready made machine for any Deque.
+package containertest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nnhatnam/skale/container"
+)
+
+// drain pops every element of s from the front, and returns their values.
+func drain[E comparable](s container.Sequence[int, E], value func(e E) int) []int {
+	var zero E
+	var vs []int
+	for e := s.PopFront(); e != zero; e = s.PopFront() {
+		vs = append(vs, value(e))
+	}
+	return vs
+}
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkEnds checks the length and the values of the front and back elements of s.
+func checkEnds[E comparable](t *testing.T, s container.Sequence[int, E], value func(e E) int, want []int) {
+	t.Helper()
+
+	var zero E
+	if s.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", s.Len(), len(want))
+	}
+	if len(want) == 0 {
+		if s.Front() != zero || s.Back() != zero {
+			t.Errorf("Front(), Back() of an empty sequence are not the zero element")
+		}
+		return
+	}
+	if f := s.Front(); f == zero || value(f) != want[0] {
+		t.Errorf("Front() is not %d", want[0])
+	}
+	if b := s.Back(); b == zero || value(b) != want[len(want)-1] {
+		t.Errorf("Back() is not %d", want[len(want)-1])
+	}
+}
+
+// TestSequence tests a Sequence of ints. newSeq returns an empty sequence, value returns the value of an element.
+func TestSequence[E comparable](t *testing.T, newSeq func() container.Sequence[int, E], value func(e E) int) {
+	var zero E
+
+	t.Run("Empty", func(t *testing.T) {
+		s := newSeq()
+		checkEnds(t, s, value, nil)
+		if e := s.PopFront(); e != zero {
+			t.Errorf("PopFront() of an empty sequence = %v, want the zero element", e)
+		}
+	})
+
+	t.Run("PushBack", func(t *testing.T) {
+		s := newSeq()
+		var want []int
+		for i := 1; i <= 5; i++ {
+			s.PushBack(i)
+			want = append(want, i)
+			checkEnds(t, s, value, want)
+		}
+		if got := drain(s, value); !equal(got, []int{1, 2, 3, 4, 5}) {
+			t.Errorf("values = %v, want [1 2 3 4 5]", got)
+		}
+	})
+
+	t.Run("PushFront", func(t *testing.T) {
+		s := newSeq()
+		for i := 1; i <= 5; i++ {
+			s.PushFront(i)
+		}
+		checkEnds(t, s, value, []int{5, 4, 3, 2, 1})
+		if got := drain(s, value); !equal(got, []int{5, 4, 3, 2, 1}) {
+			t.Errorf("values = %v, want [5 4 3 2 1]", got)
+		}
+	})
+
+	t.Run("PopFront", func(t *testing.T) {
+		s := newSeq()
+		s.PushBack(1)
+		if e := s.PopFront(); e == zero || value(e) != 1 {
+			t.Errorf("PopFront() is not 1")
+		}
+
+		s.PushBack(1)
+		s.PushBack(2)
+		for _, want := range []int{1, 2} {
+			if e := s.PopFront(); e == zero || value(e) != want {
+				t.Errorf("PopFront() is not %d", want)
+			}
+		}
+		if e := s.PopFront(); e != zero {
+			t.Errorf("PopFront() of an emptied sequence = %v, want the zero element", e)
+		}
+		checkEnds(t, s, value, nil)
+	})
+
+	t.Run("Mixed", func(t *testing.T) {
+		s := newSeq()
+		s.PushBack(3)
+		s.PushFront(2)
+		s.PushBack(4)
+		s.PushFront(1)
+		checkEnds(t, s, value, []int{1, 2, 3, 4})
+		s.PopFront()
+		s.PushBack(5)
+		if got := drain(s, value); !equal(got, []int{2, 3, 4, 5}) {
+			t.Errorf("values = %v, want [2 3 4 5]", got)
+		}
+	})
+}
+
+// TestDeque tests a Deque of ints, including the Sequence tests. newDeque returns an empty deque, value returns the
+// value of an element.
+func TestDeque[E comparable](t *testing.T, newDeque func() container.Deque[int, E], value func(e E) int) {
+	var zero E
+
+	TestSequence(t, func() container.Sequence[int, E] { return newDeque() }, value)
+
+	t.Run("PopBack", func(t *testing.T) {
+		d := newDeque()
+		if e := d.PopBack(); e != zero {
+			t.Errorf("PopBack() of an empty deque = %v, want the zero element", e)
+		}
+
+		d.PushBack(1)
+		if e := d.PopBack(); e == zero || value(e) != 1 {
+			t.Errorf("PopBack() is not 1")
+		}
+
+		d.PushBack(1)
+		d.PushBack(2)
+		for _, want := range []int{2, 1} {
+			if e := d.PopBack(); e == zero || value(e) != want {
+				t.Errorf("PopBack() is not %d", want)
+			}
+		}
+		if e := d.PopBack(); e != zero {
+			t.Errorf("PopBack() of an emptied deque = %v, want the zero element", e)
+		}
+		checkEnds[E](t, d, value, nil)
+	})
+
+	t.Run("BothEnds", func(t *testing.T) {
+		d := newDeque()
+		for i := 0; i < 10; i++ {
+			d.PushBack(i)
+		}
+		for i := 0; i < 5; i++ {
+			f, b := d.PopFront(), d.PopBack()
+			if f == zero || b == zero || value(f) != i || value(b) != 9-i {
+				t.Fatalf("step %d: PopFront(), PopBack() are not %d, %d", i, i, 9-i)
+			}
+		}
+		checkEnds[E](t, d, value, nil)
+	})
+}
+
+// TestIterable tests an Iterable of ints. newIterable returns an iterable whose ascending walk visits values in order;
+// value returns the value of an element.
+func TestIterable[E any](t *testing.T, newIterable func(values []int) container.Iterable[E], value func(e E) int) {
+	for _, n := range []int{0, 1, 2, 10} {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = 10 * i
+		}
+
+		t.Run(fmt.Sprint("Len", n), func(t *testing.T) {
+			it := newIterable(values)
+
+			var got []int
+			it.WalkAscending(func(e E) bool {
+				got = append(got, value(e))
+				return true
+			})
+			if !equal(got, values) {
+				t.Errorf("WalkAscending() visits %v, want %v", got, values)
+			}
+
+			got = got[:0]
+			it.WalkDescending(func(e E) bool {
+				got = append(got, value(e))
+				return true
+			})
+			for i, j := 0, len(got)-1; i < j; i, j = i+1, j-1 {
+				got[i], got[j] = got[j], got[i]
+			}
+			if !equal(got, values) {
+				t.Errorf("WalkDescending() visits %v reversed, want %v", got, values)
+			}
+
+			// stopping early
+			calls := 0
+			it.WalkAscending(func(e E) bool {
+				calls++
+				return false
+			})
+			if want := min(n, 1); calls != want {
+				t.Errorf("WalkAscending() called f %d times after it returned false, want %d", calls, want)
+			}
+		})
+	}
+}
+
+// TestBidiCursor tests a BidiCursor over ints. newCursor returns a cursor at the sentinel position of a container
+// holding values, in this order.
+func TestBidiCursor[E comparable](t *testing.T, newCursor func(values []int) container.BidiCursor[int, E]) {
+	var zero E
+
+	t.Run("Empty", func(t *testing.T) {
+		c := newCursor(nil)
+		if e := c.MoveNext(); e != zero {
+			t.Errorf("MoveNext() on an empty container = %v, want the zero element", e)
+		}
+		if e := c.MovePrev(); e != zero {
+			t.Errorf("MovePrev() on an empty container = %v, want the zero element", e)
+		}
+		if !c.IsValid() {
+			t.Errorf("IsValid() = false after moves on an empty container")
+		}
+	})
+
+	t.Run("OneElement", func(t *testing.T) {
+		c := newCursor([]int{1})
+		if e := c.MoveNext(); e == zero || c.Value() != 1 {
+			t.Errorf("MoveNext() from the sentinel did not reach 1")
+		}
+		if e := c.MovePrev(); e != zero {
+			t.Errorf("MovePrev() from the only element = %v, want the zero element", e)
+		}
+		if e := c.MovePrev(); e == zero || c.Value() != 1 {
+			t.Errorf("MovePrev() from the sentinel did not reach 1")
+		}
+		if e := c.MoveNext(); e != zero {
+			t.Errorf("MoveNext() from the only element = %v, want the zero element", e)
+		}
+	})
+
+	t.Run("Walk", func(t *testing.T) {
+		values := []int{1, 2, 3, 4, 5}
+		c := newCursor(values)
+
+		var got []int
+		for e := c.MoveNext(); e != zero; e = c.MoveNext() {
+			got = append(got, c.Value())
+		}
+		if !equal(got, values) {
+			t.Errorf("ascending walk = %v, want %v", got, values)
+		}
+
+		got = got[:0]
+		for e := c.MovePrev(); e != zero; e = c.MovePrev() {
+			got = append(got, c.Value())
+		}
+		if !equal(got, []int{5, 4, 3, 2, 1}) {
+			t.Errorf("descending walk = %v, want [5 4 3 2 1]", got)
+		}
+
+		// back and forth in the middle
+		c.MoveNext()
+		c.MoveNext()
+		c.MoveNext()
+		c.MovePrev()
+		if c.Value() != 2 {
+			t.Errorf("Value() = %d after next, next, next, prev, want 2", c.Value())
+		}
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		c := newCursor([]int{1, 2})
+		c.MoveNext()
+		c.Close()
+		if c.IsValid() {
+			t.Errorf("IsValid() = true after Close")
+		}
+	})
+}
+
+// TestOrderedSet tests an OrderedSet of ints in ascending order. newSet returns an empty set.
+func TestOrderedSet(t *testing.T, newSet func() container.OrderedSet[int]) {
+	members := func(s container.OrderedSet[int], lo, hi int) []int {
+		var vs []int
+		s.Range(lo, hi, func(v int) bool {
+			vs = append(vs, v)
+			return true
+		})
+		return vs
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		s := newSet()
+		if s.Len() != 0 || s.Contains(0) {
+			t.Errorf("a new set is not empty")
+		}
+		if _, ok := s.Min(); ok {
+			t.Errorf("Min() of an empty set = _, true")
+		}
+		if _, ok := s.Max(); ok {
+			t.Errorf("Max() of an empty set = _, true")
+		}
+		if _, ok := s.Delete(0); ok {
+			t.Errorf("Delete() on an empty set = _, true")
+		}
+	})
+
+	t.Run("PutDelete", func(t *testing.T) {
+		s := newSet()
+		for _, v := range []int{5, 1, 4, 2, 3} {
+			if _, replaced := s.Put(v); replaced {
+				t.Errorf("Put(%d) replaced a member", v)
+			}
+		}
+		if _, replaced := s.Put(3); !replaced || s.Len() != 5 {
+			t.Errorf("Put(3) twice did not replace the member")
+		}
+		if got := members(s, 0, 10); !equal(got, []int{1, 2, 3, 4, 5}) {
+			t.Errorf("members = %v, want [1 2 3 4 5]", got)
+		}
+		if v, ok := s.Min(); !ok || v != 1 {
+			t.Errorf("Min() = %d, %v, want 1, true", v, ok)
+		}
+		if v, ok := s.Max(); !ok || v != 5 {
+			t.Errorf("Max() = %d, %v, want 5, true", v, ok)
+		}
+
+		if v, ok := s.Delete(3); !ok || v != 3 {
+			t.Errorf("Delete(3) = %d, %v, want 3, true", v, ok)
+		}
+		if _, ok := s.Delete(3); ok || s.Contains(3) || s.Len() != 4 {
+			t.Errorf("3 is still a member after Delete")
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		s := newSet()
+		for v := 0; v < 20; v += 2 {
+			s.Put(v)
+		}
+		if got := members(s, 3, 9); !equal(got, []int{4, 6, 8}) {
+			t.Errorf("Range(3, 9) = %v, want [4 6 8]", got)
+		}
+		if got := members(s, 4, 4); len(got) != 0 {
+			t.Errorf("Range(4, 4) = %v, want []", got)
+		}
+		calls := 0
+		s.Range(0, 20, func(int) bool {
+			calls++
+			return calls < 2
+		})
+		if calls != 2 {
+			t.Errorf("Range() called f %d times after it returned false, want 2", calls)
+		}
+	})
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,183 @@
+package containertest
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/nnhatnam/skale/container"
+)
+
+// Input draws the arguments of the operations of a Machine from a string of bytes, such as the one provided by the
+// fuzzing engine. Once the bytes are consumed, every draw returns 0 and the run stops at the next operation.
+type Input struct {
+	data []byte
+	args []string // the arguments drawn by the current operation, for the log
+}
+
+// Intn returns an int in [0, n) drawn from the input. It panics if n is not positive.
+func (in *Input) Intn(n int) int {
+	if n <= 0 {
+		panic("containertest: Intn argument must be positive")
+	}
+	v := 0
+	for max := n - 1; max > 0; max >>= 8 {
+		v <<= 8
+		if len(in.data) > 0 {
+			v |= int(in.data[0])
+			in.data = in.data[1:]
+		}
+	}
+	v %= n
+	in.args = append(in.args, fmt.Sprint(v))
+	return v
+}
+
+// Bool returns a bool drawn from the input.
+func (in *Input) Bool() bool {
+	return in.Intn(2) == 1
+}
+
+// Op is an operation of a Machine. Run applies it to both the container and the model held by state s,
+// drawing its arguments from in, and returns an error if the container does not behave like the model.
+type Op[S any] struct {
+	Name string
+	Run  func(s S, in *Input) error
+}
+
+// Machine is a model-based test. It applies a sequence of operations, chosen from an Input, to a container and to a
+// simple reference model of it, and checks after each step that both agree. On failure the test reports the steps
+// that led to it, with their arguments, so that the sequence can be replayed by hand.
+type Machine[S any] struct {
+	// New returns the state of a new run: an empty container and its model.
+	New func() S
+	// Ops are the operations the machine chooses from.
+	Ops []Op[S]
+	// Check compares the container with its model. It is called after each step.
+	Check func(s S) error
+}
+
+// Run runs the machine with the operations chosen by data, typically from a fuzz target:
+//
+//	func FuzzList(f *testing.F) {
+//		m := listMachine()
+//		f.Fuzz(func(t *testing.T, data []byte) { m.Run(t, data) })
+//	}
+func (m Machine[S]) Run(t testing.TB, data []byte) {
+	t.Helper()
+
+	s := m.New()
+	if err := m.Check(s); err != nil {
+		t.Fatalf("new state: %v", err)
+	}
+
+	in := &Input{data: data}
+	var log []string
+	for step := 0; len(in.data) > 0; step++ {
+		op := m.Ops[in.Intn(len(m.Ops))]
+		in.args = in.args[:0]
+
+		err := op.Run(s, in)
+		log = append(log, fmt.Sprintf("%s(%s)", op.Name, strings.Join(in.args, ", ")))
+		if err == nil {
+			err = m.Check(s)
+		}
+		if err != nil {
+			t.Fatalf("step %d: %v\nsteps:\n\t%s", step, err, strings.Join(log, "\n\t"))
+		}
+	}
+}
+
+// RunRandom runs the machine for about steps operations chosen by r.
+func (m Machine[S]) RunRandom(t testing.TB, r *rand.Rand, steps int) {
+	t.Helper()
+
+	data := make([]byte, 4*steps)
+	r.Read(data)
+	m.Run(t, data)
+}
+
+// dequeState is the state of the machine returned by DequeMachine.
+type dequeState[E comparable] struct {
+	d     container.Deque[int, E]
+	model []int
+	next  int // the next value pushed
+}
+
+// DequeMachine returns a Machine that pushes and pops the ends of the Deque returned by newDeque, and compares it with
+// a slice. value returns the value of an element.
+func DequeMachine[E comparable](newDeque func() container.Deque[int, E], value func(e E) int) Machine[*dequeState[E]] {
+	var zero E
+
+	pop := func(s *dequeState[E], front bool) error {
+		var e E
+		if front {
+			e = s.d.PopFront()
+		} else {
+			e = s.d.PopBack()
+		}
+		if len(s.model) == 0 {
+			if e != zero {
+				return fmt.Errorf("pop of an empty deque returned %v, want the zero element", e)
+			}
+			return nil
+		}
+
+		var want int
+		if front {
+			want, s.model = s.model[0], s.model[1:]
+		} else {
+			want, s.model = s.model[len(s.model)-1], s.model[:len(s.model)-1]
+		}
+		if e == zero || value(e) != want {
+			return fmt.Errorf("pop returned %v, want %d", e, want)
+		}
+		return nil
+	}
+
+	return Machine[*dequeState[E]]{
+		New: func() *dequeState[E] {
+			return &dequeState[E]{d: newDeque()}
+		},
+		Ops: []Op[*dequeState[E]]{
+			{"PushBack", func(s *dequeState[E], in *Input) error {
+				s.next++
+				s.d.PushBack(s.next)
+				s.model = append(s.model, s.next)
+				return nil
+			}},
+			{"PushFront", func(s *dequeState[E], in *Input) error {
+				s.next++
+				s.d.PushFront(s.next)
+				s.model = append([]int{s.next}, s.model...)
+				return nil
+			}},
+			{"PopFront", func(s *dequeState[E], in *Input) error {
+				return pop(s, true)
+			}},
+			{"PopBack", func(s *dequeState[E], in *Input) error {
+				return pop(s, false)
+			}},
+		},
+		Check: func(s *dequeState[E]) error {
+			if s.d.Len() != len(s.model) {
+				return fmt.Errorf("Len() = %d, want %d", s.d.Len(), len(s.model))
+			}
+			front, back := s.d.Front(), s.d.Back()
+			if len(s.model) == 0 {
+				if front != zero || back != zero {
+					return fmt.Errorf("Front(), Back() of an empty deque are not the zero element")
+				}
+				return nil
+			}
+			if front == zero || value(front) != s.model[0] {
+				return fmt.Errorf("Front() = %v, want %d", front, s.model[0])
+			}
+			if back == zero || value(back) != s.model[len(s.model)-1] {
+				return fmt.Errorf("Back() = %v, want %d", back, s.model[len(s.model)-1])
+			}
+			return nil
+		},
+	}
+}
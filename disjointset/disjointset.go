@@ -0,0 +1,163 @@
+// Package disjointset implements disjoint set forests, also known as union-find.
+//
+// DisjointSet uses union by rank and path compression, so that a sequence of m operations on n elements runs in
+// O(m α(n)), α being the inverse Ackermann function. Every set also keeps its members in a linkedlist.List, and two
+// sets are merged by splicing their lists in O(1), so the groups can be enumerated at any time without a pass over
+// the elements.
+//
+// Rollback trades path compression for the ability to undo unions, as needed by offline dynamic connectivity
+// algorithms, which add and remove edges by walking a segment tree of time intervals.
+//
+// Structure is not thread safe.
+package disjointset
+
+import "github.com/nnhatnam/skale/list/linkedlist"
+
+// entry is an element of a DisjointSet.
+type entry[T comparable] struct {
+	parent T
+	rank   int
+	group  *group[T] // the members of the set, only set for representatives
+}
+
+// group is a set of a DisjointSet.
+type group[T comparable] struct {
+	members linkedlist.List[T]
+	order   *linkedlist.Cursor[*group[T]] // node in DisjointSet.groups
+}
+
+// DisjointSet is a partition of elements of type T into disjoint sets.
+type DisjointSet[T comparable] struct {
+	entries map[T]*entry[T]
+	groups  linkedlist.List[*group[T]] // sets in the order their representatives were added
+}
+
+// New returns an empty DisjointSet.
+func New[T comparable]() *DisjointSet[T] {
+	return &DisjointSet[T]{entries: make(map[T]*entry[T])}
+}
+
+// Len returns the number of elements of s.
+func (s *DisjointSet[T]) Len() int {
+	return len(s.entries)
+}
+
+// Count returns the number of sets of s.
+func (s *DisjointSet[T]) Count() int {
+	return s.groups.Len()
+}
+
+// Contains reports whether x is an element of s.
+func (s *DisjointSet[T]) Contains(x T) bool {
+	_, ok := s.entries[x]
+	return ok
+}
+
+// Add adds x to s, in a set of its own. Return false if x is already an element of s.
+// The complexity is O(1).
+func (s *DisjointSet[T]) Add(x T) bool {
+	if s.Contains(x) {
+		return false
+	}
+	g := &group[T]{}
+	g.members.PushBack(x)
+	s.groups.PushBack(g)
+	g.order = s.groups.BackCursor()
+	s.entries[x] = &entry[T]{parent: x, group: g}
+	return true
+}
+
+// Find returns the representative of the set of x. Return false if x is not an element of s.
+// The complexity is O(α(n)) amortized.
+func (s *DisjointSet[T]) Find(x T) (T, bool) {
+	if !s.Contains(x) {
+		var zero T
+		return zero, false
+	}
+	return s.find(x).parent, true
+}
+
+// find returns the entry of the representative of the set of x, an element of s, and compresses the path to it.
+func (s *DisjointSet[T]) find(x T) *entry[T] {
+	r, root := x, s.entries[x]
+	for root.parent != r {
+		r = root.parent
+		root = s.entries[r]
+	}
+	for e := s.entries[x]; e != root; {
+		next := s.entries[e.parent]
+		e.parent = r
+		e = next
+	}
+	return root
+}
+
+// Union merges the sets of x and y, adding x and y to s if they are not elements of s yet. The representative of the
+// merged set is the one of higher rank, and its members are those of the set of x followed by those of the set of y.
+// Return false if x and y are already in the same set.
+// The complexity is O(α(n)) amortized.
+func (s *DisjointSet[T]) Union(x, y T) bool {
+	s.Add(x)
+	s.Add(y)
+	rx, ry := s.find(x), s.find(y)
+	if rx == ry {
+		return false
+	}
+
+	if rx.rank < ry.rank {
+		rx.parent = ry.parent
+		ry.group.members.SpliceFrontList(&rx.group.members)
+		rx.group.order.Remove(linkedlist.AdvanceNext)
+		rx.group = nil
+		return true
+	}
+
+	ry.parent = rx.parent
+	rx.group.members.SpliceBackList(&ry.group.members)
+	ry.group.order.Remove(linkedlist.AdvanceNext)
+	ry.group = nil
+	if rx.rank == ry.rank {
+		rx.rank++
+	}
+	return true
+}
+
+// Same reports whether x and y are elements of s in the same set.
+// The complexity is O(α(n)) amortized.
+func (s *DisjointSet[T]) Same(x, y T) bool {
+	if !s.Contains(x) || !s.Contains(y) {
+		return false
+	}
+	return s.find(x) == s.find(y)
+}
+
+// Size returns the number of elements in the set of x, 0 if x is not an element of s.
+// The complexity is O(α(n)) amortized.
+func (s *DisjointSet[T]) Size(x T) int {
+	if !s.Contains(x) {
+		return 0
+	}
+	return s.find(x).group.members.Len()
+}
+
+// Group returns the members of the set of x. Return nil if x is not an element of s.
+// The list belongs to s: it must not be modified, and it changes when the set is merged with another.
+// The complexity is O(α(n)) amortized.
+func (s *DisjointSet[T]) Group(x T) *linkedlist.List[T] {
+	if !s.Contains(x) {
+		return nil
+	}
+	return &s.find(x).group.members
+}
+
+// Groups returns the members of every set of s, the sets in the order their representatives were added.
+// The lists belong to s: they must not be modified, and they change when sets are merged.
+// The complexity is O(k) for k sets.
+func (s *DisjointSet[T]) Groups() []*linkedlist.List[T] {
+	groups := make([]*linkedlist.List[T], 0, s.Count())
+	s.groups.WalkAscending(func(n *linkedlist.Node[*group[T]]) bool {
+		groups = append(groups, &n.Value.members)
+		return true
+	})
+	return groups
+}
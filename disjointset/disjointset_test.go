@@ -0,0 +1,172 @@
+package disjointset
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+// values returns the values of l.
+func values[T any](l *linkedlist.List[T]) []T {
+	vs := []T{}
+	l.WalkAscending(func(n *linkedlist.Node[T]) bool {
+		vs = append(vs, n.Value)
+		return true
+	})
+	return vs
+}
+
+// groupValues returns the values of the lists of groups.
+func groupValues[T any](groups []*linkedlist.List[T]) [][]T {
+	vs := make([][]T, len(groups))
+	for i, l := range groups {
+		vs[i] = values(l)
+	}
+	return vs
+}
+
+// labels is a naive partition of [0, n): every element is labeled with its set.
+type labels []int
+
+func newLabels(n int) labels {
+	l := make(labels, n)
+	for i := range l {
+		l[i] = i
+	}
+	return l
+}
+
+func (l labels) union(x, y int) bool {
+	lx, ly := l[x], l[y]
+	if lx == ly {
+		return false
+	}
+	for i := range l {
+		if l[i] == ly {
+			l[i] = lx
+		}
+	}
+	return true
+}
+
+func (l labels) count() int {
+	seen := make(map[int]bool)
+	for _, x := range l {
+		seen[x] = true
+	}
+	return len(seen)
+}
+
+func (l labels) size(x int) int {
+	n := 0
+	for _, y := range l {
+		if y == l[x] {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDisjointSet(t *testing.T) {
+	s := New[string]()
+	if _, ok := s.Find("a"); ok || s.Same("a", "a") || s.Size("a") != 0 || s.Group("a") != nil {
+		t.Errorf("empty set: Find, Same, Size or Group found an element")
+	}
+
+	for _, x := range []string{"a", "b", "c", "d", "e"} {
+		if !s.Add(x) {
+			t.Errorf("Add(%q) = false, want true", x)
+		}
+	}
+	if s.Add("a") {
+		t.Errorf("Add(%q) = true, want false", "a")
+	}
+	if s.Len() != 5 || s.Count() != 5 {
+		t.Errorf("Len(), Count() = %d, %d, want 5, 5", s.Len(), s.Count())
+	}
+
+	if !s.Union("a", "b") || !s.Union("c", "d") || !s.Union("d", "b") || s.Union("a", "c") {
+		t.Errorf("Union results are wrong")
+	}
+	if !s.Same("a", "d") || s.Same("a", "e") {
+		t.Errorf("Same(a, d), Same(a, e) = %v, %v, want true, false", s.Same("a", "d"), s.Same("a", "e"))
+	}
+	if s.Count() != 2 || s.Size("c") != 4 || s.Size("e") != 1 {
+		t.Errorf("Count(), Size(c), Size(e) = %d, %d, %d, want 2, 4, 1", s.Count(), s.Size("c"), s.Size("e"))
+	}
+	if got, want := values(s.Group("b")), []string{"c", "d", "a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Group(b) = %v, want %v", got, want)
+	}
+	if got, want := groupValues(s.Groups()), [][]string{{"c", "d", "a", "b"}, {"e"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Groups() = %v, want %v", got, want)
+	}
+
+	ra, _ := s.Find("a")
+	for _, x := range []string{"b", "c", "d"} {
+		if r, ok := s.Find(x); !ok || r != ra {
+			t.Errorf("Find(%q) = %q, %v, want %q, true", x, r, ok, ra)
+		}
+	}
+
+	if !s.Union("f", "e") || s.Len() != 6 || s.Count() != 2 {
+		t.Errorf("Union(f, e) did not add f: Len(), Count() = %d, %d, want 6, 2", s.Len(), s.Count())
+	}
+	if got, want := values(s.Group("e")), []string{"f", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Group(e) = %v, want %v", got, want)
+	}
+}
+
+func TestDisjointSetCompression(t *testing.T) {
+	s := New[int]()
+	for i := 1; i < 64; i++ {
+		s.Union(i, i-1)
+	}
+	r, _ := s.Find(63)
+	for i := 0; i < 64; i++ {
+		if s.entries[i].parent != r && i != r {
+			t.Errorf("parent of %d = %d after Find, want the representative %d", i, s.entries[i].parent, r)
+		}
+	}
+	if s.Size(0) != 64 || s.entries[r].rank > 6 {
+		t.Errorf("Size(0), rank = %d, %d, want 64, at most 6", s.Size(0), s.entries[r].rank)
+	}
+}
+
+func TestDisjointSetRandom(t *testing.T) {
+	const n = 100
+	r := rand.New(rand.NewSource(1))
+	s := New[int]()
+	for i := 0; i < n; i++ {
+		s.Add(i)
+	}
+	model := newLabels(n)
+
+	for step := 0; step < 500; step++ {
+		x, y := r.Intn(n), r.Intn(n)
+		if got, want := s.Union(x, y), model.union(x, y); got != want {
+			t.Fatalf("step %d: Union(%d, %d) = %v, want %v", step, x, y, got, want)
+		}
+		if got, want := s.Count(), model.count(); got != want {
+			t.Fatalf("step %d: Count() = %d, want %d", step, got, want)
+		}
+		if x, y := r.Intn(n), r.Intn(n); s.Same(x, y) != (model[x] == model[y]) {
+			t.Fatalf("step %d: Same(%d, %d) = %v, want %v", step, x, y, s.Same(x, y), model[x] == model[y])
+		}
+	}
+
+	members := 0
+	for _, g := range s.Groups() {
+		vs := values(g)
+		members += len(vs)
+		for _, x := range vs {
+			if model[x] != model[vs[0]] || s.Size(x) != len(vs) || model.size(x) != len(vs) {
+				t.Errorf("group %v: %d is not in the set of %d, or has size %d", vs, x, vs[0], s.Size(x))
+			}
+		}
+	}
+	if members != n {
+		t.Errorf("the groups have %d members, want %d", members, n)
+	}
+}
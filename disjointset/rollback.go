@@ -0,0 +1,186 @@
+package disjointset
+
+import "github.com/nnhatnam/skale/list/linkedlist"
+
+// change is an operation recorded by a Rollback: the element child added, if root is -1, or the representative child
+// linked under the representative root.
+type change struct {
+	child, root int
+}
+
+// Rollback is a partition of elements of type T into disjoint sets, whose operations can be undone.
+//
+// It uses union by size without path compression, so that a union changes a single link and is undone in O(1), and
+// Find is O(log n). A typical use saves a snapshot, performs some unions, then rolls back to the snapshot:
+//
+//	snap := s.Snapshot()
+//	s.Union(x, y)
+//	// ...
+//	s.RollbackTo(snap)
+type Rollback[T comparable] struct {
+	index   map[T]int // index of the elements in elems
+	elems   []T
+	parent  []int
+	size    []int // size of the sets, only meaningful for representatives
+	count   int
+	history []change
+}
+
+// NewRollback returns an empty Rollback.
+func NewRollback[T comparable]() *Rollback[T] {
+	return &Rollback[T]{index: make(map[T]int)}
+}
+
+// Len returns the number of elements of s.
+func (s *Rollback[T]) Len() int {
+	return len(s.elems)
+}
+
+// Count returns the number of sets of s.
+func (s *Rollback[T]) Count() int {
+	return s.count
+}
+
+// Contains reports whether x is an element of s.
+func (s *Rollback[T]) Contains(x T) bool {
+	_, ok := s.index[x]
+	return ok
+}
+
+// Add adds x to s, in a set of its own. Return false if x is already an element of s.
+// The addition is recorded, and undone by a rollback.
+// The complexity is O(1).
+func (s *Rollback[T]) Add(x T) bool {
+	if s.Contains(x) {
+		return false
+	}
+	i := len(s.elems)
+	s.index[x] = i
+	s.elems = append(s.elems, x)
+	s.parent = append(s.parent, i)
+	s.size = append(s.size, 1)
+	s.count++
+	s.history = append(s.history, change{child: i, root: -1})
+	return true
+}
+
+// Find returns the representative of the set of x. Return false if x is not an element of s.
+// The complexity is O(log n).
+func (s *Rollback[T]) Find(x T) (T, bool) {
+	i, ok := s.index[x]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return s.elems[s.find(i)], true
+}
+
+// find returns the index of the representative of the set of the element at index i.
+func (s *Rollback[T]) find(i int) int {
+	for s.parent[i] != i {
+		i = s.parent[i]
+	}
+	return i
+}
+
+// Union merges the sets of x and y, adding x and y to s if they are not elements of s yet. The representative of the
+// merged set is the one of the larger set, the one of x on a tie.
+// Return false if x and y are already in the same set, in which case nothing is recorded but the additions.
+// The complexity is O(log n).
+func (s *Rollback[T]) Union(x, y T) bool {
+	s.Add(x)
+	s.Add(y)
+	rx, ry := s.find(s.index[x]), s.find(s.index[y])
+	if rx == ry {
+		return false
+	}
+	if s.size[rx] < s.size[ry] {
+		rx, ry = ry, rx
+	}
+	s.parent[ry] = rx
+	s.size[rx] += s.size[ry]
+	s.count--
+	s.history = append(s.history, change{child: ry, root: rx})
+	return true
+}
+
+// Same reports whether x and y are elements of s in the same set.
+// The complexity is O(log n).
+func (s *Rollback[T]) Same(x, y T) bool {
+	i, ok := s.index[x]
+	j, ok2 := s.index[y]
+	return ok && ok2 && s.find(i) == s.find(j)
+}
+
+// Size returns the number of elements in the set of x, 0 if x is not an element of s.
+// The complexity is O(log n).
+func (s *Rollback[T]) Size(x T) int {
+	i, ok := s.index[x]
+	if !ok {
+		return 0
+	}
+	return s.size[s.find(i)]
+}
+
+// Snapshot returns the current state of s, to be restored by RollbackTo.
+func (s *Rollback[T]) Snapshot() int {
+	return len(s.history)
+}
+
+// RollbackTo undoes the additions and unions made since the snapshot was returned by Snapshot. Snapshots taken after
+// it become invalid. It panics if the snapshot is invalid.
+// The complexity is O(k) for k operations undone.
+func (s *Rollback[T]) RollbackTo(snapshot int) {
+	if snapshot < 0 || snapshot > len(s.history) {
+		panic("disjointset: invalid snapshot")
+	}
+	for len(s.history) > snapshot {
+		s.Undo()
+	}
+}
+
+// Undo undoes the last addition or union not undone yet. Return false if there is none.
+// The complexity is O(1).
+func (s *Rollback[T]) Undo() bool {
+	if len(s.history) == 0 {
+		return false
+	}
+	c := s.history[len(s.history)-1]
+	s.history = s.history[:len(s.history)-1]
+
+	if c.root < 0 {
+		delete(s.index, s.elems[c.child])
+		var zero T
+		s.elems[c.child] = zero // avoid memory leaks
+		s.elems = s.elems[:c.child]
+		s.parent = s.parent[:c.child]
+		s.size = s.size[:c.child]
+		s.count--
+		return true
+	}
+
+	s.parent[c.child] = c.child
+	s.size[c.root] -= s.size[c.child]
+	s.count++
+	return true
+}
+
+// Groups returns the members of every set of s, the sets in the order of their first element added, and the members
+// of a set in the order they were added. Unlike DisjointSet, the lists are built on demand, since splicing them could
+// not be undone in O(1).
+// The complexity is O(n log n).
+func (s *Rollback[T]) Groups() []*linkedlist.List[T] {
+	groups := make([]*linkedlist.List[T], 0, s.count)
+	lists := make(map[int]*linkedlist.List[T], s.count)
+	for i, x := range s.elems {
+		r := s.find(i)
+		l, ok := lists[r]
+		if !ok {
+			l = linkedlist.New[T]()
+			lists[r] = l
+			groups = append(groups, l)
+		}
+		l.PushBack(x)
+	}
+	return groups
+}
@@ -0,0 +1,108 @@
+package disjointset
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRollback(t *testing.T) {
+	s := NewRollback[string]()
+	if _, ok := s.Find("a"); ok || s.Same("a", "a") || s.Size("a") != 0 || s.Undo() {
+		t.Errorf("empty set: Find, Same, Size or Undo succeeded")
+	}
+
+	for _, x := range []string{"a", "b", "c", "d"} {
+		s.Add(x)
+	}
+	snap := s.Snapshot()
+	if !s.Union("a", "b") || !s.Union("c", "d") || s.Union("b", "a") {
+		t.Errorf("Union results are wrong")
+	}
+	inner := s.Snapshot()
+	if !s.Union("b", "d") || !s.Union("e", "a") {
+		t.Errorf("Union results are wrong")
+	}
+	if s.Count() != 1 || s.Len() != 5 || s.Size("c") != 5 {
+		t.Errorf("Count(), Len(), Size(c) = %d, %d, %d, want 1, 5, 5", s.Count(), s.Len(), s.Size("c"))
+	}
+	if got, want := groupValues(s.Groups()), [][]string{{"a", "b", "c", "d", "e"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Groups() = %v, want %v", got, want)
+	}
+
+	s.RollbackTo(inner)
+	if s.Count() != 2 || s.Len() != 4 || s.Contains("e") || s.Same("a", "c") || !s.Same("c", "d") {
+		t.Errorf("after RollbackTo(inner): Count(), Len() = %d, %d, want 2, 4", s.Count(), s.Len())
+	}
+	if got, want := groupValues(s.Groups()), [][]string{{"a", "b"}, {"c", "d"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Groups() = %v, want %v", got, want)
+	}
+
+	if !s.Undo() || s.Same("c", "d") || s.Count() != 3 {
+		t.Errorf("Undo did not undo Union(c, d)")
+	}
+	s.RollbackTo(snap)
+	if s.Count() != 4 || s.Size("a") != 1 {
+		t.Errorf("after RollbackTo(snap): Count(), Size(a) = %d, %d, want 4, 1", s.Count(), s.Size("a"))
+	}
+
+	s.RollbackTo(0)
+	if s.Len() != 0 || s.Count() != 0 || s.Contains("a") {
+		t.Errorf("after RollbackTo(0): Len(), Count() = %d, %d, want 0, 0", s.Len(), s.Count())
+	}
+}
+
+func TestRollbackInvalidSnapshot(t *testing.T) {
+	s := NewRollback[int]()
+	s.Add(1)
+	snap := s.Snapshot()
+	s.RollbackTo(0)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RollbackTo(%d) after RollbackTo(0) did not panic", snap)
+		}
+	}()
+	s.RollbackTo(snap)
+}
+
+func TestRollbackRandom(t *testing.T) {
+	const n = 50
+	r := rand.New(rand.NewSource(1))
+	s := NewRollback[int]()
+	for i := 0; i < n; i++ {
+		s.Add(i)
+	}
+
+	// the stack of snapshots, and of the models saved with them
+	var snaps []int
+	var models []labels
+	model := newLabels(n)
+
+	for step := 0; step < 2000; step++ {
+		switch op := r.Intn(10); {
+		case op < 2:
+			snaps = append(snaps, s.Snapshot())
+			models = append(models, append(labels(nil), model...))
+		case op < 4 && len(snaps) > 0:
+			k := r.Intn(len(snaps))
+			s.RollbackTo(snaps[k])
+			model = models[k]
+			snaps, models = snaps[:k], models[:k]
+		default:
+			x, y := r.Intn(n), r.Intn(n)
+			if got, want := s.Union(x, y), model.union(x, y); got != want {
+				t.Fatalf("step %d: Union(%d, %d) = %v, want %v", step, x, y, got, want)
+			}
+		}
+
+		if got, want := s.Count(), model.count(); got != want {
+			t.Fatalf("step %d: Count() = %d, want %d", step, got, want)
+		}
+		x, y := r.Intn(n), r.Intn(n)
+		if s.Same(x, y) != (model[x] == model[y]) || s.Size(x) != model.size(x) {
+			t.Fatalf("step %d: Same(%d, %d), Size(%d) = %v, %d, want %v, %d",
+				step, x, y, x, s.Same(x, y), s.Size(x), model[x] == model[y], model.size(x))
+		}
+	}
+}
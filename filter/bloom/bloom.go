@@ -0,0 +1,227 @@
+// Package bloom implements Bloom filters and counting Bloom filters.
+//
+// A Bloom filter is a set that answers membership queries with false positives but no false negatives: Test returns
+// false for a value that was never added, except with a probability, the false positive rate, chosen when the filter
+// is created for an expected number of values. A Filter needs about 9.6 bits per value for a 1% rate. A
+// CountingFilter keeps a counter instead of every bit, which takes 8 times more space, but supports Delete.
+//
+// The filters hash values with double hashing: the k positions of a value are h1 + i*h2, for i < k, modulo the
+// size of the filter. The hashes only depend on the values, so a filter can be marshaled and loaded by another process.
+//
+// Structure is not thread safe.
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/nnhatnam/skale/filter/internal/hashing"
+)
+
+var (
+	// ErrIncompatible is returned by Union when the filters do not have the same size and number of hashes.
+	ErrIncompatible = errors.New("bloom: filters have different parameters")
+	// ErrInvalidData is returned by UnmarshalBinary when the data is not a marshaled filter of the right kind.
+	ErrInvalidData = errors.New("bloom: invalid data")
+)
+
+// Parameters returns the number of bits m and of hashes k of a filter holding n values with a false positive rate p.
+// It panics if n is not positive or p is not in (0, 1).
+func Parameters(n int, p float64) (m, k int) {
+	if n <= 0 {
+		panic("bloom: expected number of values must be positive")
+	}
+	if p <= 0 || p >= 1 {
+		panic("bloom: false positive rate must be in (0, 1)")
+	}
+	fm := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k = int(math.Round(fm / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return int(fm), k
+}
+
+// Filter is a Bloom filter.
+type Filter struct {
+	bits []uint64
+	m    uint64 // number of bits
+	k    uint64 // number of hashes
+}
+
+// New returns an empty filter for n values with a false positive rate p.
+// It panics if n is not positive or p is not in (0, 1).
+func New(n int, p float64) *Filter {
+	return NewWithSize(Parameters(n, p))
+}
+
+// NewWithSize returns an empty filter of m bits and k hashes. It panics if m or k is not positive.
+func NewWithSize(m, k int) *Filter {
+	if m <= 0 || k <= 0 {
+		panic("bloom: size and number of hashes must be positive")
+	}
+	return &Filter{bits: make([]uint64, (m+63)/64), m: uint64(m), k: uint64(k)}
+}
+
+// Size returns the number of bits of the filter.
+func (f *Filter) Size() int {
+	return int(f.m)
+}
+
+// Hashes returns the number of hashes of the filter, the bits set by Add.
+func (f *Filter) Hashes() int {
+	return int(f.k)
+}
+
+// add sets the bits of the hashes h1 and h2.
+func (f *Filter) add(h1, h2 uint64) {
+	for i := uint64(0); i < f.k; i++ {
+		b := (h1 + i*h2) % f.m
+		f.bits[b/64] |= 1 << (b % 64)
+	}
+}
+
+// test reports whether the bits of the hashes h1 and h2 are set.
+func (f *Filter) test(h1, h2 uint64) bool {
+	for i := uint64(0); i < f.k; i++ {
+		b := (h1 + i*h2) % f.m
+		if f.bits[b/64]&(1<<(b%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Add adds data to the filter.
+// The complexity is O(k + len(data)).
+func (f *Filter) Add(data []byte) {
+	f.add(hashing.Sum(data))
+}
+
+// AddString is like Add, for data given as a string.
+func (f *Filter) AddString(data string) {
+	f.add(hashing.Sum(data))
+}
+
+// Test reports whether data may have been added to the filter. Return false if it certainly was not.
+// The complexity is O(k + len(data)).
+func (f *Filter) Test(data []byte) bool {
+	return f.test(hashing.Sum(data))
+}
+
+// TestString is like Test, for data given as a string.
+func (f *Filter) TestString(data string) bool {
+	return f.test(hashing.Sum(data))
+}
+
+// Union adds the values of other to f, which then tests true for the values added to either filter.
+// Return ErrIncompatible if the filters do not have the same size and number of hashes.
+// The complexity is O(m).
+func (f *Filter) Union(other *Filter) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrIncompatible
+	}
+	for i, w := range other.bits {
+		f.bits[i] |= w
+	}
+	return nil
+}
+
+// Clear removes all the values of the filter.
+func (f *Filter) Clear() {
+	for i := range f.bits {
+		f.bits[i] = 0
+	}
+}
+
+// Fill returns the fraction of the bits that are set. The false positive rate grows with it.
+// The complexity is O(m).
+func (f *Filter) Fill() float64 {
+	n := 0
+	for _, w := range f.bits {
+		n += bits.OnesCount64(w)
+	}
+	return float64(n) / float64(f.m)
+}
+
+// EstimatedLen returns an estimate of the number of distinct values added to the filter, from its fill.
+// The complexity is O(m).
+func (f *Filter) EstimatedLen() int {
+	return estimatedLen(f.Fill(), f.m, f.k)
+}
+
+// EstimatedFalsePositiveRate returns an estimate of the current false positive rate of the filter, from its fill.
+// The complexity is O(m).
+func (f *Filter) EstimatedFalsePositiveRate() float64 {
+	return math.Pow(f.Fill(), float64(f.k))
+}
+
+// estimatedLen returns the number of values that fill a fraction of a filter of m bits and k hashes.
+func estimatedLen(fill float64, m, k uint64) int {
+	if fill >= 1 {
+		return math.MaxInt
+	}
+	return int(math.Round(-float64(m) / float64(k) * math.Log(1-fill)))
+}
+
+// The marshaled filters start with a header: a byte for the kind of filter, a byte for the version of the format,
+// then m and k, big endian.
+const (
+	kindFilter   = 'B'
+	kindCounting = 'C'
+	version      = 1
+	headerSize   = 2 + 8 + 8
+
+	// maxHashes is the largest number of hashes of an unmarshaled filter. The optimal number of hashes for a false
+	// positive rate p is log2(1/p), 64 is far beyond any practical rate.
+	maxHashes = 64
+)
+
+// appendHeader appends the header of a filter to b.
+func appendHeader(b []byte, kind byte, m, k uint64) []byte {
+	b = append(b, kind, version)
+	b = binary.BigEndian.AppendUint64(b, m)
+	return binary.BigEndian.AppendUint64(b, k)
+}
+
+// readHeader reads the header of a filter of the kind from data, and returns m, k and the rest of the data.
+func readHeader(data []byte, kind byte) (m, k uint64, rest []byte, err error) {
+	if len(data) < headerSize || data[0] != kind || data[1] != version {
+		return 0, 0, nil, ErrInvalidData
+	}
+	m, k = binary.BigEndian.Uint64(data[2:]), binary.BigEndian.Uint64(data[10:])
+	if m == 0 || k == 0 || m > math.MaxInt || k > maxHashes || k > m {
+		return 0, 0, nil, ErrInvalidData
+	}
+	return m, k, data[headerSize:], nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	b := appendHeader(make([]byte, 0, headerSize+8*len(f.bits)), kindFilter, f.m, f.k)
+	for _, w := range f.bits {
+		b = binary.BigEndian.AppendUint64(b, w)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces f by the filter marshaled in data.
+// Return ErrInvalidData if data is not a marshaled Filter.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	m, k, rest, err := readHeader(data, kindFilter)
+	if err != nil {
+		return err
+	}
+	words := (m + 63) / 64
+	if uint64(len(rest)) != 8*words {
+		return ErrInvalidData
+	}
+	f.bits = make([]uint64, words)
+	for i := range f.bits {
+		f.bits[i] = binary.BigEndian.Uint64(rest[8*i:])
+	}
+	f.m, f.k = m, k
+	return nil
+}
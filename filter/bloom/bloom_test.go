@@ -0,0 +1,180 @@
+package bloom
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// falsePositiveRate returns the fraction of trials values never added for which test returns true.
+func falsePositiveRate(test func(s string) bool, trials int) float64 {
+	n := 0
+	for i := 0; i < trials; i++ {
+		if test(fmt.Sprintf("absent-%d", i)) {
+			n++
+		}
+	}
+	return float64(n) / float64(trials)
+}
+
+func TestParameters(t *testing.T) {
+	tests := []struct {
+		n    int
+		p    float64
+		m, k int
+	}{
+		{1000, 0.01, 9586, 7},
+		{1000, 0.001, 14378, 10},
+		{1, 0.5, 2, 1},
+	}
+	for _, tt := range tests {
+		if m, k := Parameters(tt.n, tt.p); m != tt.m || k != tt.k {
+			t.Errorf("Parameters(%d, %v) = %d, %d, want %d, %d", tt.n, tt.p, m, k, tt.m, tt.k)
+		}
+	}
+
+	for _, p := range []float64{0, 1, -0.5} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Parameters(10, %v) did not panic", p)
+				}
+			}()
+			Parameters(10, p)
+		}()
+	}
+}
+
+func TestFilter(t *testing.T) {
+	const n = 10000
+	for _, p := range []float64{0.1, 0.01, 0.001} {
+		f := New(n, p)
+		if f.Fill() != 0 || f.TestString("a") {
+			t.Errorf("p = %v: an empty filter has a fill of %v", p, f.Fill())
+		}
+		for i := 0; i < n; i++ {
+			f.AddString(fmt.Sprintf("value-%d", i))
+		}
+		for i := 0; i < n; i++ {
+			if !f.Test([]byte(fmt.Sprintf("value-%d", i))) {
+				t.Fatalf("p = %v: Test(value-%d) = false, want true", p, i)
+			}
+		}
+
+		if rate := falsePositiveRate(f.TestString, 100000); rate > 1.25*p || rate < p/2 {
+			t.Errorf("p = %v: measured false positive rate %v", p, rate)
+		}
+		want := 1 - math.Exp(-float64(f.Hashes()*n)/float64(f.Size()))
+		if fill := f.Fill(); math.Abs(fill-want) > 0.02 {
+			t.Errorf("p = %v: Fill() = %v, want about %v", p, fill, want)
+		}
+		if est := f.EstimatedLen(); math.Abs(float64(est-n)) > 0.02*n {
+			t.Errorf("p = %v: EstimatedLen() = %d, want about %d", p, est, n)
+		}
+		if est := f.EstimatedFalsePositiveRate(); math.Abs(est-p) > 0.2*p {
+			t.Errorf("p = %v: EstimatedFalsePositiveRate() = %v, want about %v", p, est, p)
+		}
+
+		f.Clear()
+		if f.Fill() != 0 || f.TestString("value-1") {
+			t.Errorf("p = %v: Clear() left a fill of %v", p, f.Fill())
+		}
+	}
+}
+
+func TestFilterUnion(t *testing.T) {
+	f, g := New(100, 0.01), New(100, 0.01)
+	for i := 0; i < 50; i++ {
+		f.AddString(fmt.Sprintf("f-%d", i))
+		g.AddString(fmt.Sprintf("g-%d", i))
+	}
+	if err := f.Union(g); err != nil {
+		t.Fatalf("Union() = %v, want nil", err)
+	}
+	for i := 0; i < 50; i++ {
+		if !f.TestString(fmt.Sprintf("f-%d", i)) || !f.TestString(fmt.Sprintf("g-%d", i)) {
+			t.Errorf("the union does not hold f-%d or g-%d", i, i)
+		}
+	}
+	if est := f.EstimatedLen(); est < 90 || est > 110 {
+		t.Errorf("EstimatedLen() of the union = %d, want about 100", est)
+	}
+
+	if err := f.Union(New(1000, 0.01)); err != ErrIncompatible {
+		t.Errorf("Union() of filters of different sizes = %v, want ErrIncompatible", err)
+	}
+	if err := f.Union(NewWithSize(f.Size(), f.Hashes()+1)); err != ErrIncompatible {
+		t.Errorf("Union() of filters of different hashes = %v, want ErrIncompatible", err)
+	}
+}
+
+func TestFilterMarshal(t *testing.T) {
+	f := New(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		f.AddString(fmt.Sprintf("value-%d", i))
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() = %v", err)
+	}
+
+	var g Filter
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() = %v", err)
+	}
+	if g.Size() != f.Size() || g.Hashes() != f.Hashes() || g.Fill() != f.Fill() {
+		t.Errorf("unmarshaled filter: size %d, hashes %d, fill %v, want %d, %d, %v",
+			g.Size(), g.Hashes(), g.Fill(), f.Size(), f.Hashes(), f.Fill())
+	}
+	for i := 0; i < 1000; i++ {
+		if !g.TestString(fmt.Sprintf("value-%d", i)) {
+			t.Fatalf("unmarshaled filter: Test(value-%d) = false", i)
+		}
+	}
+
+	counting, _ := NewCounting(10, 0.1).MarshalBinary()
+	for _, bad := range [][]byte{nil, data[:10], data[:len(data)-1], append(data, 0), counting} {
+		if err := g.UnmarshalBinary(bad); err != ErrInvalidData {
+			t.Errorf("UnmarshalBinary() of %d bytes = %v, want ErrInvalidData", len(bad), err)
+		}
+	}
+}
+
+func TestUnmarshalHeader(t *testing.T) {
+	// data returns a marshaled filter of the kind with m, k and words of set bits or counters
+	data := func(kind byte, m, k uint64, words int) []byte {
+		return append(appendHeader(nil, kind, m, k), bytes.Repeat([]byte{0xff}, 8*words)...)
+	}
+	tests := []struct {
+		name string
+		m, k uint64
+	}{
+		{"m = 0", 0, 1},
+		{"k = 0", 64, 0},
+		{"huge m", 1 << 62, 1},
+		{"m > MaxInt", math.MaxUint64, 1},
+		{"huge k", 64, 1 << 62},
+		{"k > 64", 1024, 65},
+		{"k > m", 8, 9},
+	}
+	for _, tt := range tests {
+		var f Filter
+		if err := f.UnmarshalBinary(data(kindFilter, tt.m, tt.k, 1)); err != ErrInvalidData {
+			t.Errorf("Filter.UnmarshalBinary() with %s = %v, want ErrInvalidData", tt.name, err)
+		}
+		var c CountingFilter
+		if err := c.UnmarshalBinary(data(kindCounting, tt.m, tt.k, 1)); err != ErrInvalidData {
+			t.Errorf("CountingFilter.UnmarshalBinary() with %s = %v, want ErrInvalidData", tt.name, err)
+		}
+	}
+
+	var f Filter
+	if err := f.UnmarshalBinary(data(kindFilter, 64, 64, 1)); err != nil || !f.TestString("a") {
+		t.Errorf("Filter.UnmarshalBinary() with k = 64 = %v, want nil", err)
+	}
+	var c CountingFilter
+	if err := c.UnmarshalBinary(data(kindCounting, 8, 8, 1)); err != nil || !c.TestString("a") {
+		t.Errorf("CountingFilter.UnmarshalBinary() with k = m = %v, want nil", err)
+	}
+}
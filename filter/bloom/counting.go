@@ -0,0 +1,176 @@
+package bloom
+
+import (
+	"math"
+
+	"github.com/nnhatnam/skale/filter/internal/hashing"
+)
+
+// CountingFilter is a counting Bloom filter: a Bloom filter with a counter for every bit, which supports Delete.
+//
+// Counters are 8 bits wide and saturate: a counter reaching 255 is never decremented again, which keeps the filter
+// free of false negatives at the cost of a few positions that stay set.
+type CountingFilter struct {
+	counts []uint8
+	m      uint64
+	k      uint64
+}
+
+// NewCounting returns an empty counting filter for n values with a false positive rate p.
+// It panics if n is not positive or p is not in (0, 1).
+func NewCounting(n int, p float64) *CountingFilter {
+	return NewCountingWithSize(Parameters(n, p))
+}
+
+// NewCountingWithSize returns an empty counting filter of m counters and k hashes. It panics if m or k is not positive.
+func NewCountingWithSize(m, k int) *CountingFilter {
+	if m <= 0 || k <= 0 {
+		panic("bloom: size and number of hashes must be positive")
+	}
+	return &CountingFilter{counts: make([]uint8, m), m: uint64(m), k: uint64(k)}
+}
+
+// Size returns the number of counters of the filter.
+func (f *CountingFilter) Size() int {
+	return int(f.m)
+}
+
+// Hashes returns the number of hashes of the filter, the counters incremented by Add.
+func (f *CountingFilter) Hashes() int {
+	return int(f.k)
+}
+
+// add increments the counters of the hashes h1 and h2.
+func (f *CountingFilter) add(h1, h2 uint64) {
+	for i := uint64(0); i < f.k; i++ {
+		if c := &f.counts[(h1+i*h2)%f.m]; *c < math.MaxUint8 {
+			*c++
+		}
+	}
+}
+
+// test reports whether the counters of the hashes h1 and h2 are positive.
+func (f *CountingFilter) test(h1, h2 uint64) bool {
+	for i := uint64(0); i < f.k; i++ {
+		if f.counts[(h1+i*h2)%f.m] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// delete decrements the counters of the hashes h1 and h2, if they are all positive.
+func (f *CountingFilter) delete(h1, h2 uint64) bool {
+	if !f.test(h1, h2) {
+		return false
+	}
+	for i := uint64(0); i < f.k; i++ {
+		if c := &f.counts[(h1+i*h2)%f.m]; *c < math.MaxUint8 {
+			*c--
+		}
+	}
+	return true
+}
+
+// Add adds data to the filter.
+// The complexity is O(k + len(data)).
+func (f *CountingFilter) Add(data []byte) {
+	f.add(hashing.Sum(data))
+}
+
+// AddString is like Add, for data given as a string.
+func (f *CountingFilter) AddString(data string) {
+	f.add(hashing.Sum(data))
+}
+
+// Test reports whether data may have been added to the filter. Return false if it certainly was not.
+// The complexity is O(k + len(data)).
+func (f *CountingFilter) Test(data []byte) bool {
+	return f.test(hashing.Sum(data))
+}
+
+// TestString is like Test, for data given as a string.
+func (f *CountingFilter) TestString(data string) bool {
+	return f.test(hashing.Sum(data))
+}
+
+// Delete removes data from the filter. Return false if data certainly was not in the filter, which is left unchanged.
+// data must have been added: deleting a false positive removes other values from the filter.
+// The complexity is O(k + len(data)).
+func (f *CountingFilter) Delete(data []byte) bool {
+	return f.delete(hashing.Sum(data))
+}
+
+// DeleteString is like Delete, for data given as a string.
+func (f *CountingFilter) DeleteString(data string) bool {
+	return f.delete(hashing.Sum(data))
+}
+
+// Union adds the values of other to f, adding their counters.
+// Return ErrIncompatible if the filters do not have the same size and number of hashes.
+// The complexity is O(m).
+func (f *CountingFilter) Union(other *CountingFilter) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrIncompatible
+	}
+	for i, c := range other.counts {
+		if sum := int(f.counts[i]) + int(c); sum < math.MaxUint8 {
+			f.counts[i] = uint8(sum)
+		} else {
+			f.counts[i] = math.MaxUint8
+		}
+	}
+	return nil
+}
+
+// Clear removes all the values of the filter.
+func (f *CountingFilter) Clear() {
+	for i := range f.counts {
+		f.counts[i] = 0
+	}
+}
+
+// Fill returns the fraction of the counters that are positive. The false positive rate grows with it.
+// The complexity is O(m).
+func (f *CountingFilter) Fill() float64 {
+	n := 0
+	for _, c := range f.counts {
+		if c > 0 {
+			n++
+		}
+	}
+	return float64(n) / float64(f.m)
+}
+
+// EstimatedLen returns an estimate of the number of distinct values in the filter, from its fill.
+// The complexity is O(m).
+func (f *CountingFilter) EstimatedLen() int {
+	return estimatedLen(f.Fill(), f.m, f.k)
+}
+
+// EstimatedFalsePositiveRate returns an estimate of the current false positive rate of the filter, from its fill.
+// The complexity is O(m).
+func (f *CountingFilter) EstimatedFalsePositiveRate() float64 {
+	return math.Pow(f.Fill(), float64(f.k))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (f *CountingFilter) MarshalBinary() ([]byte, error) {
+	b := appendHeader(make([]byte, 0, headerSize+len(f.counts)), kindCounting, f.m, f.k)
+	return append(b, f.counts...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces f by the filter marshaled in data.
+// Return ErrInvalidData if data is not a marshaled CountingFilter.
+func (f *CountingFilter) UnmarshalBinary(data []byte) error {
+	m, k, rest, err := readHeader(data, kindCounting)
+	if err != nil {
+		return err
+	}
+	if uint64(len(rest)) != m {
+		return ErrInvalidData
+	}
+	f.counts = append([]uint8(nil), rest...)
+	f.m, f.k = m, k
+	return nil
+}
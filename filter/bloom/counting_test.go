@@ -0,0 +1,92 @@
+package bloom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestCountingFilter(t *testing.T) {
+	const n, p = 10000, 0.01
+	f := NewCounting(n, p)
+	for i := 0; i < n; i++ {
+		f.AddString(fmt.Sprintf("value-%d", i))
+	}
+	if rate := falsePositiveRate(f.TestString, 100000); rate > 1.25*p || rate < p/2 {
+		t.Errorf("measured false positive rate %v, want about %v", rate, p)
+	}
+	if est := f.EstimatedLen(); math.Abs(float64(est-n)) > 0.02*n {
+		t.Errorf("EstimatedLen() = %d, want about %d", est, n)
+	}
+	if est := f.EstimatedFalsePositiveRate(); math.Abs(est-p) > 0.2*p {
+		t.Errorf("EstimatedFalsePositiveRate() = %v, want about %v", est, p)
+	}
+
+	// delete the even values: the odd ones are still there, and the false positive rate drops
+	for i := 0; i < n; i += 2 {
+		if !f.Delete([]byte(fmt.Sprintf("value-%d", i))) {
+			t.Fatalf("Delete(value-%d) = false, want true", i)
+		}
+	}
+	for i := 1; i < n; i += 2 {
+		if !f.TestString(fmt.Sprintf("value-%d", i)) {
+			t.Fatalf("Test(value-%d) = false after deleting the even values", i)
+		}
+	}
+	if rate := falsePositiveRate(f.TestString, 100000); rate > p/4 {
+		t.Errorf("measured false positive rate %v after deleting half the values, want below %v", rate, p/4)
+	}
+
+	for i := 1; i < n; i += 2 {
+		f.DeleteString(fmt.Sprintf("value-%d", i))
+	}
+	if f.Fill() != 0 {
+		t.Errorf("Fill() = %v after deleting every value, want 0", f.Fill())
+	}
+	if f.DeleteString("value-1") {
+		t.Errorf("Delete(value-1) of an empty filter = true")
+	}
+}
+
+func TestCountingSaturation(t *testing.T) {
+	f := NewCountingWithSize(8, 1)
+	for i := 0; i < 300; i++ {
+		f.AddString("a")
+	}
+	for i := 0; i < 300; i++ {
+		f.DeleteString("a")
+	}
+	if !f.TestString("a") {
+		t.Errorf("a saturated counter was decremented")
+	}
+}
+
+func TestCountingUnionMarshal(t *testing.T) {
+	f, g := NewCounting(100, 0.01), NewCounting(100, 0.01)
+	f.AddString("a")
+	g.AddString("a")
+	g.AddString("b")
+	if err := f.Union(g); err != nil {
+		t.Fatalf("Union() = %v, want nil", err)
+	}
+	if err := f.Union(NewCounting(10, 0.01)); err != ErrIncompatible {
+		t.Errorf("Union() of filters of different sizes = %v, want ErrIncompatible", err)
+	}
+
+	data, _ := f.MarshalBinary()
+	var h CountingFilter
+	if err := h.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() = %v", err)
+	}
+	if bad, _ := New(10, 0.1).MarshalBinary(); h.UnmarshalBinary(bad) != ErrInvalidData {
+		t.Errorf("UnmarshalBinary() of a Filter did not fail")
+	}
+
+	// a was added twice: it survives one deletion
+	if !h.DeleteString("a") || !h.TestString("a") || !h.DeleteString("b") || h.TestString("b") {
+		t.Errorf("the counters were not added by Union or not kept by MarshalBinary")
+	}
+	if !h.DeleteString("a") || h.TestString("a") || h.Fill() != 0 {
+		t.Errorf("the filter is not empty after deleting every value, fill %v", h.Fill())
+	}
+}
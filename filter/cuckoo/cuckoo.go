@@ -0,0 +1,372 @@
+// Package cuckoo implements cuckoo filters.
+//
+// A cuckoo filter is a set that answers membership queries with false positives but no false negatives, like a Bloom
+// filter, and supports Delete without counters. It stores a short fingerprint of every value in one of two buckets
+// of 4 slots, moving fingerprints to their other bucket to make room, as cuckoo hashing does. For false positive rates
+// below 3%, it takes less space than a Bloom filter.
+//
+// A filter has a fixed capacity: Add fails once the filter is full, at about 95% of its slots.
+//
+// Structure is not thread safe.
+package cuckoo
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/nnhatnam/skale/filter/internal/hashing"
+)
+
+var (
+	// ErrFull is returned by Union when the filter has no room left for the values of the other filter.
+	ErrFull = errors.New("cuckoo: filter is full")
+	// ErrIncompatible is returned by Union when the filters do not have the same number of buckets and fingerprint size.
+	ErrIncompatible = errors.New("cuckoo: filters have different parameters")
+	// ErrInvalidData is returned by UnmarshalBinary when the data is not a marshaled filter.
+	ErrInvalidData = errors.New("cuckoo: invalid data")
+)
+
+const (
+	// bucketSize is the number of slots of a bucket.
+	bucketSize = 4
+	// maxLoad is the fraction of the slots a filter is sized to use.
+	maxLoad = 0.95
+	// maxKicks is the number of fingerprints moved by Add before it gives up.
+	maxKicks = 500
+
+	minFingerprintBits = 4
+	maxFingerprintBits = 32
+)
+
+// victim is a fingerprint left without a slot by the last Add, which made the filter full.
+type victim struct {
+	fp    uint32
+	index uint64
+	ok    bool
+}
+
+// Filter is a cuckoo filter.
+type Filter struct {
+	slots   []uint64 // the slots of the buckets, packed, fpBits bits each. An empty slot holds 0.
+	buckets uint64   // number of buckets, a power of two
+	fpBits  uint
+	count   int
+	victim  victim
+	rand    uint64 // state of the generator choosing the fingerprints to move
+}
+
+// New returns an empty filter for n values with a false positive rate p.
+// The fingerprints have between 4 and 32 bits, which bounds the false positive rate to about 2e-9.
+// It panics if n is not positive or p is not in (0, 1).
+func New(n int, p float64) *Filter {
+	if n <= 0 {
+		panic("cuckoo: expected number of values must be positive")
+	}
+	if p <= 0 || p >= 1 {
+		panic("cuckoo: false positive rate must be in (0, 1)")
+	}
+
+	// a value is tested against the 2*bucketSize slots of its buckets
+	fpBits := uint(math.Ceil(math.Log2(2 * bucketSize / p)))
+	if fpBits < minFingerprintBits {
+		fpBits = minFingerprintBits
+	}
+	if fpBits > maxFingerprintBits {
+		fpBits = maxFingerprintBits
+	}
+	buckets := uint64(math.Ceil(float64(n) / (bucketSize * maxLoad)))
+	if buckets&(buckets-1) != 0 {
+		buckets = 1 << bits.Len64(buckets)
+	}
+	return newFilter(buckets, fpBits)
+}
+
+func newFilter(buckets uint64, fpBits uint) *Filter {
+	return &Filter{
+		slots:   make([]uint64, (buckets*bucketSize*uint64(fpBits)+63)/64),
+		buckets: buckets,
+		fpBits:  fpBits,
+		rand:    1,
+	}
+}
+
+// Len returns the number of values in the filter.
+func (f *Filter) Len() int {
+	return f.count
+}
+
+// Cap returns the number of slots of the filter, above its capacity.
+func (f *Filter) Cap() int {
+	return int(f.buckets * bucketSize)
+}
+
+// FingerprintBits returns the number of bits of the fingerprints.
+func (f *Filter) FingerprintBits() int {
+	return int(f.fpBits)
+}
+
+// slot returns the fingerprint in slot j of bucket i.
+func (f *Filter) slot(i uint64, j int) uint32 {
+	pos := (i*bucketSize + uint64(j)) * uint64(f.fpBits)
+	w, off := pos/64, pos%64
+	v := f.slots[w] >> off
+	if off+uint64(f.fpBits) > 64 {
+		v |= f.slots[w+1] << (64 - off)
+	}
+	return uint32(v & (1<<f.fpBits - 1))
+}
+
+// setSlot sets the fingerprint in slot j of bucket i to fp.
+func (f *Filter) setSlot(i uint64, j int, fp uint32) {
+	pos := (i*bucketSize + uint64(j)) * uint64(f.fpBits)
+	w, off := pos/64, pos%64
+	mask := uint64(1)<<f.fpBits - 1
+	f.slots[w] = f.slots[w]&^(mask<<off) | uint64(fp)<<off
+	if off+uint64(f.fpBits) > 64 {
+		f.slots[w+1] = f.slots[w+1]&^(mask>>(64-off)) | uint64(fp)>>(64-off)
+	}
+}
+
+// locate returns the fingerprint of the hashes h1 and h2, never 0, and its first bucket.
+func (f *Filter) locate(h1, h2 uint64) (uint32, uint64) {
+	fp := uint32(h2 >> (64 - f.fpBits))
+	if fp == 0 {
+		fp = 1
+	}
+	return fp, h1 & (f.buckets - 1)
+}
+
+// alt returns the other bucket of fingerprint fp in bucket i. alt(alt(i, fp), fp) is i.
+func (f *Filter) alt(i uint64, fp uint32) uint64 {
+	return (i ^ hashing.Mix(uint64(fp))) & (f.buckets - 1)
+}
+
+// put puts fp in an empty slot of bucket i. Return false if the bucket is full.
+func (f *Filter) put(i uint64, fp uint32) bool {
+	for j := 0; j < bucketSize; j++ {
+		if f.slot(i, j) == 0 {
+			f.setSlot(i, j, fp)
+			return true
+		}
+	}
+	return false
+}
+
+// take removes fp from bucket i. Return false if fp is not in the bucket.
+func (f *Filter) take(i uint64, fp uint32) bool {
+	for j := 0; j < bucketSize; j++ {
+		if f.slot(i, j) == fp {
+			f.setSlot(i, j, 0)
+			return true
+		}
+	}
+	return false
+}
+
+// has reports whether bucket i holds fp.
+func (f *Filter) has(i uint64, fp uint32) bool {
+	for j := 0; j < bucketSize; j++ {
+		if f.slot(i, j) == fp {
+			return true
+		}
+	}
+	return false
+}
+
+// random returns a pseudo-random number, from a xorshift generator.
+func (f *Filter) random() uint64 {
+	f.rand ^= f.rand << 13
+	f.rand ^= f.rand >> 7
+	f.rand ^= f.rand << 17
+	return f.rand
+}
+
+// add adds fingerprint fp, of bucket i. When both buckets of fp are full, it moves fingerprints to their other bucket
+// until one finds an empty slot. The last fingerprint moved becomes the victim if none does, and the filter is full.
+// Return false if the filter was already full.
+func (f *Filter) add(fp uint32, i uint64) bool {
+	if f.victim.ok {
+		return false
+	}
+	f.count++
+	if f.put(i, fp) {
+		return true
+	}
+	if i = f.alt(i, fp); f.put(i, fp) {
+		return true
+	}
+	for n := 0; n < maxKicks; n++ {
+		j := int(f.random() % bucketSize)
+		old := f.slot(i, j)
+		f.setSlot(i, j, fp)
+		fp, i = old, f.alt(i, old)
+		if f.put(i, fp) {
+			return true
+		}
+	}
+	f.victim = victim{fp: fp, index: i, ok: true}
+	return true
+}
+
+// contains reports whether fingerprint fp, of bucket i, is in the filter.
+func (f *Filter) contains(fp uint32, i uint64) bool {
+	i2 := f.alt(i, fp)
+	if f.victim.ok && f.victim.fp == fp && (f.victim.index == i || f.victim.index == i2) {
+		return true
+	}
+	return f.has(i, fp) || f.has(i2, fp)
+}
+
+// delete removes fingerprint fp, of bucket i, and gives its slot to the victim.
+func (f *Filter) delete(fp uint32, i uint64) bool {
+	i2 := f.alt(i, fp)
+	switch {
+	case f.victim.ok && f.victim.fp == fp && (f.victim.index == i || f.victim.index == i2):
+		f.victim = victim{}
+		f.count--
+		return true
+	case !f.take(i, fp) && !f.take(i2, fp):
+		return false
+	}
+	f.count--
+	if v := f.victim; v.ok {
+		f.victim = victim{}
+		f.count--
+		f.add(v.fp, v.index)
+	}
+	return true
+}
+
+// Add adds data to the filter. Return false if the filter is full, in which case data is not added.
+// A value can be added several times, up to 2*4 times, and must then be deleted as many times.
+// The complexity is O(len(data)) amortized.
+func (f *Filter) Add(data []byte) bool {
+	return f.add(f.locate(hashing.Sum(data)))
+}
+
+// AddString is like Add, for data given as a string.
+func (f *Filter) AddString(data string) bool {
+	return f.add(f.locate(hashing.Sum(data)))
+}
+
+// Test reports whether data may have been added to the filter. Return false if it certainly was not.
+// The complexity is O(len(data)).
+func (f *Filter) Test(data []byte) bool {
+	return f.contains(f.locate(hashing.Sum(data)))
+}
+
+// TestString is like Test, for data given as a string.
+func (f *Filter) TestString(data string) bool {
+	return f.contains(f.locate(hashing.Sum(data)))
+}
+
+// Delete removes data from the filter. Return false if data certainly was not in the filter, which is left unchanged.
+// data must have been added: deleting a false positive removes another value from the filter.
+// The complexity is O(len(data)).
+func (f *Filter) Delete(data []byte) bool {
+	return f.delete(f.locate(hashing.Sum(data)))
+}
+
+// DeleteString is like Delete, for data given as a string.
+func (f *Filter) DeleteString(data string) bool {
+	return f.delete(f.locate(hashing.Sum(data)))
+}
+
+// Union adds the values of other to f. Return ErrIncompatible if the filters do not have the same number of buckets
+// and fingerprint size, and ErrFull if f has no room left for them, in which case only some of them are added.
+// The complexity is O(m) for m slots.
+func (f *Filter) Union(other *Filter) error {
+	if f.buckets != other.buckets || f.fpBits != other.fpBits {
+		return ErrIncompatible
+	}
+	for i := uint64(0); i < other.buckets; i++ {
+		for j := 0; j < bucketSize; j++ {
+			if fp := other.slot(i, j); fp != 0 && !f.add(fp, i) {
+				return ErrFull
+			}
+		}
+	}
+	if v := other.victim; v.ok && !f.add(v.fp, v.index) {
+		return ErrFull
+	}
+	return nil
+}
+
+// Clear removes all the values of the filter.
+func (f *Filter) Clear() {
+	for i := range f.slots {
+		f.slots[i] = 0
+	}
+	f.count = 0
+	f.victim = victim{}
+}
+
+// Fill returns the fraction of the slots in use. Add starts failing at about 0.95.
+func (f *Filter) Fill() float64 {
+	return float64(f.count) / float64(f.Cap())
+}
+
+// EstimatedFalsePositiveRate returns an estimate of the current false positive rate of the filter, from its fill:
+// a value is compared with the fingerprints of its 2 buckets.
+func (f *Filter) EstimatedFalsePositiveRate() float64 {
+	stored := 2 * bucketSize * f.Fill()
+	return 1 - math.Pow(1-1/math.Exp2(float64(f.fpBits)), stored)
+}
+
+// A marshaled filter is a header, then the slots. The header holds a byte for the kind of filter, a byte for the
+// version of the format, then the number of fingerprint bits, the number of buckets, the number of values and the
+// victim, big endian.
+const (
+	kind       = 'K'
+	version    = 1
+	headerSize = 2 + 1 + 8 + 8 + 1 + 4 + 8
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, headerSize+8*len(f.slots))
+	b = append(b, kind, version, byte(f.fpBits))
+	b = binary.BigEndian.AppendUint64(b, f.buckets)
+	b = binary.BigEndian.AppendUint64(b, uint64(f.count))
+	if f.victim.ok {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	b = binary.BigEndian.AppendUint32(b, f.victim.fp)
+	b = binary.BigEndian.AppendUint64(b, f.victim.index)
+	for _, w := range f.slots {
+		b = binary.BigEndian.AppendUint64(b, w)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces f by the filter marshaled in data.
+// Return ErrInvalidData if data is not a marshaled Filter.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize || data[0] != kind || data[1] != version {
+		return ErrInvalidData
+	}
+	fpBits := uint(data[2])
+	buckets := binary.BigEndian.Uint64(data[3:])
+	count := binary.BigEndian.Uint64(data[11:])
+	v := victim{ok: data[19] == 1, fp: binary.BigEndian.Uint32(data[20:]), index: binary.BigEndian.Uint64(data[24:])}
+	if fpBits < minFingerprintBits || fpBits > maxFingerprintBits || buckets == 0 || buckets&(buckets-1) != 0 ||
+		buckets > math.MaxInt/(bucketSize*maxFingerprintBits) || count > buckets*bucketSize+1 || v.index >= buckets {
+		return ErrInvalidData
+	}
+
+	// check the size of the slots before allocating them
+	if words := (buckets*bucketSize*uint64(fpBits) + 63) / 64; uint64(len(data)-headerSize) != 8*words {
+		return ErrInvalidData
+	}
+	g := newFilter(buckets, fpBits)
+	for i := range g.slots {
+		g.slots[i] = binary.BigEndian.Uint64(data[headerSize+8*i:])
+	}
+	g.count, g.victim = int(count), v
+	*f = *g
+	return nil
+}
@@ -0,0 +1,252 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// falsePositiveRate returns the fraction of trials values never added for which f tests true.
+func falsePositiveRate(f *Filter, trials int) float64 {
+	n := 0
+	for i := 0; i < trials; i++ {
+		if f.TestString(fmt.Sprintf("absent-%d", i)) {
+			n++
+		}
+	}
+	return float64(n) / float64(trials)
+}
+
+func TestSlots(t *testing.T) {
+	for fpBits := uint(minFingerprintBits); fpBits <= maxFingerprintBits; fpBits++ {
+		f := newFilter(16, fpBits)
+		max := uint32(1<<fpBits - 1)
+		for i := uint64(0); i < f.buckets; i++ {
+			for j := 0; j < bucketSize; j++ {
+				f.setSlot(i, j, (max-uint32(i)*bucketSize-uint32(j))&max)
+			}
+		}
+		for i := uint64(0); i < f.buckets; i++ {
+			for j := 0; j < bucketSize; j++ {
+				if got, want := f.slot(i, j), (max-uint32(i)*bucketSize-uint32(j))&max; got != want {
+					t.Fatalf("%d bits: slot(%d, %d) = %x, want %x", fpBits, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		n       int
+		p       float64
+		cap, fp int
+	}{
+		{1000, 0.01, 512 * 4, 10},
+		{1000, 0.001, 512 * 4, 13},
+		{100, 0.5, 32 * 4, 4},
+		{1, 1e-12, 4, 32},
+	}
+	for _, tt := range tests {
+		f := New(tt.n, tt.p)
+		if f.Cap() != tt.cap || f.FingerprintBits() != tt.fp {
+			t.Errorf("New(%d, %v): Cap(), FingerprintBits() = %d, %d, want %d, %d",
+				tt.n, tt.p, f.Cap(), f.FingerprintBits(), tt.cap, tt.fp)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	const n = 10000
+	for _, p := range []float64{0.05, 0.01, 0.001} {
+		f := New(n, p)
+		for i := 0; i < n; i++ {
+			if !f.AddString(fmt.Sprintf("value-%d", i)) {
+				t.Fatalf("p = %v: Add(value-%d) = false, the filter is full at %v", p, i, f.Fill())
+			}
+		}
+		for i := 0; i < n; i++ {
+			if !f.Test([]byte(fmt.Sprintf("value-%d", i))) {
+				t.Fatalf("p = %v: Test(value-%d) = false, want true", p, i)
+			}
+		}
+		if f.Len() != n {
+			t.Errorf("p = %v: Len() = %d, want %d", p, f.Len(), n)
+		}
+
+		rate := falsePositiveRate(f, 100000)
+		if rate > p {
+			t.Errorf("p = %v: measured false positive rate %v", p, rate)
+		}
+		if est := f.EstimatedFalsePositiveRate(); math.Abs(est-rate) > 0.25*est+0.0002 {
+			t.Errorf("p = %v: EstimatedFalsePositiveRate() = %v, measured %v", p, est, rate)
+		}
+
+		for i := 0; i < n; i += 2 {
+			if !f.Delete([]byte(fmt.Sprintf("value-%d", i))) {
+				t.Fatalf("p = %v: Delete(value-%d) = false, want true", p, i)
+			}
+		}
+		for i := 1; i < n; i += 2 {
+			if !f.TestString(fmt.Sprintf("value-%d", i)) {
+				t.Fatalf("p = %v: Test(value-%d) = false after deleting the even values", p, i)
+			}
+		}
+		if f.Len() != n/2 {
+			t.Errorf("p = %v: Len() = %d after deleting half the values, want %d", p, f.Len(), n/2)
+		}
+	}
+}
+
+func TestFull(t *testing.T) {
+	f := New(100, 0.01)
+	added := 0
+	for i := 0; f.AddString(fmt.Sprintf("value-%d", i)); i++ {
+		added++
+	}
+	if fill := f.Fill(); fill < 0.9 || !f.victim.ok {
+		t.Errorf("the filter is full at %v, want above 0.9", fill)
+	}
+	if f.AddString("more") {
+		t.Errorf("Add() to a full filter = true")
+	}
+
+	// no false negative, even for the victim
+	for i := 0; i < added; i++ {
+		if !f.TestString(fmt.Sprintf("value-%d", i)) {
+			t.Fatalf("Test(value-%d) = false in a full filter", i)
+		}
+	}
+
+	// a deletion makes room for the victim
+	if !f.DeleteString("value-0") || f.victim.ok || f.Len() != added-1 {
+		t.Errorf("after a deletion: victim %v, Len() = %d, want no victim, %d", f.victim.ok, f.Len(), added-1)
+	}
+	for i := 1; i < added; i++ {
+		if !f.TestString(fmt.Sprintf("value-%d", i)) {
+			t.Fatalf("Test(value-%d) = false after a deletion", i)
+		}
+	}
+	if !f.AddString("more") {
+		t.Errorf("Add() after a deletion = false")
+	}
+
+	f.Clear()
+	if f.Len() != 0 || f.Fill() != 0 || f.TestString("value-1") {
+		t.Errorf("Clear() left %d values", f.Len())
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	f := New(100, 0.01)
+	for i := 0; i < 2*bucketSize; i++ {
+		f.AddString("a")
+	}
+	for i := 0; i < 2*bucketSize; i++ {
+		if !f.TestString("a") || !f.DeleteString("a") {
+			t.Fatalf("deletion %d of a failed", i)
+		}
+	}
+	if f.TestString("a") || f.Len() != 0 {
+		t.Errorf("a is still in the filter after as many deletions as additions")
+	}
+}
+
+func TestUnion(t *testing.T) {
+	f, g := New(1000, 0.01), New(1000, 0.01)
+	for i := 0; i < 400; i++ {
+		f.AddString(fmt.Sprintf("f-%d", i))
+		g.AddString(fmt.Sprintf("g-%d", i))
+	}
+	if err := f.Union(g); err != nil {
+		t.Fatalf("Union() = %v, want nil", err)
+	}
+	if f.Len() != 800 {
+		t.Errorf("Len() of the union = %d, want 800", f.Len())
+	}
+	for i := 0; i < 400; i++ {
+		if !f.TestString(fmt.Sprintf("f-%d", i)) || !f.TestString(fmt.Sprintf("g-%d", i)) {
+			t.Fatalf("the union does not hold f-%d or g-%d", i, i)
+		}
+	}
+
+	if err := f.Union(New(100, 0.01)); err != ErrIncompatible {
+		t.Errorf("Union() of filters of different sizes = %v, want ErrIncompatible", err)
+	}
+
+	// the union of two filters filled at 60% does not fit
+	h := New(1000, 0.01)
+	for i := 0; i < 1200; i++ {
+		h.AddString(fmt.Sprintf("h-%d", i))
+	}
+	if err := f.Union(h); err != ErrFull {
+		t.Errorf("Union() with too many values = %v, want ErrFull", err)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	f := New(100, 0.01)
+	added := 0
+	for i := 0; f.AddString(fmt.Sprintf("value-%d", i)); i++ {
+		added++
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() = %v", err)
+	}
+
+	var g Filter
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() = %v", err)
+	}
+	if g.Len() != f.Len() || g.Cap() != f.Cap() || g.victim != f.victim {
+		t.Errorf("unmarshaled filter: Len(), Cap() = %d, %d, want %d, %d", g.Len(), g.Cap(), f.Len(), f.Cap())
+	}
+	for i := 0; i < added; i++ {
+		if !g.TestString(fmt.Sprintf("value-%d", i)) {
+			t.Fatalf("unmarshaled filter: Test(value-%d) = false", i)
+		}
+	}
+
+	bad := append([]byte(nil), data...)
+	bad[2] = 40 // fingerprint bits
+	for _, bad := range [][]byte{nil, data[:headerSize], data[:len(data)-1], append(data, 0), bad} {
+		if err := g.UnmarshalBinary(bad); err != ErrInvalidData {
+			t.Errorf("UnmarshalBinary() of %d bytes = %v, want ErrInvalidData", len(bad), err)
+		}
+	}
+}
+
+func TestUnmarshalHeader(t *testing.T) {
+	// header returns the header of a filter of buckets buckets and fingerprints of fpBits bits, without slots
+	header := func(fpBits byte, buckets uint64) []byte {
+		b := append([]byte{kind, version, fpBits}, make([]byte, headerSize-3)...)
+		binary.BigEndian.PutUint64(b[3:], buckets)
+		return b
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"no buckets", header(8, 0)},
+		{"buckets not a power of two", append(header(8, 3), make([]byte, 8*3)...)},
+		{"short fingerprints", append(header(3, 16), make([]byte, 8*3)...)},
+		{"long fingerprints", append(header(33, 16), make([]byte, 8*33)...)},
+		{"buckets > MaxInt", header(8, 1<<63)},
+		{"huge buckets", header(8, 1<<50)},
+		{"many buckets", header(32, 1<<40)},
+		{"missing slots", header(8, 1<<20)},
+	}
+	for _, tt := range tests {
+		var f Filter
+		if err := f.UnmarshalBinary(tt.data); err != ErrInvalidData {
+			t.Errorf("UnmarshalBinary() with %s = %v, want ErrInvalidData", tt.name, err)
+		}
+	}
+
+	var f Filter
+	if err := f.UnmarshalBinary(append(header(8, 16), make([]byte, 8*8)...)); err != nil || f.Cap() != 64 {
+		t.Errorf("UnmarshalBinary() of an empty filter = %v, Cap() = %d, want nil, 64", err, f.Cap())
+	}
+}
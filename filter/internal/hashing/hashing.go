@@ -0,0 +1,31 @@
+// Package hashing implements the hash functions of the filters.
+//
+// The hashes only depend on the data, unlike those of hash/maphash, so that a filter marshaled by a process can be
+// loaded by another one.
+package hashing
+
+const (
+	offset64 = 14695981039346656037
+	prime64  = 1099511628211
+)
+
+// Sum returns two 64-bit hashes of data, for double hashing: the i-th hash of data is h1 + i*h2.
+// The hashes are FNV-1a, finalized by two different mixes so that they are independent enough.
+func Sum[K ~string | ~[]byte](data K) (h1, h2 uint64) {
+	h := uint64(offset64)
+	for i := 0; i < len(data); i++ {
+		h ^= uint64(data[i])
+		h *= prime64
+	}
+	return Mix(h), Mix(h ^ 0x9e3779b97f4a7c15)
+}
+
+// Mix returns a hash of x, the finalizer of the SplitMix64 generator.
+func Mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,28 @@
+package graph
+
+// ConnectedComponents returns the connected components of g, each as the list of its vertices in BFS order.
+// The components of a directed graph are its weakly connected components: the direction of the edges is ignored.
+// The components are ordered by their first vertex, in the order the vertices were added.
+// The complexity is O(V+E).
+func (g *Graph[V, W]) ConnectedComponents() [][]V {
+	var components [][]V
+	seen := make(map[V]bool, g.Order())
+	for _, v := range g.Vertices() {
+		if seen[v] {
+			continue
+		}
+		var c []V
+		it := g.iterator(v, false, g.directed, seen)
+		for u, ok := it.Next(); ok; u, ok = it.Next() {
+			c = append(c, u)
+		}
+		components = append(components, c)
+	}
+	return components
+}
+
+// Connected reports whether g is connected, weakly for a directed graph. A graph without vertices is connected.
+// The complexity is O(V+E).
+func (g *Graph[V, W]) Connected() bool {
+	return len(g.ConnectedComponents()) <= 1
+}
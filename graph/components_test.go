@@ -0,0 +1,39 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestConnectedComponents(t *testing.T) {
+	g := NewUndirected[int, int]()
+	if !g.Connected() || len(g.ConnectedComponents()) != 0 {
+		t.Errorf("empty graph: Connected() = false or components %v", g.ConnectedComponents())
+	}
+
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(2, 5, 1)
+	g.AddVertex(6)
+	if got := fmt.Sprint(g.ConnectedComponents()); got != "[[1 2 5] [3 4] [6]]" {
+		t.Errorf("ConnectedComponents() = %v, want [[1 2 5] [3 4] [6]]", got)
+	}
+	if g.Connected() {
+		t.Errorf("Connected() = true, want false")
+	}
+
+	g.AddEdge(6, 4, 1)
+	g.AddEdge(5, 3, 1)
+	if !g.Connected() {
+		t.Errorf("Connected() = false, want true: %v", g.ConnectedComponents())
+	}
+
+	// a directed graph is split in weakly connected components
+	d := NewDirected[int, int]()
+	d.AddEdge(1, 2, 1)
+	d.AddEdge(3, 2, 1)
+	d.AddEdge(4, 5, 1)
+	if got := fmt.Sprint(d.ConnectedComponents()); got != "[[1 2 3] [4 5]]" {
+		t.Errorf("ConnectedComponents() of a directed graph = %v, want [[1 2 3] [4 5]]", got)
+	}
+}
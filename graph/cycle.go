@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+var (
+	// ErrUndirected is returned by the algorithms that only apply to directed graphs.
+	ErrUndirected = errors.New("graph: undirected graph")
+	// ErrDirected is returned by the algorithms that only apply to undirected graphs.
+	ErrDirected = errors.New("graph: directed graph")
+)
+
+// CycleError is returned by TopologicalSort when the graph has a cycle.
+type CycleError[V comparable] struct {
+	// Cycle lists the vertices of a cycle, the last one having an edge back to the first one.
+	Cycle []V
+}
+
+func (e *CycleError[V]) Error() string {
+	return formatCycle("graph: cycle ", e.Cycle)
+}
+
+// formatCycle formats cycle after prefix, like a -> b -> a.
+func formatCycle[V comparable](prefix string, cycle []V) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, v := range cycle {
+		fmt.Fprintf(&b, "%v -> ", v)
+	}
+	if len(cycle) > 0 {
+		fmt.Fprintf(&b, "%v", cycle[0])
+	}
+	return b.String()
+}
+
+// Vertex colors of a DFS.
+const (
+	white = iota // not visited yet
+	gray         // on the DFS stack
+	black        // done
+)
+
+// Cycle returns the vertices of a cycle of g, the last one having an edge back to the first one.
+// In an undirected graph, the edge between two vertices does not make a cycle by itself, but a self loop does.
+// Return nil if g has no cycle.
+// The complexity is O(V+E).
+func (g *Graph[V, W]) Cycle() []V {
+	color := make(map[V]int, g.Order())
+	parent := make(map[V]V, g.Order())
+
+	for _, start := range g.Vertices() {
+		if color[start] != white {
+			continue
+		}
+
+		var stack linkedlist.List[*frame[V, W]]
+		color[start] = gray
+		stack.PushBack(newFrame(g.vertices[start], 0))
+		for top := stack.Back(); top != nil; top = stack.Back() {
+			f := top.Value
+			h := f.next(false)
+			if h == nil {
+				color[f.x.id] = black
+				stack.PopBack()
+				continue
+			}
+
+			v, w := h.from, h.to
+			switch color[w] {
+			case white:
+				color[w] = gray
+				parent[w] = v
+				stack.PushBack(newFrame(g.vertices[w], f.depth+1))
+
+			case gray:
+				if p, ok := parent[v]; !g.directed && ok && p == w {
+					continue // the edge back to the parent in the DFS tree
+				}
+				// the DFS path from w to v, closed by the edge from v to w
+				cycle := []V{v}
+				for u := v; u != w; {
+					u = parent[u]
+					cycle = append(cycle, u)
+				}
+				for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// Acyclic reports whether g has no cycle.
+// The complexity is O(V+E).
+func (g *Graph[V, W]) Acyclic() bool {
+	return g.Cycle() == nil
+}
+
+// TopologicalSort returns the vertices of the directed graph g ordered so that every edge goes from a vertex to a
+// later one. Among the valid orders, vertices are taken as early as possible in the order they were added (Kahn's
+// algorithm). It returns ErrUndirected if g is undirected, and a *CycleError reporting a cycle if g is not acyclic.
+// The complexity is O(V+E).
+func (g *Graph[V, W]) TopologicalSort() ([]V, error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+
+	indegree := make(map[V]int, g.Order())
+	var ready linkedlist.List[V]
+	for _, v := range g.Vertices() {
+		if indegree[v] = g.InDegree(v); indegree[v] == 0 {
+			ready.PushBack(v)
+		}
+	}
+
+	sorted := make([]V, 0, g.Order())
+	for n := ready.PopFront(); n != nil; n = ready.PopFront() {
+		sorted = append(sorted, n.Value)
+		g.WalkNeighbors(n.Value, func(to V, w W) bool {
+			if indegree[to]--; indegree[to] == 0 {
+				ready.PushBack(to)
+			}
+			return true
+		})
+	}
+
+	if len(sorted) < g.Order() {
+		return nil, &CycleError[V]{Cycle: g.Cycle()}
+	}
+	return sorted, nil
+}
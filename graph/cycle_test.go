@@ -0,0 +1,104 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// checkCycle verifies that cycle is a cycle of g.
+func checkCycle[V comparable, W Weight](t *testing.T, g *Graph[V, W], cycle []V) {
+	t.Helper()
+
+	if len(cycle) == 0 {
+		t.Errorf("empty cycle")
+		return
+	}
+	seen := make(map[V]bool)
+	for i, v := range cycle {
+		if seen[v] {
+			t.Errorf("cycle %v goes twice through %v", cycle, v)
+		}
+		seen[v] = true
+		if next := cycle[(i+1)%len(cycle)]; !g.HasEdge(v, next) {
+			t.Errorf("cycle %v uses the missing edge %v -> %v", cycle, v, next)
+		}
+	}
+	if !g.directed && len(cycle) == 2 {
+		t.Errorf("cycle %v of an undirected graph uses an edge twice", cycle)
+	}
+}
+
+func TestCycle(t *testing.T) {
+	d := NewDirected[string, int]()
+	d.AddEdge("a", "b", 1)
+	d.AddEdge("a", "c", 1)
+	d.AddEdge("b", "c", 1)
+	if c := d.Cycle(); c != nil || !d.Acyclic() {
+		t.Errorf("Cycle() of a DAG = %v, want nil", c)
+	}
+
+	d.AddEdge("c", "d", 1)
+	d.AddEdge("d", "b", 1)
+	checkCycle(t, d, d.Cycle())
+	if got := fmt.Sprint(d.Cycle()); got != "[b c d]" {
+		t.Errorf("Cycle() = %v, want [b c d]", got)
+	}
+
+	d.RemoveEdge("d", "b")
+	d.AddEdge("d", "d", 1)
+	if got := fmt.Sprint(d.Cycle()); got != "[d]" {
+		t.Errorf("Cycle() with a self loop = %v, want [d]", got)
+	}
+
+	u := NewUndirected[int, int]()
+	u.AddEdge(1, 2, 1)
+	u.AddEdge(2, 3, 1)
+	u.AddEdge(2, 4, 1)
+	if c := u.Cycle(); c != nil {
+		t.Errorf("Cycle() of a tree = %v, want nil", c)
+	}
+	u.AddEdge(4, 1, 1)
+	checkCycle(t, u, u.Cycle())
+	u.RemoveEdge(4, 1)
+	u.AddEdge(3, 3, 1)
+	if got := fmt.Sprint(u.Cycle()); got != "[3]" {
+		t.Errorf("Cycle() with a self loop = %v, want [3]", got)
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	g := NewDirected[string, int]()
+	g.AddVertex("shirt")
+	g.AddEdge("undershorts", "pants", 1)
+	g.AddEdge("pants", "shoes", 1)
+	g.AddEdge("socks", "shoes", 1)
+	g.AddEdge("shirt", "tie", 1)
+	g.AddEdge("tie", "jacket", 1)
+	g.AddEdge("pants", "belt", 1)
+	g.AddEdge("belt", "jacket", 1)
+
+	sorted, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("TopologicalSort() error = %v", err)
+	}
+	want := "[shirt undershorts socks tie pants shoes belt jacket]"
+	if fmt.Sprint(sorted) != want {
+		t.Errorf("TopologicalSort() = %v, want %s", sorted, want)
+	}
+
+	g.AddEdge("jacket", "pants", 1)
+	sorted, err = g.TopologicalSort()
+	var ce *CycleError[string]
+	if sorted != nil || !errors.As(err, &ce) {
+		t.Fatalf("TopologicalSort() of a cyclic graph = %v, %v, want a *CycleError", sorted, err)
+	}
+	checkCycle(t, g, ce.Cycle)
+	if err.Error() != "graph: cycle jacket -> pants -> belt -> jacket" {
+		t.Errorf("err.Error() = %q", err.Error())
+	}
+
+	if _, err := NewUndirected[int, int]().TopologicalSort(); err != ErrUndirected {
+		t.Errorf("TopologicalSort() of an undirected graph error = %v, want ErrUndirected", err)
+	}
+}
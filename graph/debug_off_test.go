@@ -0,0 +1,6 @@
+//go:build !skaledebug
+
+package graph
+
+// largeN is the size of the large inputs of the tests.
+const largeN = 100000
@@ -0,0 +1,7 @@
+//go:build skaledebug
+
+package graph
+
+// largeN is the size of the large inputs of the tests. Built with the skaledebug tag, linkedlist validates a list
+// after every mutation, in O(n), so the large inputs are kept small.
+const largeN = 2000
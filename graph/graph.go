@@ -0,0 +1,267 @@
+// Package graph implements directed and undirected graphs, with the usual traversals and algorithms.
+//
+// Vertices are identified by values of a comparable type and edges carry a numeric weight. Each vertex keeps its
+// edges in adjacency lists, linkedlist.List, and every edge keeps cursors to its nodes, so that vertices and edges
+// are added and removed in constant time, per edge involved. Vertices and edges are visited in the order they were
+// added, which makes every traversal deterministic.
+//
+// The graphs are simple: there is at most one edge from a vertex to another, self loops included.
+//
+// Besides BFS and DFS iterators, components, cycle detection and topological sort, the package implements shortest
+// paths (Dijkstra, Bellman-Ford, A* and Floyd-Warshall) and minimum spanning trees (Kruskal and Prim). Paths are
+// returned as linkedlist.List of vertices, from the source to the target.
+//
+// Structure is not thread safe.
+package graph
+
+import (
+	"github.com/nnhatnam/skale/list/linkedlist"
+	"golang.org/x/exp/constraints"
+)
+
+// Weight is the type of the weights of the edges.
+type Weight interface {
+	constraints.Integer | constraints.Float
+}
+
+// half is an edge seen from one of its ends. A directed edge is a single half, linked in the out list of its tail
+// and in the in list of its head. An undirected edge u-v is made of two halves, u->v and v->u, linked in the out lists
+// of u and v, except self loops which are a single half.
+type half[V comparable, W Weight] struct {
+	from, to V
+	weight   W
+	primary  bool // the half created in the direction given to AddEdge, to visit undirected edges once
+
+	out, in *linkedlist.Cursor[*half[V, W]]
+}
+
+// vertex is a vertex and its adjacency lists. in is only used by directed graphs.
+type vertex[V comparable, W Weight] struct {
+	id    V
+	out   linkedlist.List[*half[V, W]]
+	in    linkedlist.List[*half[V, W]]
+	order *linkedlist.Cursor[V] // node in Graph.order
+}
+
+// key identifies the half from a vertex to another.
+type key[V comparable] struct {
+	from, to V
+}
+
+// Graph is a directed or undirected graph with vertices of type V and edge weights of type W.
+type Graph[V comparable, W Weight] struct {
+	directed bool
+	vertices map[V]*vertex[V, W]
+	halves   map[key[V]]*half[V, W]
+	order    linkedlist.List[V] // vertices in insertion order
+	size     int
+}
+
+// NewDirected returns an empty directed graph.
+func NewDirected[V comparable, W Weight]() *Graph[V, W] {
+	return newGraph[V, W](true)
+}
+
+// NewUndirected returns an empty undirected graph.
+func NewUndirected[V comparable, W Weight]() *Graph[V, W] {
+	return newGraph[V, W](false)
+}
+
+func newGraph[V comparable, W Weight](directed bool) *Graph[V, W] {
+	return &Graph[V, W]{
+		directed: directed,
+		vertices: make(map[V]*vertex[V, W]),
+		halves:   make(map[key[V]]*half[V, W]),
+	}
+}
+
+// Directed reports whether g is a directed graph.
+func (g *Graph[V, W]) Directed() bool {
+	return g.directed
+}
+
+// Order returns the number of vertices of g.
+func (g *Graph[V, W]) Order() int {
+	return len(g.vertices)
+}
+
+// Size returns the number of edges of g. An undirected edge counts once.
+func (g *Graph[V, W]) Size() int {
+	return g.size
+}
+
+// HasVertex reports whether v is a vertex of g.
+func (g *Graph[V, W]) HasVertex(v V) bool {
+	_, ok := g.vertices[v]
+	return ok
+}
+
+// AddVertex adds vertex v to g. Return false if v is already a vertex of g.
+// The complexity is O(1).
+func (g *Graph[V, W]) AddVertex(v V) bool {
+	if g.HasVertex(v) {
+		return false
+	}
+	g.order.PushBack(v)
+	g.vertices[v] = &vertex[V, W]{id: v, order: g.order.BackCursor()}
+	return true
+}
+
+// vertex returns vertex v, adding it if needed.
+func (g *Graph[V, W]) vertex(v V) *vertex[V, W] {
+	g.AddVertex(v)
+	return g.vertices[v]
+}
+
+// RemoveVertex removes vertex v and its edges from g. Return false if v is not a vertex of g.
+// The complexity is O(degree of v).
+func (g *Graph[V, W]) RemoveVertex(v V) bool {
+	x, ok := g.vertices[v]
+	if !ok {
+		return false
+	}
+	for n := x.out.Front(); n != nil; n = x.out.Front() {
+		g.RemoveEdge(v, n.Value.to)
+	}
+	for n := x.in.Front(); n != nil; n = x.in.Front() {
+		g.RemoveEdge(n.Value.from, v)
+	}
+	x.order.Remove(linkedlist.AdvanceNext)
+	delete(g.vertices, v)
+	return true
+}
+
+// Vertices returns the vertices of g, in the order they were added.
+func (g *Graph[V, W]) Vertices() []V {
+	vs := make([]V, 0, g.Order())
+	g.order.WalkAscending(func(n *linkedlist.Node[V]) bool {
+		vs = append(vs, n.Value)
+		return true
+	})
+	return vs
+}
+
+// AddEdge adds an edge from u to v of weight w to g, adding u and v if they are not vertices of g yet.
+// If the edge already exists, its weight is set to w. In an undirected graph, the edge also goes from v to u.
+// The complexity is O(1).
+func (g *Graph[V, W]) AddEdge(u, v V, w W) {
+	if h, ok := g.halves[key[V]{u, v}]; ok {
+		h.weight = w
+		if !g.directed {
+			g.halves[key[V]{v, u}].weight = w
+		}
+		return
+	}
+
+	g.size++
+	g.link(u, v, w, true)
+	if !g.directed && u != v {
+		g.link(v, u, w, false)
+	}
+}
+
+// link adds the half from u to v.
+func (g *Graph[V, W]) link(u, v V, w W, primary bool) {
+	from, to := g.vertex(u), g.vertex(v)
+	h := &half[V, W]{from: u, to: v, weight: w, primary: primary}
+
+	from.out.PushBack(h)
+	h.out = from.out.BackCursor()
+	if g.directed {
+		to.in.PushBack(h)
+		h.in = to.in.BackCursor()
+	}
+	g.halves[key[V]{u, v}] = h
+}
+
+// RemoveEdge removes the edge from u to v from g. Return false if there is no such edge.
+// The complexity is O(1).
+func (g *Graph[V, W]) RemoveEdge(u, v V) bool {
+	if !g.unlink(u, v) {
+		return false
+	}
+	if !g.directed && u != v {
+		g.unlink(v, u)
+	}
+	g.size--
+	return true
+}
+
+// unlink removes the half from u to v.
+func (g *Graph[V, W]) unlink(u, v V) bool {
+	h, ok := g.halves[key[V]{u, v}]
+	if !ok {
+		return false
+	}
+	h.out.Remove(linkedlist.AdvanceNext)
+	if h.in != nil {
+		h.in.Remove(linkedlist.AdvanceNext)
+	}
+	delete(g.halves, key[V]{u, v})
+	return true
+}
+
+// HasEdge reports whether there is an edge from u to v in g.
+func (g *Graph[V, W]) HasEdge(u, v V) bool {
+	_, ok := g.halves[key[V]{u, v}]
+	return ok
+}
+
+// Weight returns the weight of the edge from u to v. Return false if there is no such edge.
+func (g *Graph[V, W]) Weight(u, v V) (W, bool) {
+	if h, ok := g.halves[key[V]{u, v}]; ok {
+		return h.weight, true
+	}
+	var zero W
+	return zero, false
+}
+
+// OutDegree returns the number of edges leaving v. In an undirected graph, it is the degree of v, a self loop
+// counting once.
+func (g *Graph[V, W]) OutDegree(v V) int {
+	if x, ok := g.vertices[v]; ok {
+		return x.out.Len()
+	}
+	return 0
+}
+
+// InDegree returns the number of edges entering v. In an undirected graph, it is the degree of v, a self loop
+// counting once.
+func (g *Graph[V, W]) InDegree(v V) int {
+	x, ok := g.vertices[v]
+	switch {
+	case !ok:
+		return 0
+	case g.directed:
+		return x.in.Len()
+	}
+	return x.out.Len()
+}
+
+// WalkNeighbors calls f with every vertex reached by an edge from v, and the weight of the edge, in the order the edges
+// were added, until f returns false. f must not modify g.
+func (g *Graph[V, W]) WalkNeighbors(v V, f func(to V, w W) bool) {
+	x, ok := g.vertices[v]
+	if !ok {
+		return
+	}
+	x.out.WalkAscending(func(n *linkedlist.Node[*half[V, W]]) bool {
+		return f(n.Value.to, n.Value.weight)
+	})
+}
+
+// WalkEdges calls f with every edge of g, until f returns false. The edges are visited by tail, in the order the
+// vertices were added, then in the order the edges were added. An undirected edge is visited once, in the direction
+// it was added. f must not modify g.
+func (g *Graph[V, W]) WalkEdges(f func(u, v V, w W) bool) {
+	more := true
+	g.order.WalkAscending(func(n *linkedlist.Node[V]) bool {
+		g.vertices[n.Value].out.WalkAscending(func(e *linkedlist.Node[*half[V, W]]) bool {
+			if h := e.Value; h.primary {
+				more = f(h.from, h.to, h.weight)
+			}
+			return more
+		})
+		return more
+	})
+}
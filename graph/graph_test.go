@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+// checkGraph verifies the adjacency lists of g against its edge index, and the number of edges.
+func checkGraph[V comparable, W Weight](t *testing.T, g *Graph[V, W]) {
+	t.Helper()
+
+	halves := 0
+	for id, x := range g.vertices {
+		if x.id != id || x.order.Value() != id {
+			t.Errorf("vertex %v is indexed as %v", x.id, id)
+		}
+		if err := x.out.Validate(); err != nil {
+			t.Errorf("out list of %v: %v", id, err)
+		}
+		x.out.WalkAscending(func(n *linkedlist.Node[*half[V, W]]) bool {
+			h := n.Value
+			if h.from != id || g.halves[key[V]{h.from, h.to}] != h {
+				t.Errorf("half %v->%v in the out list of %v is not indexed", h.from, h.to, id)
+			}
+			if _, ok := g.vertices[h.to]; !ok {
+				t.Errorf("half %v->%v goes to a removed vertex", h.from, h.to)
+			}
+			halves++
+			return true
+		})
+		if !g.directed && x.in.Len() != 0 {
+			t.Errorf("in list of %v is not empty in an undirected graph", id)
+		}
+	}
+	if halves != len(g.halves) {
+		t.Errorf("adjacency lists hold %d halves, %d indexed", halves, len(g.halves))
+	}
+	if g.order.Len() != len(g.vertices) {
+		t.Errorf("g.order.Len() = %d, want %d", g.order.Len(), len(g.vertices))
+	}
+
+	edges := 0
+	g.WalkEdges(func(u, v V, w W) bool {
+		edges++
+		return true
+	})
+	if edges != g.Size() {
+		t.Errorf("WalkEdges visited %d edges, g.Size() = %d", edges, g.Size())
+	}
+}
+
+func TestDirected(t *testing.T) {
+	g := NewDirected[string, int]()
+	if !g.Directed() || g.Order() != 0 || g.Size() != 0 {
+		t.Errorf("new graph: Directed(), Order(), Size() = %v, %d, %d, want true, 0, 0", g.Directed(), g.Order(), g.Size())
+	}
+
+	if !g.AddVertex("a") || g.AddVertex("a") {
+		t.Errorf("AddVertex(a) twice = false/true, want true/false")
+	}
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("a", "c", 3)
+	g.AddEdge("c", "c", 4)
+	checkGraph(t, g)
+
+	if g.Order() != 3 || g.Size() != 4 {
+		t.Errorf("Order(), Size() = %d, %d, want 3, 4", g.Order(), g.Size())
+	}
+	if !g.HasEdge("a", "b") || g.HasEdge("b", "a") {
+		t.Errorf("HasEdge(a, b), HasEdge(b, a) = %v, %v, want true, false", g.HasEdge("a", "b"), g.HasEdge("b", "a"))
+	}
+	if g.OutDegree("a") != 2 || g.InDegree("c") != 3 || g.InDegree("z") != 0 {
+		t.Errorf("OutDegree(a), InDegree(c) = %d, %d, want 2, 3", g.OutDegree("a"), g.InDegree("c"))
+	}
+
+	g.AddEdge("a", "b", 10)
+	if w, ok := g.Weight("a", "b"); !ok || w != 10 || g.Size() != 4 {
+		t.Errorf("Weight(a, b) after a second AddEdge = %d, %v, Size() = %d, want 10, true, 4", w, ok, g.Size())
+	}
+	if _, ok := g.Weight("b", "a"); ok {
+		t.Errorf("Weight(b, a) = _, true, want false")
+	}
+
+	var out []string
+	g.WalkNeighbors("a", func(to string, w int) bool {
+		out = append(out, fmt.Sprint(to, w))
+		return true
+	})
+	if fmt.Sprint(out) != "[b10 c3]" {
+		t.Errorf("neighbors of a = %v, want [b10 c3]", out)
+	}
+
+	if !g.RemoveEdge("a", "c") || g.RemoveEdge("a", "c") {
+		t.Errorf("RemoveEdge(a, c) twice = false/true, want true/false")
+	}
+	checkGraph(t, g)
+
+	if !g.RemoveVertex("c") || g.RemoveVertex("c") {
+		t.Errorf("RemoveVertex(c) twice = false/true, want true/false")
+	}
+	checkGraph(t, g)
+	if g.Size() != 1 || g.HasEdge("b", "c") || fmt.Sprint(g.Vertices()) != "[a b]" {
+		t.Errorf("after RemoveVertex(c): Size() = %d, Vertices() = %v, want 1, [a b]", g.Size(), g.Vertices())
+	}
+}
+
+func TestUndirected(t *testing.T) {
+	g := NewUndirected[int, float64]()
+	g.AddEdge(1, 2, 0.5)
+	g.AddEdge(2, 3, 1.5)
+	g.AddEdge(3, 3, 2)
+	checkGraph(t, g)
+
+	if g.Directed() || g.Size() != 3 {
+		t.Errorf("Directed(), Size() = %v, %d, want false, 3", g.Directed(), g.Size())
+	}
+	if !g.HasEdge(2, 1) || g.InDegree(2) != 2 || g.OutDegree(3) != 2 {
+		t.Errorf("HasEdge(2, 1), degree of 2 and 3 = %v, %d, %d, want true, 2, 2", g.HasEdge(2, 1), g.InDegree(2), g.OutDegree(3))
+	}
+
+	g.AddEdge(2, 1, 7)
+	if w, _ := g.Weight(1, 2); w != 7 {
+		t.Errorf("Weight(1, 2) = %v after AddEdge(2, 1), want 7", w)
+	}
+
+	var edges []string
+	g.WalkEdges(func(u, v int, w float64) bool {
+		edges = append(edges, fmt.Sprint(u, v))
+		return true
+	})
+	if fmt.Sprint(edges) != "[1 2 2 3 3 3]" {
+		t.Errorf("WalkEdges visited %v, want [1 2 2 3 3 3]", edges)
+	}
+
+	g.RemoveEdge(2, 1)
+	checkGraph(t, g)
+	if g.HasEdge(1, 2) || g.Size() != 2 {
+		t.Errorf("RemoveEdge(2, 1) left the edge 1-2")
+	}
+
+	g.RemoveVertex(3)
+	checkGraph(t, g)
+	if g.Size() != 0 || g.OutDegree(2) != 0 {
+		t.Errorf("RemoveVertex(3) left edges: Size() = %d", g.Size())
+	}
+}
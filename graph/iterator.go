@@ -0,0 +1,137 @@
+package graph
+
+import "github.com/nnhatnam/skale/list/linkedlist"
+
+// frame is a vertex being explored, with a cursor on the next edge to follow.
+type frame[V comparable, W Weight] struct {
+	x     *vertex[V, W]
+	c     *linkedlist.Cursor[*half[V, W]]
+	in    bool // c walks the in list of x
+	depth int
+}
+
+func newFrame[V comparable, W Weight](x *vertex[V, W], depth int) *frame[V, W] {
+	return &frame[V, W]{x: x, c: x.out.Cursor(), depth: depth}
+}
+
+// next returns the next edge of f. The in edges are followed after the out edges if both is
+// set. Return nil when every edge has been followed.
+func (f *frame[V, W]) next(both bool) *half[V, W] {
+	for {
+		if n := f.c.MoveNext(); n != nil {
+			return n.Value
+		}
+		if f.in || !both {
+			return nil
+		}
+		f.in, f.c = true, f.x.in.Cursor()
+	}
+}
+
+// end returns the vertex reached by following h from f.
+func (f *frame[V, W]) end(h *half[V, W]) V {
+	if f.in {
+		return h.from
+	}
+	return h.to
+}
+
+// Iterator visits the vertices reachable from a start vertex, each once, in breadth first or depth first order.
+// The graph must not be modified while the iterator is in use.
+type Iterator[V comparable, W Weight] struct {
+	g     *Graph[V, W]
+	dfs   bool
+	both  bool // follow the edges in both directions
+	seen  map[V]bool
+	deque linkedlist.List[*frame[V, W]] // the queue of a BFS, the stack of a DFS
+	depth int
+
+	pending *frame[V, W] // the start vertex of a DFS, until it is returned
+}
+
+// BFS returns an iterator visiting the vertices reachable from start in breadth first order, start first.
+// The neighbors of a vertex are visited in the order its edges were added.
+// The iterator is empty if start is not a vertex of g.
+func (g *Graph[V, W]) BFS(start V) *Iterator[V, W] {
+	return g.iterator(start, false, false, make(map[V]bool))
+}
+
+// DFS returns an iterator visiting the vertices reachable from start in depth first preorder, start first.
+// The neighbors of a vertex are visited in the order its edges were added.
+// The iterator is empty if start is not a vertex of g.
+func (g *Graph[V, W]) DFS(start V) *Iterator[V, W] {
+	return g.iterator(start, true, false, make(map[V]bool))
+}
+
+// iterator returns an iterator from start. Vertices in seen are not visited, and visited vertices are added to seen.
+func (g *Graph[V, W]) iterator(start V, dfs, both bool, seen map[V]bool) *Iterator[V, W] {
+	it := &Iterator[V, W]{g: g, dfs: dfs, both: both, seen: seen}
+	if x, ok := g.vertices[start]; ok && !seen[start] {
+		seen[start] = true
+		f := newFrame(x, 0)
+		it.deque.PushBack(f)
+		if dfs {
+			it.pending = f
+		}
+	}
+	return it
+}
+
+// Next returns the next vertex. Return false when every reachable vertex has been visited.
+// The complexity is O(1) amortized, every edge being followed once during the walk.
+func (it *Iterator[V, W]) Next() (V, bool) {
+	if it.dfs {
+		return it.nextDFS()
+	}
+	return it.nextBFS()
+}
+
+// Depth returns the depth of the last vertex returned by Next: 0 for the start vertex, then its distance in edges
+// from the start vertex for a BFS, and its depth in the DFS tree for a DFS.
+func (it *Iterator[V, W]) Depth() int {
+	return it.depth
+}
+
+func (it *Iterator[V, W]) nextBFS() (V, bool) {
+	n := it.deque.PopFront()
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+
+	f := n.Value
+	for h := f.next(it.both); h != nil; h = f.next(it.both) {
+		if v := f.end(h); !it.seen[v] {
+			it.seen[v] = true
+			it.deque.PushBack(newFrame(it.g.vertices[v], f.depth+1))
+		}
+	}
+	it.depth = f.depth
+	return f.x.id, true
+}
+
+func (it *Iterator[V, W]) nextDFS() (V, bool) {
+	if f := it.pending; f != nil {
+		it.pending = nil
+		it.depth = f.depth
+		return f.x.id, true
+	}
+
+	for top := it.deque.Back(); top != nil; top = it.deque.Back() {
+		f := top.Value
+		h := f.next(it.both)
+		if h == nil {
+			it.deque.PopBack()
+			continue
+		}
+		if v := f.end(h); !it.seen[v] {
+			it.seen[v] = true
+			next := newFrame(it.g.vertices[v], f.depth+1)
+			it.deque.PushBack(next)
+			it.depth = next.depth
+			return v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+// visit returns the vertices of it with their depth.
+func visit[V comparable, W Weight](it *Iterator[V, W]) []string {
+	var vs []string
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		vs = append(vs, fmt.Sprintf("%v:%d", v, it.Depth()))
+	}
+	return vs
+}
+
+// tree returns the directed graph 1 -> 2 -> 4, 1 -> 3 -> 5, 2 -> 5, 5 -> 1, with an isolated vertex 6.
+func tree() *Graph[int, int] {
+	g := NewDirected[int, int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(2, 4, 1)
+	g.AddEdge(2, 5, 1)
+	g.AddEdge(3, 5, 1)
+	g.AddEdge(5, 1, 1)
+	g.AddVertex(6)
+	return g
+}
+
+func TestBFS(t *testing.T) {
+	g := tree()
+	if got := fmt.Sprint(visit(g.BFS(1))); got != "[1:0 2:1 3:1 4:2 5:2]" {
+		t.Errorf("BFS(1) = %v, want [1:0 2:1 3:1 4:2 5:2]", got)
+	}
+	if got := fmt.Sprint(visit(g.BFS(3))); got != "[3:0 5:1 1:2 2:3 4:4]" {
+		t.Errorf("BFS(3) = %v, want [3:0 5:1 1:2 2:3 4:4]", got)
+	}
+	if got := visit(g.BFS(6)); len(got) != 1 {
+		t.Errorf("BFS(6) = %v, want [6:0]", got)
+	}
+	if got := visit(g.BFS(7)); len(got) != 0 {
+		t.Errorf("BFS of a missing vertex = %v, want []", got)
+	}
+}
+
+func TestDFS(t *testing.T) {
+	g := tree()
+	if got := fmt.Sprint(visit(g.DFS(1))); got != "[1:0 2:1 4:2 5:2 3:1]" {
+		t.Errorf("DFS(1) = %v, want [1:0 2:1 4:2 5:2 3:1]", got)
+	}
+	if got := fmt.Sprint(visit(g.DFS(5))); got != "[5:0 1:1 2:2 4:3 3:2]" {
+		t.Errorf("DFS(5) = %v, want [5:0 1:1 2:2 4:3 3:2]", got)
+	}
+	if got := visit(g.DFS(7)); len(got) != 0 {
+		t.Errorf("DFS of a missing vertex = %v, want []", got)
+	}
+
+	// undirected edges are followed both ways
+	u := NewUndirected[string, int]()
+	u.AddEdge("b", "a", 1)
+	u.AddEdge("c", "b", 1)
+	if got := fmt.Sprint(visit(u.DFS("a"))); got != "[a:0 b:1 c:2]" {
+		t.Errorf("DFS(a) = %v, want [a:0 b:1 c:2]", got)
+	}
+}
+
+func TestIteratorLarge(t *testing.T) {
+	// a long path must not exhaust the stack
+	g := NewDirected[int, int]()
+	const n = largeN
+	for i := 0; i < n; i++ {
+		g.AddEdge(i, i+1, 1)
+	}
+	it := g.DFS(0)
+	count := 0
+	for _, ok := it.Next(); ok; _, ok = it.Next() {
+		count++
+	}
+	if count != n+1 || it.Depth() != n {
+		t.Errorf("DFS visited %d vertices down to depth %d, want %d, %d", count, it.Depth(), n+1, n)
+	}
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/nnhatnam/skale/disjointset"
+	"github.com/nnhatnam/skale/heap"
+)
+
+// Kruskal returns a minimum spanning forest of the undirected graph g, computed by Kruskal's algorithm, and its
+// weight. The forest has every vertex of g, and a minimum spanning tree of each connected component of g.
+// Among edges of equal weight, the ones added to g first are preferred. It returns ErrDirected if g is directed.
+// The complexity is O(E log E).
+func (g *Graph[V, W]) Kruskal() (*Graph[V, W], W, error) {
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+
+	type edge struct {
+		u, v V
+		w    W
+	}
+	edges := make([]edge, 0, g.Size())
+	g.WalkEdges(func(u, v V, w W) bool {
+		edges = append(edges, edge{u, v, w})
+		return true
+	})
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].w < edges[j].w })
+
+	forest := g.emptyForest()
+	sets := disjointset.New[V]()
+	var total W
+	for _, e := range edges {
+		if sets.Union(e.u, e.v) {
+			forest.AddEdge(e.u, e.v, e.w)
+			total += e.w
+		}
+	}
+	return forest, total, nil
+}
+
+// Prim returns a minimum spanning forest of the undirected graph g, computed by Prim's algorithm on a heap with
+// decrease-key, and its weight. The forest has every vertex of g, and a minimum spanning tree of each connected
+// component of g, grown from its first vertex. It returns ErrDirected if g is directed.
+// The complexity is O((V+E) log V).
+func (g *Graph[V, W]) Prim() (*Graph[V, W], W, error) {
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+
+	forest := g.emptyForest()
+	var total W
+	done := make(map[V]bool, g.Order())
+	from := make(map[V]V, g.Order()) // the tree end of the lightest edge reaching a vertex
+	handles := make(map[V]*heap.Handle[distItem[V, W]], g.Order())
+	h := newDistHeap[V, W]()
+
+	for _, root := range g.Vertices() {
+		if done[root] {
+			continue
+		}
+		handles[root] = h.Push(distItem[V, W]{root, 0})
+		for x := h.Pop(); x != nil; x = h.Pop() {
+			u := x.Value.v
+			done[u] = true
+			if u != root {
+				forest.AddEdge(from[u], u, x.Value.d)
+				total += x.Value.d
+			}
+
+			g.WalkNeighbors(u, func(v V, w W) bool {
+				if done[v] {
+					return true
+				}
+				hv, ok := handles[v]
+				switch {
+				case !ok:
+					handles[v] = h.Push(distItem[V, W]{v, w})
+				case w < hv.Value.d:
+					h.Update(hv, distItem[V, W]{v, w})
+				default:
+					return true
+				}
+				from[v] = u
+				return true
+			})
+		}
+	}
+	return forest, total, nil
+}
+
+// emptyForest returns an undirected graph with the vertices of g and no edge.
+func (g *Graph[V, W]) emptyForest() *Graph[V, W] {
+	forest := NewUndirected[V, W]()
+	for _, v := range g.Vertices() {
+		forest.AddVertex(v)
+	}
+	return forest
+}
@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"testing"
+)
+
+// clrsMST is the graph of figure 23.1 of Introduction to Algorithms.
+func clrsMST() *Graph[string, int] {
+	g := NewUndirected[string, int]()
+	for _, e := range []struct {
+		u, v string
+		w    int
+	}{
+		{"a", "b", 4}, {"a", "h", 8}, {"b", "c", 8}, {"b", "h", 11}, {"c", "d", 7},
+		{"c", "f", 4}, {"c", "i", 2}, {"d", "e", 9}, {"d", "f", 14}, {"e", "f", 10},
+		{"f", "g", 2}, {"g", "h", 1}, {"g", "i", 6}, {"h", "i", 7},
+	} {
+		g.AddEdge(e.u, e.v, e.w)
+	}
+	return g
+}
+
+// checkForest verifies that forest is a spanning forest of g of weight total, with edges of g.
+func checkForest(t *testing.T, g, forest *Graph[string, int], total int) {
+	t.Helper()
+	if forest.Order() != g.Order() || forest.Acyclic() == false {
+		t.Errorf("forest has %d vertices, cycle %v, want %d vertices and no cycle", forest.Order(), forest.Cycle(), g.Order())
+	}
+	if want := len(g.ConnectedComponents()); len(forest.ConnectedComponents()) != want {
+		t.Errorf("forest has %d components, want %d", len(forest.ConnectedComponents()), want)
+	}
+	sum := 0
+	forest.WalkEdges(func(u, v string, w int) bool {
+		if gw, ok := g.Weight(u, v); !ok || gw != w {
+			t.Errorf("forest edge %s-%s (%d) is not an edge of the graph", u, v, w)
+		}
+		sum += w
+		return true
+	})
+	if sum != total {
+		t.Errorf("forest weighs %d, reported %d", sum, total)
+	}
+}
+
+func TestMST(t *testing.T) {
+	for name, mst := range map[string]func(g *Graph[string, int]) (*Graph[string, int], int, error){
+		"Kruskal": (*Graph[string, int]).Kruskal,
+		"Prim":    (*Graph[string, int]).Prim,
+	} {
+		g := clrsMST()
+		forest, total, err := mst(g)
+		if err != nil {
+			t.Fatalf("%s() error = %v", name, err)
+		}
+		if total != 37 {
+			t.Errorf("%s() weight = %d, want 37", name, total)
+		}
+		checkForest(t, g, forest, total)
+
+		// a second component and a self loop
+		g.AddEdge("x", "y", 3)
+		g.AddEdge("y", "z", -1)
+		g.AddEdge("x", "z", 5)
+		g.AddEdge("z", "z", -10)
+		forest, total, _ = mst(g)
+		if total != 39 {
+			t.Errorf("%s() weight of the forest = %d, want 39", name, total)
+		}
+		checkForest(t, g, forest, total)
+
+		if _, _, err := mst(NewDirected[string, int]()); err != ErrDirected {
+			t.Errorf("%s() of a directed graph error = %v, want ErrDirected", name, err)
+		}
+	}
+}
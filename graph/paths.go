@@ -0,0 +1,323 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/nnhatnam/skale/heap"
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+var (
+	// ErrVertexNotFound is returned when a vertex given to an algorithm is not a vertex of the graph.
+	ErrVertexNotFound = errors.New("graph: vertex not found")
+	// ErrNegativeWeight is returned by Dijkstra and AStar when they meet an edge of negative weight.
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+	// ErrNoPath is returned by AStar when the target cannot be reached from the source.
+	ErrNoPath = errors.New("graph: no path")
+)
+
+// NegativeCycleError is returned by BellmanFord and FloydWarshall when the graph has a cycle of negative weight,
+// which makes the shortest paths through it undefined. In an undirected graph, an edge of negative weight is such a
+// cycle by itself.
+type NegativeCycleError[V comparable] struct {
+	// Cycle lists the vertices of a negative cycle, the last one having an edge back to the first one.
+	Cycle []V
+}
+
+func (e *NegativeCycleError[V]) Error() string {
+	return formatCycle("graph: negative cycle ", e.Cycle)
+}
+
+// ShortestPaths holds the shortest paths from a source vertex to every vertex reachable from it.
+type ShortestPaths[V comparable, W Weight] struct {
+	source V
+	dist   map[V]W
+	prev   map[V]V // the vertex before v on the shortest path to v
+}
+
+func newShortestPaths[V comparable, W Weight](source V) *ShortestPaths[V, W] {
+	p := &ShortestPaths[V, W]{source: source, dist: make(map[V]W), prev: make(map[V]V)}
+	p.dist[source] = 0
+	return p
+}
+
+// Source returns the source vertex of the paths.
+func (p *ShortestPaths[V, W]) Source() V {
+	return p.source
+}
+
+// Distance returns the weight of the shortest path to v. Return false if v cannot be reached.
+func (p *ShortestPaths[V, W]) Distance(v V) (W, bool) {
+	d, ok := p.dist[v]
+	return d, ok
+}
+
+// Path returns the vertices of the shortest path to v, from the source to v. Return nil if v cannot be reached.
+// The complexity is O(length of the path).
+func (p *ShortestPaths[V, W]) Path(v V) *linkedlist.List[V] {
+	if _, ok := p.dist[v]; !ok {
+		return nil
+	}
+	return pathTo(p.prev, p.source, v)
+}
+
+// pathTo returns the path from source to v following prev backwards.
+func pathTo[V comparable](prev map[V]V, source, v V) *linkedlist.List[V] {
+	path := linkedlist.New[V]()
+	for path.PushFront(v); v != source; path.PushFront(v) {
+		v = prev[v]
+	}
+	return path
+}
+
+// distItem is a vertex in the heap of Dijkstra's and Prim's algorithms, keyed by d.
+type distItem[V comparable, W Weight] struct {
+	v V
+	d W
+}
+
+func newDistHeap[V comparable, W Weight]() *heap.Heap[distItem[V, W]] {
+	return heap.New(func(a, b distItem[V, W]) int {
+		switch {
+		case a.d < b.d:
+			return -1
+		case a.d > b.d:
+			return 1
+		}
+		return 0
+	})
+}
+
+// Dijkstra returns the shortest paths from source, computed by Dijkstra's algorithm on a heap with decrease-key.
+// It returns ErrVertexNotFound if source is not a vertex of g, and ErrNegativeWeight if an edge reachable from
+// source has a negative weight.
+// The complexity is O((V+E) log V).
+func (g *Graph[V, W]) Dijkstra(source V) (*ShortestPaths[V, W], error) {
+	if !g.HasVertex(source) {
+		return nil, ErrVertexNotFound
+	}
+
+	p := newShortestPaths[V, W](source)
+	h := newDistHeap[V, W]()
+	handles := map[V]*heap.Handle[distItem[V, W]]{source: h.Push(distItem[V, W]{source, 0})}
+
+	for x := h.Pop(); x != nil; x = h.Pop() {
+		u, du := x.Value.v, x.Value.d
+		var err error
+		g.WalkNeighbors(u, func(v V, w W) bool {
+			if w < 0 {
+				err = ErrNegativeWeight
+				return false
+			}
+			d := du + w
+			if old, ok := p.dist[v]; ok && d >= old {
+				return true
+			}
+			p.dist[v], p.prev[v] = d, u
+			if hv, ok := handles[v]; ok {
+				h.Update(hv, distItem[V, W]{v, d})
+			} else {
+				handles[v] = h.Push(distItem[V, W]{v, d})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// BellmanFord returns the shortest paths from source, computed by the Bellman-Ford algorithm, which accepts
+// negative weights. It returns ErrVertexNotFound if source is not a vertex of g, and a *NegativeCycleError if a cycle
+// of negative weight can be reached from source.
+// The complexity is O(V*E).
+func (g *Graph[V, W]) BellmanFord(source V) (*ShortestPaths[V, W], error) {
+	if !g.HasVertex(source) {
+		return nil, ErrVertexNotFound
+	}
+
+	p := newShortestPaths[V, W](source)
+	vertices := g.Vertices()
+
+	// relax relaxes every edge once, and returns the head of the last relaxed edge
+	relax := func() (V, bool) {
+		var last V
+		relaxed := false
+		for _, u := range vertices {
+			du, ok := p.dist[u]
+			if !ok {
+				continue
+			}
+			g.WalkNeighbors(u, func(v V, w W) bool {
+				if old, ok := p.dist[v]; !ok || du+w < old {
+					p.dist[v], p.prev[v] = du+w, u
+					last, relaxed = v, true
+				}
+				return true
+			})
+		}
+		return last, relaxed
+	}
+
+	for i := 1; i < len(vertices); i++ {
+		if _, relaxed := relax(); !relaxed {
+			return p, nil
+		}
+	}
+	v, relaxed := relax()
+	if !relaxed {
+		return p, nil
+	}
+
+	// v is reached by a negative cycle: going back V times from v lands on the cycle
+	for i := 0; i < len(vertices); i++ {
+		v = p.prev[v]
+	}
+	cycle := []V{v}
+	for u := p.prev[v]; u != v; u = p.prev[u] {
+		cycle = append(cycle, u)
+	}
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return nil, &NegativeCycleError[V]{Cycle: cycle}
+}
+
+// AStar returns the shortest path from source to target and its weight, computed by the A* algorithm.
+// heuristic estimates the weight of the shortest path from a vertex to target. It must never overestimate it
+// (admissible heuristic) for the path to be the shortest. A heuristic returning 0 turns AStar into Dijkstra's algorithm.
+// It returns ErrVertexNotFound if source or target is not a vertex of g, ErrNoPath if target cannot be reached, and
+// ErrNegativeWeight if it meets an edge of negative weight.
+// The complexity is O((V+E) log V) with a consistent heuristic, usually much less.
+func (g *Graph[V, W]) AStar(source, target V, heuristic func(v V) W) (*linkedlist.List[V], W, error) {
+	if !g.HasVertex(source) || !g.HasVertex(target) {
+		return nil, 0, ErrVertexNotFound
+	}
+
+	dist := map[V]W{source: 0}
+	prev := make(map[V]V)
+	open := newDistHeap[V, W]() // keyed by the estimated weight of the path through the vertex
+	handles := map[V]*heap.Handle[distItem[V, W]]{source: open.Push(distItem[V, W]{source, heuristic(source)})}
+
+	for x := open.Pop(); x != nil; x = open.Pop() {
+		u := x.Value.v
+		if u == target {
+			return pathTo(prev, source, target), dist[target], nil
+		}
+
+		du := dist[u]
+		var err error
+		g.WalkNeighbors(u, func(v V, w W) bool {
+			if w < 0 {
+				err = ErrNegativeWeight
+				return false
+			}
+			d := du + w
+			if old, ok := dist[v]; ok && d >= old {
+				return true
+			}
+			dist[v], prev[v] = d, u
+			item := distItem[V, W]{v, d + heuristic(v)}
+			// a vertex already closed is reopened, which only happens with an inconsistent heuristic
+			if hv, ok := handles[v]; ok && open.Contains(hv) {
+				open.Update(hv, item)
+			} else {
+				handles[v] = open.Push(item)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return nil, 0, ErrNoPath
+}
+
+// AllPairs holds the shortest paths between every pair of vertices.
+type AllPairs[V comparable, W Weight] struct {
+	index    map[V]int
+	vertices []V
+	dist     [][]W
+	reach    [][]bool
+	next     [][]int // the index of the vertex after i on the shortest path from i to j
+}
+
+// Distance returns the weight of the shortest path from u to v. Return false if v cannot be reached from u.
+func (p *AllPairs[V, W]) Distance(u, v V) (W, bool) {
+	i, ok1 := p.index[u]
+	j, ok2 := p.index[v]
+	if !ok1 || !ok2 || !p.reach[i][j] {
+		return 0, false
+	}
+	return p.dist[i][j], true
+}
+
+// Path returns the vertices of the shortest path from u to v. Return nil if v cannot be reached from u.
+// The complexity is O(length of the path).
+func (p *AllPairs[V, W]) Path(u, v V) *linkedlist.List[V] {
+	i, ok1 := p.index[u]
+	j, ok2 := p.index[v]
+	if !ok1 || !ok2 || !p.reach[i][j] {
+		return nil
+	}
+	path := linkedlist.New[V]()
+	for path.PushBack(u); i != j; path.PushBack(p.vertices[i]) {
+		i = p.next[i][j]
+	}
+	return path
+}
+
+// FloydWarshall returns the shortest paths between every pair of vertices, computed by the Floyd-Warshall algorithm,
+// which accepts negative weights. It returns a *NegativeCycleError if g has a cycle of negative weight.
+// The complexity is O(V^3) in time and O(V^2) in space.
+func (g *Graph[V, W]) FloydWarshall() (*AllPairs[V, W], error) {
+	n := g.Order()
+	p := &AllPairs[V, W]{
+		index:    make(map[V]int, n),
+		vertices: g.Vertices(),
+		dist:     make([][]W, n),
+		reach:    make([][]bool, n),
+		next:     make([][]int, n),
+	}
+	for i, v := range p.vertices {
+		p.index[v] = i
+		p.dist[i], p.reach[i], p.next[i] = make([]W, n), make([]bool, n), make([]int, n)
+		p.reach[i][i], p.next[i][i] = true, i
+	}
+	g.WalkEdges(func(u, v V, w W) bool {
+		i, j := p.index[u], p.index[v]
+		for k := 0; k < 2; k++ {
+			if !p.reach[i][j] || w < p.dist[i][j] {
+				p.dist[i][j], p.reach[i][j], p.next[i][j] = w, true, j
+			}
+			if g.directed {
+				break
+			}
+			i, j = j, i
+		}
+		return true
+	})
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if !p.reach[i][k] {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if p.reach[k][j] && (!p.reach[i][j] || p.dist[i][k]+p.dist[k][j] < p.dist[i][j]) {
+					p.dist[i][j], p.reach[i][j], p.next[i][j] = p.dist[i][k]+p.dist[k][j], true, p.next[i][k]
+				}
+			}
+		}
+	}
+
+	for i, v := range p.vertices {
+		if p.dist[i][i] < 0 {
+			// v is on a negative cycle, that Bellman-Ford reports
+			_, err := g.BellmanFord(v)
+			return nil, err
+		}
+	}
+	return p, nil
+}
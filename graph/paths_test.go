@@ -0,0 +1,286 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+// values returns the values of l, or nil if l is nil.
+func values[V any](l *linkedlist.List[V]) []V {
+	if l == nil {
+		return nil
+	}
+	var vs []V
+	l.WalkAscending(func(n *linkedlist.Node[V]) bool {
+		vs = append(vs, n.Value)
+		return true
+	})
+	return vs
+}
+
+// pathWeight returns the weight of path in g, and false if it uses a missing edge.
+func pathWeight[V comparable, W Weight](g *Graph[V, W], path []V) (W, bool) {
+	var total W
+	for i := 1; i < len(path); i++ {
+		w, ok := g.Weight(path[i-1], path[i])
+		if !ok {
+			return total, false
+		}
+		total += w
+	}
+	return total, true
+}
+
+// clrsDijkstra is the graph of figure 24.6 of Introduction to Algorithms.
+func clrsDijkstra() *Graph[string, int] {
+	g := NewDirected[string, int]()
+	for _, e := range []struct {
+		u, v string
+		w    int
+	}{
+		{"s", "t", 10}, {"s", "y", 5}, {"t", "x", 1}, {"t", "y", 2}, {"y", "t", 3},
+		{"y", "x", 9}, {"y", "z", 2}, {"x", "z", 4}, {"z", "x", 6}, {"z", "s", 7},
+	} {
+		g.AddEdge(e.u, e.v, e.w)
+	}
+	return g
+}
+
+// clrsBellmanFord is the graph of figure 24.4 of Introduction to Algorithms.
+func clrsBellmanFord() *Graph[string, int] {
+	g := NewDirected[string, int]()
+	for _, e := range []struct {
+		u, v string
+		w    int
+	}{
+		{"s", "t", 6}, {"s", "y", 7}, {"t", "x", 5}, {"t", "y", 8}, {"t", "z", -4},
+		{"x", "t", -2}, {"y", "x", -3}, {"y", "z", 9}, {"z", "s", 2}, {"z", "x", 7},
+	} {
+		g.AddEdge(e.u, e.v, e.w)
+	}
+	return g
+}
+
+// checkPaths checks the distances and paths of p against want, a map from vertex to "distance path".
+func checkPaths(t *testing.T, g *Graph[string, int], p *ShortestPaths[string, int], want map[string]string) {
+	t.Helper()
+	for v, w := range want {
+		d, ok := p.Distance(v)
+		path := values(p.Path(v))
+		if got := fmt.Sprint(d, " ", path); !ok || got != w {
+			t.Errorf("distance and path to %s = %s, want %s", v, got, w)
+		}
+		if pw, ok := pathWeight(g, path); !ok || pw != d {
+			t.Errorf("path %v weighs %d, distance %d", path, pw, d)
+		}
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	g := clrsDijkstra()
+	p, err := g.Dijkstra("s")
+	if err != nil {
+		t.Fatalf("Dijkstra(s) error = %v", err)
+	}
+	checkPaths(t, g, p, map[string]string{
+		"s": "0 [s]",
+		"t": "8 [s y t]",
+		"x": "9 [s y t x]",
+		"y": "5 [s y]",
+		"z": "7 [s y z]",
+	})
+
+	g.AddVertex("lonely")
+	p, _ = g.Dijkstra("s")
+	if _, ok := p.Distance("lonely"); ok || p.Path("lonely") != nil || p.Source() != "s" {
+		t.Errorf("an unreachable vertex has a distance or a path")
+	}
+
+	if _, err := g.Dijkstra("nope"); err != ErrVertexNotFound {
+		t.Errorf("Dijkstra(nope) error = %v, want ErrVertexNotFound", err)
+	}
+	if _, err := clrsBellmanFord().Dijkstra("s"); err != ErrNegativeWeight {
+		t.Errorf("Dijkstra() with negative weights error = %v, want ErrNegativeWeight", err)
+	}
+}
+
+func TestBellmanFord(t *testing.T) {
+	g := clrsBellmanFord()
+	p, err := g.BellmanFord("s")
+	if err != nil {
+		t.Fatalf("BellmanFord(s) error = %v", err)
+	}
+	checkPaths(t, g, p, map[string]string{
+		"s": "0 [s]",
+		"t": "2 [s y x t]",
+		"x": "4 [s y x]",
+		"y": "7 [s y]",
+		"z": "-2 [s y x t z]",
+	})
+
+	// Bellman-Ford agrees with Dijkstra without negative weights
+	d := clrsDijkstra()
+	p, _ = d.BellmanFord("s")
+	checkPaths(t, d, p, map[string]string{"x": "9 [s y t x]", "z": "7 [s y z]"})
+
+	g.AddEdge("x", "y", -5)
+	_, err = g.BellmanFord("s")
+	var nce *NegativeCycleError[string]
+	if !errors.As(err, &nce) {
+		t.Fatalf("BellmanFord() error = %v, want a *NegativeCycleError", err)
+	}
+	checkCycle(t, g, nce.Cycle)
+	if w, _ := pathWeight(g, append(nce.Cycle, nce.Cycle[0])); w >= 0 {
+		t.Errorf("cycle %v weighs %d, want a negative weight", nce.Cycle, w)
+	}
+
+	if _, err := g.BellmanFord("nope"); err != ErrVertexNotFound {
+		t.Errorf("BellmanFord(nope) error = %v, want ErrVertexNotFound", err)
+	}
+}
+
+// grid returns an undirected 4-connected grid graph of n*n cells, without the cells of walls.
+func grid(n int, walls map[[2]int]bool) *Graph[[2]int, int] {
+	g := NewUndirected[[2]int, int]()
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			c := [2]int{x, y}
+			if walls[c] {
+				continue
+			}
+			g.AddVertex(c)
+			if x > 0 && !walls[[2]int{x - 1, y}] {
+				g.AddEdge([2]int{x - 1, y}, c, 1)
+			}
+			if y > 0 && !walls[[2]int{x, y - 1}] {
+				g.AddEdge([2]int{x, y - 1}, c, 1)
+			}
+		}
+	}
+	return g
+}
+
+func TestAStar(t *testing.T) {
+	const n = 20
+	r := rand.New(rand.NewSource(1))
+	walls := make(map[[2]int]bool)
+	for i := 0; i < n*n/4; i++ {
+		walls[[2]int{r.Intn(n), r.Intn(n)}] = true
+	}
+	source, target := [2]int{0, 0}, [2]int{n - 1, n - 1}
+	delete(walls, source)
+	delete(walls, target)
+	g := grid(n, walls)
+
+	manhattan := func(c [2]int) int {
+		return (target[0] - c[0]) + (target[1] - c[1])
+	}
+	path, cost, err := g.AStar(source, target, manhattan)
+
+	p, _ := g.Dijkstra(source)
+	want, reachable := p.Distance(target)
+	if !reachable {
+		t.Fatalf("the target is walled off, change the seed")
+	}
+	if err != nil {
+		t.Fatalf("AStar() error = %v", err)
+	}
+	if cost != want {
+		t.Errorf("AStar() cost = %d, Dijkstra distance %d", cost, want)
+	}
+	vs := values(path)
+	if w, ok := pathWeight(g, vs); !ok || w != cost || vs[0] != source || vs[len(vs)-1] != target {
+		t.Errorf("AStar() path %v is not a path of weight %d from %v to %v", vs, cost, source, target)
+	}
+
+	// a zero heuristic is Dijkstra
+	if _, cost, _ := g.AStar(source, target, func([2]int) int { return 0 }); cost != want {
+		t.Errorf("AStar() with a zero heuristic cost = %d, want %d", cost, want)
+	}
+
+	// walled off target
+	closed := grid(3, map[[2]int]bool{{1, 2}: true, {2, 1}: true})
+	if _, _, err := closed.AStar([2]int{0, 0}, [2]int{2, 2}, manhattan); err != ErrNoPath {
+		t.Errorf("AStar() to a walled off target error = %v, want ErrNoPath", err)
+	}
+	if _, _, err := closed.AStar([2]int{0, 0}, [2]int{1, 2}, manhattan); err != ErrVertexNotFound {
+		t.Errorf("AStar() to a wall error = %v, want ErrVertexNotFound", err)
+	}
+}
+
+func TestFloydWarshall(t *testing.T) {
+	// figure 25.1 of Introduction to Algorithms
+	g := NewDirected[int, int]()
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(1, 3, 8)
+	g.AddEdge(1, 5, -4)
+	g.AddEdge(2, 4, 1)
+	g.AddEdge(2, 5, 7)
+	g.AddEdge(3, 2, 4)
+	g.AddEdge(4, 1, 2)
+	g.AddEdge(4, 3, -5)
+	g.AddEdge(5, 4, 6)
+
+	p, err := g.FloydWarshall()
+	if err != nil {
+		t.Fatalf("FloydWarshall() error = %v", err)
+	}
+	want := [][]int{
+		{0, 1, -3, 2, -4},
+		{3, 0, -4, 1, -1},
+		{7, 4, 0, 5, 3},
+		{2, -1, -5, 0, -2},
+		{8, 5, 1, 6, 0},
+	}
+	for i := range want {
+		for j := range want[i] {
+			d, ok := p.Distance(i+1, j+1)
+			if !ok || d != want[i][j] {
+				t.Errorf("Distance(%d, %d) = %d, %v, want %d", i+1, j+1, d, ok, want[i][j])
+			}
+			path := values(p.Path(i+1, j+1))
+			if w, ok := pathWeight(g, path); !ok || w != d {
+				t.Errorf("Path(%d, %d) = %v weighs %d, want %d", i+1, j+1, path, w, d)
+			}
+		}
+	}
+	if got := fmt.Sprint(values(p.Path(1, 2))); got != "[1 5 4 3 2]" {
+		t.Errorf("Path(1, 2) = %v, want [1 5 4 3 2]", got)
+	}
+
+	g.AddVertex(6)
+	p, _ = g.FloydWarshall()
+	if _, ok := p.Distance(1, 6); ok || p.Path(6, 1) != nil || p.Path(7, 1) != nil {
+		t.Errorf("an unreachable vertex has a distance or a path")
+	}
+
+	g.AddEdge(3, 1, -10)
+	_, err = g.FloydWarshall()
+	var nce *NegativeCycleError[int]
+	if !errors.As(err, &nce) {
+		t.Fatalf("FloydWarshall() error = %v, want a *NegativeCycleError", err)
+	}
+	checkCycle(t, g, nce.Cycle)
+}
+
+func TestFloydWarshallUndirected(t *testing.T) {
+	g := NewUndirected[string, float64]()
+	g.AddEdge("a", "b", 1.5)
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("a", "c", 4)
+
+	p, err := g.FloydWarshall()
+	if err != nil {
+		t.Fatalf("FloydWarshall() error = %v", err)
+	}
+	if d, _ := p.Distance("c", "a"); d != 3.5 {
+		t.Errorf("Distance(c, a) = %v, want 3.5", d)
+	}
+	if got := fmt.Sprint(values(p.Path("c", "a"))); got != "[c b a]" {
+		t.Errorf("Path(c, a) = %v, want [c b a]", got)
+	}
+}
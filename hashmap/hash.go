@@ -0,0 +1,102 @@
+package hashmap
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// HashComparable hashes any comparable key, consistently with ==: equal keys have equal hashes.
+// Strings and integers are hashed directly, other keys by reflection, which is slower: a map of such keys is better
+// given its own hash function. It panics if key is an interface holding a value of a non comparable type, as == does.
+func HashComparable[K comparable](seed maphash.Seed, key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(seed, k)
+	case int:
+		return hashUint64(seed, uint64(k))
+	case int32:
+		return hashUint64(seed, uint64(k))
+	case int64:
+		return hashUint64(seed, uint64(k))
+	case uint:
+		return hashUint64(seed, uint64(k))
+	case uint32:
+		return hashUint64(seed, uint64(k))
+	case uint64:
+		return hashUint64(seed, k)
+	}
+
+	var h maphash.Hash
+	h.SetSeed(seed)
+	writeValue(&h, reflect.ValueOf(&key).Elem())
+	return h.Sum64()
+}
+
+// hashUint64 hashes x.
+func hashUint64(seed maphash.Seed, x uint64) uint64 {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], x)
+	return maphash.Bytes(seed, b[:])
+}
+
+// writeUint64 writes x to h.
+func writeUint64(h *maphash.Hash, x uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], x)
+	h.Write(b[:])
+}
+
+// writeFloat writes x to h. Both zeros are equal, so they are written the same.
+func writeFloat(h *maphash.Hash, x float64) {
+	if x == 0 {
+		x = 0
+	}
+	writeUint64(h, math.Float64bits(x))
+}
+
+// writeValue writes comparable value v to h, so that equal values write the same bytes.
+func writeValue(h *maphash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		h.WriteString(v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeFloat(h, real(c))
+		writeFloat(h, imag(c))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(h, uint64(v.Pointer()))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if t.Field(i).Name != "_" { // blank fields are ignored by ==
+				writeValue(h, v.Field(i))
+			}
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+		} else {
+			writeValue(h, v.Elem())
+		}
+	default:
+		panic("hashmap: hash of non comparable type " + v.Type().String())
+	}
+}
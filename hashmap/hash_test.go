@@ -0,0 +1,89 @@
+package hashmap
+
+import (
+	"hash/maphash"
+	"math"
+	"reflect"
+	"testing"
+)
+
+type point struct {
+	x, y float64
+	_    int
+	name string
+}
+
+type id int
+
+type boxed struct {
+	v any
+}
+
+// hashValue hashes v by reflection, as HashComparable hashes keys that are not strings or integers, including
+// interfaces, which are only comparable type arguments from go 1.20.
+func hashValue(seed maphash.Seed, v any) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	writeValue(&h, reflect.ValueOf(v))
+	return h.Sum64()
+}
+
+func TestHashComparable(t *testing.T) {
+	seed := maphash.MakeSeed()
+	x, y := 1, 1
+
+	tests := []struct {
+		name string
+		a, b any
+		hash func(a any) uint64
+	}{
+		{"string", "skale", "skale", func(a any) uint64 { return HashComparable(seed, a.(string)) }},
+		{"int", 42, 42, func(a any) uint64 { return HashComparable(seed, a.(int)) }},
+		{"named int", id(42), id(42), func(a any) uint64 { return HashComparable(seed, a.(id)) }},
+		{"zeros", 0.0, math.Copysign(0, -1), func(a any) uint64 { return HashComparable(seed, a.(float64)) }},
+		{"struct", point{x: 1, name: "p"}, point{x: 1, name: "p"}, func(a any) uint64 { return HashComparable(seed, a.(point)) }},
+		{"struct zeros", point{y: 0}, point{y: math.Copysign(0, -1)}, func(a any) uint64 { return HashComparable(seed, a.(point)) }},
+		{"array", [2]string{"a", "b"}, [2]string{"a", "b"}, func(a any) uint64 { return HashComparable(seed, a.([2]string)) }},
+		{"pointer", &x, &x, func(a any) uint64 { return HashComparable(seed, a.(*int)) }},
+		{"interface", boxed{7}, boxed{7}, func(a any) uint64 { return hashValue(seed, a) }},
+		{"nil interface", boxed{}, boxed{}, func(a any) uint64 { return hashValue(seed, a) }},
+		{"complex", complex(1, 0), complex(1, math.Copysign(0, -1)), func(a any) uint64 { return HashComparable(seed, a.(complex128)) }},
+	}
+	for _, tt := range tests {
+		if tt.a != tt.b {
+			t.Fatalf("%s: %v != %v", tt.name, tt.a, tt.b)
+		}
+		if tt.hash(tt.a) != tt.hash(tt.b) {
+			t.Errorf("%s: equal keys %v and %v have different hashes", tt.name, tt.a, tt.b)
+		}
+	}
+
+	if HashComparable(seed, &x) == HashComparable(seed, &y) {
+		t.Errorf("pointers to different variables have the same hash")
+	}
+	if HashComparable(seed, point{name: "a"}) == HashComparable(seed, point{name: "b"}) {
+		t.Errorf("different structs have the same hash")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("hash of an interface holding a slice did not panic")
+		}
+	}()
+	hashValue(seed, boxed{[]int{1}})
+}
+
+func TestMapStructKeys(t *testing.T) {
+	m := NewComparable[point, int]()
+	for i := 0; i < 100; i++ {
+		m.Put(point{x: float64(i), name: "p"}, i)
+	}
+	for i := 0; i < 100; i++ {
+		if v, ok := m.Get(point{x: float64(i), name: "p"}); !ok || v != i {
+			t.Errorf("Get(point %d) = %d, %v, want %d, true", i, v, ok, i)
+		}
+	}
+	if v, ok := m.Get(point{y: math.Copysign(0, -1), name: "p"}); !ok || v != 0 {
+		t.Errorf("Get(point with -0) = %d, %v, want 0, true", v, ok)
+	}
+}
@@ -0,0 +1,299 @@
+// Package hashmap implements a hash map with separate chaining and incremental resizing.
+//
+// Every bucket is a linkedlist.IntrusiveList of entries, and every entry is also linked into a list of all the
+// entries in insertion order. When the map grows, it allocates a table twice as large and moves the old buckets to it
+// a few at a time, on each Put and Delete, so that no single operation pays for a whole resize. Lookups search both
+// tables while a resize is in progress. Walk follows the insertion order, which a resize does not change.
+//
+// The hash function is given the seed of the map, a random maphash.Seed unless Options.Seed sets one, so that hashes,
+// and thus collisions, differ from a map to another. maphash.String hashes string keys, and HashComparable any
+// comparable key:
+//
+//	m := hashmap.New[string, int](maphash.String)
+//
+// Structure is not thread safe.
+package hashmap
+
+import (
+	"hash/maphash"
+	"math"
+
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+const (
+	// DefaultMaxLoadFactor is the average number of entries per bucket above which a map grows, unless
+	// Options.MaxLoadFactor sets another one.
+	DefaultMaxLoadFactor = 1.0
+
+	// minBuckets is the number of buckets of an empty map.
+	minBuckets = 8
+
+	// migrateBuckets is the least number of old buckets moved to the new table by each Put and Delete during a
+	// resize.
+	migrateBuckets = 4
+)
+
+// entry is a key and its value, linked into its bucket and into the insertion order list.
+type entry[K comparable, V any] struct {
+	bucket linkedlist.Hook[entry[K, V]]
+	order  linkedlist.Hook[entry[K, V]]
+	hash   uint64
+	key    K
+	value  V
+}
+
+func bucketHook[K comparable, V any](e *entry[K, V]) *linkedlist.Hook[entry[K, V]] { return &e.bucket }
+func orderHook[K comparable, V any](e *entry[K, V]) *linkedlist.Hook[entry[K, V]]  { return &e.order }
+
+// table is an array of buckets, a power of two of them. Buckets are allocated on first use.
+type table[K comparable, V any] struct {
+	buckets []*linkedlist.IntrusiveList[entry[K, V]]
+	mask    uint64
+}
+
+func newTable[K comparable, V any](n int) *table[K, V] {
+	return &table[K, V]{buckets: make([]*linkedlist.IntrusiveList[entry[K, V]], n), mask: uint64(n - 1)}
+}
+
+// bucket returns the bucket of hash h, allocating it if needed.
+func (t *table[K, V]) bucket(h uint64) *linkedlist.IntrusiveList[entry[K, V]] {
+	i := h & t.mask
+	if t.buckets[i] == nil {
+		t.buckets[i] = linkedlist.NewIntrusive(bucketHook[K, V])
+	}
+	return t.buckets[i]
+}
+
+// Options configures a Map.
+type Options struct {
+	// Capacity is the number of entries the map holds before it first grows. 0 means a small map.
+	Capacity int
+	// MaxLoadFactor is the average number of entries per bucket above which the map grows. 0 means
+	// DefaultMaxLoadFactor.
+	MaxLoadFactor float64
+	// Seed is the seed given to the hash function. The zero value means a random seed.
+	Seed maphash.Seed
+}
+
+// Map is a hash map from keys of type K to values of type V.
+type Map[K comparable, V any] struct {
+	hash    func(seed maphash.Seed, key K) uint64
+	seed    maphash.Seed
+	maxLoad float64
+
+	// migrateStep is the number of old buckets moved by each Put and Delete, enough for a resize to finish before
+	// the map grows again
+	migrateStep int
+
+	cur      *table[K, V]
+	old      *table[K, V] // the table being moved to cur, nil when no resize is in progress
+	migrated int          // number of buckets of old already moved
+
+	order *linkedlist.IntrusiveList[entry[K, V]]
+}
+
+// New returns an empty map hashing its keys with hash.
+func New[K comparable, V any](hash func(seed maphash.Seed, key K) uint64) *Map[K, V] {
+	return NewWithOptions[K, V](hash, Options{})
+}
+
+// NewComparable returns an empty map hashing its keys with HashComparable.
+func NewComparable[K comparable, V any]() *Map[K, V] {
+	return NewWithOptions[K, V](HashComparable[K], Options{})
+}
+
+// NewWithOptions returns an empty map hashing its keys with hash, configured by opts. A nil hash means
+// HashComparable. It panics if opts.Capacity or opts.MaxLoadFactor is negative.
+func NewWithOptions[K comparable, V any](hash func(seed maphash.Seed, key K) uint64, opts Options) *Map[K, V] {
+	if opts.Capacity < 0 {
+		panic("hashmap: capacity must not be negative")
+	}
+	if opts.MaxLoadFactor < 0 {
+		panic("hashmap: max load factor must not be negative")
+	}
+	if hash == nil {
+		hash = HashComparable[K]
+	}
+	if opts.MaxLoadFactor == 0 {
+		opts.MaxLoadFactor = DefaultMaxLoadFactor
+	}
+	if opts.Seed == (maphash.Seed{}) {
+		opts.Seed = maphash.MakeSeed()
+	}
+
+	// the table holds at least one entry, so that a Put grows it at most once
+	n := minBuckets
+	for float64(opts.Capacity) > opts.MaxLoadFactor*float64(n) || opts.MaxLoadFactor*float64(n) < 1 {
+		n *= 2
+	}
+	// a resize of n old buckets starts with floor(maxLoad*n) entries, and the next one starts as many new entries
+	// later: moving 2/maxLoad buckets per operation empties the old table in time
+	step := migrateBuckets
+	if s := int(math.Ceil(2 / opts.MaxLoadFactor)); s > step {
+		step = s
+	}
+	return &Map[K, V]{
+		hash:        hash,
+		seed:        opts.Seed,
+		maxLoad:     opts.MaxLoadFactor,
+		migrateStep: step,
+		cur:         newTable[K, V](n),
+		order:       linkedlist.NewIntrusive(orderHook[K, V]),
+	}
+}
+
+// Len returns the number of entries of the map.
+// The complexity is O(1).
+func (m *Map[K, V]) Len() int {
+	return m.order.Len()
+}
+
+// Buckets returns the number of buckets of the map, the ones of the new table during a resize.
+func (m *Map[K, V]) Buckets() int {
+	return len(m.cur.buckets)
+}
+
+// LoadFactor returns the average number of entries per bucket.
+func (m *Map[K, V]) LoadFactor() float64 {
+	return float64(m.Len()) / float64(m.Buckets())
+}
+
+// Resizing reports whether a resize is in progress.
+func (m *Map[K, V]) Resizing() bool {
+	return m.old != nil
+}
+
+// find returns the entry of key, whose hash is h, and its bucket. Return a nil entry if key is not in the map.
+func (m *Map[K, V]) find(h uint64, key K) (*entry[K, V], *linkedlist.IntrusiveList[entry[K, V]]) {
+	b := m.cur.buckets[h&m.cur.mask]
+	if m.old != nil {
+		if i := h & m.old.mask; i >= uint64(m.migrated) && m.old.buckets[i] != nil {
+			if e := search(m.old.buckets[i], h, key); e != nil {
+				return e, m.old.buckets[i]
+			}
+		}
+	}
+	if b == nil {
+		return nil, nil
+	}
+	return search(b, h, key), b
+}
+
+// search returns the entry of key, whose hash is h, in bucket b. Return nil if there is none.
+func search[K comparable, V any](b *linkedlist.IntrusiveList[entry[K, V]], h uint64, key K) *entry[K, V] {
+	c := b.Cursor()
+	for e := c.MoveNext(); e != nil; e = c.MoveNext() {
+		if e.hash == h && e.key == key {
+			return e
+		}
+	}
+	return nil
+}
+
+// Get returns the value of key. Return false if key is not in the map.
+// The complexity is O(1) on average.
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	if e, _ := m.find(m.hash(m.seed, key), key); e != nil {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is in the map.
+// The complexity is O(1) on average.
+func (m *Map[K, V]) Contains(key K) bool {
+	e, _ := m.find(m.hash(m.seed, key), key)
+	return e != nil
+}
+
+// Put sets the value of key to v. Return the previous value and true if key was already in the map, in which case it
+// keeps its place in the insertion order.
+// The complexity is O(1) on average.
+func (m *Map[K, V]) Put(key K, v V) (V, bool) {
+	m.migrate()
+	h := m.hash(m.seed, key)
+	if e, _ := m.find(h, key); e != nil {
+		old := e.value
+		e.value = v
+		return old, true
+	}
+
+	if float64(m.Len()+1) > m.maxLoad*float64(len(m.cur.buckets)) {
+		m.grow()
+	}
+	e := &entry[K, V]{hash: h, key: key, value: v}
+	m.cur.bucket(h).PushBack(e)
+	m.order.PushBack(e)
+	var zero V
+	return zero, false
+}
+
+// Delete removes key from the map. Return its value and true if key was in the map.
+// The complexity is O(1) on average.
+func (m *Map[K, V]) Delete(key K) (V, bool) {
+	m.migrate()
+	e, b := m.find(m.hash(m.seed, key), key)
+	if e == nil {
+		var zero V
+		return zero, false
+	}
+	b.Remove(e)
+	m.order.Remove(e)
+	return e.value, true
+}
+
+// Clear removes all the entries of the map. The map keeps its number of buckets.
+// The complexity is O(n).
+func (m *Map[K, V]) Clear() {
+	m.finish()
+	for i := range m.cur.buckets {
+		m.cur.buckets[i] = nil
+	}
+	m.order.Init()
+}
+
+// Walk calls f on every entry, in the order the keys were added, until f returns false. f must not modify the map.
+func (m *Map[K, V]) Walk(f func(key K, v V) bool) {
+	c := m.order.Cursor()
+	for e := c.MoveNext(); e != nil && f(e.key, e.value); e = c.MoveNext() {
+	}
+}
+
+// grow starts a resize to a table twice as large. A resize still in progress is finished first, although migrateStep
+// makes sure that the previous one is over.
+func (m *Map[K, V]) grow() {
+	m.finish()
+	m.old = m.cur
+	m.cur = newTable[K, V](2 * len(m.old.buckets))
+	m.migrated = 0
+}
+
+// migrate moves a few buckets of the old table to the new one, if a resize is in progress.
+func (m *Map[K, V]) migrate() {
+	for i := 0; i < m.migrateStep && m.old != nil; i++ {
+		m.migrateBucket()
+	}
+}
+
+// finish moves the remaining buckets of the old table to the new one.
+func (m *Map[K, V]) finish() {
+	for m.old != nil {
+		m.migrateBucket()
+	}
+}
+
+// migrateBucket moves the next bucket of the old table to the new one, and ends the resize after the last bucket.
+func (m *Map[K, V]) migrateBucket() {
+	if b := m.old.buckets[m.migrated]; b != nil {
+		for e := b.PopFront(); e != nil; e = b.PopFront() {
+			m.cur.bucket(e.hash).PushBack(e)
+		}
+		m.old.buckets[m.migrated] = nil
+	}
+	m.migrated++
+	if m.migrated == len(m.old.buckets) {
+		m.old, m.migrated = nil, 0
+	}
+}
@@ -0,0 +1,226 @@
+package hashmap
+
+import (
+	"hash/maphash"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// checkMap verifies the buckets and the order list of m against model, and the keys in insertion order.
+func checkMap(t *testing.T, m *Map[int, int], model map[int]int, order []int) {
+	t.Helper()
+	if m.Len() != len(model) {
+		t.Errorf("Len() = %d, want %d", m.Len(), len(model))
+	}
+
+	entries := 0
+	for _, tab := range []*table[int, int]{m.cur, m.old} {
+		if tab == nil {
+			continue
+		}
+		for i, b := range tab.buckets {
+			if b == nil {
+				continue
+			}
+			if tab == m.old && i < m.migrated && b.Len() > 0 {
+				t.Errorf("migrated bucket %d of the old table has %d entries", i, b.Len())
+			}
+			c := b.Cursor()
+			for e := c.MoveNext(); e != nil; e = c.MoveNext() {
+				entries++
+				if e.hash&tab.mask != uint64(i) || e.hash != m.hash(m.seed, e.key) {
+					t.Errorf("key %d with hash %x is in bucket %d", e.key, e.hash, i)
+				}
+				if v, ok := model[e.key]; !ok || v != e.value {
+					t.Errorf("entry %d = %d, want %d, %v", e.key, e.value, v, ok)
+				}
+			}
+		}
+	}
+	if entries != len(model) {
+		t.Errorf("the buckets hold %d entries, want %d", entries, len(model))
+	}
+
+	var keys []int
+	m.Walk(func(k, v int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if !reflect.DeepEqual(keys, order) {
+		t.Errorf("Walk() visits %v, want %v", keys, order)
+	}
+}
+
+func TestMap(t *testing.T) {
+	m := New[string, int](maphash.String)
+	if _, ok := m.Get("a"); ok || m.Contains("a") || m.Len() != 0 {
+		t.Errorf("empty map: Get(a) or Contains(a) found a key")
+	}
+	if _, ok := m.Delete("a"); ok {
+		t.Errorf("empty map: Delete(a) = true")
+	}
+
+	for i, k := range []string{"a", "b", "c"} {
+		if _, replaced := m.Put(k, i); replaced {
+			t.Errorf("Put(%q) replaced a value", k)
+		}
+	}
+	if old, replaced := m.Put("b", 10); !replaced || old != 1 {
+		t.Errorf("Put(b) = %d, %v, want 1, true", old, replaced)
+	}
+	if v, ok := m.Get("b"); !ok || v != 10 || !m.Contains("c") {
+		t.Errorf("Get(b) = %d, %v, want 10, true", v, ok)
+	}
+	if v, ok := m.Delete("a"); !ok || v != 0 || m.Contains("a") || m.Len() != 2 {
+		t.Errorf("Delete(a) = %d, %v, want 0, true", v, ok)
+	}
+
+	var keys []string
+	m.Walk(func(k string, v int) bool {
+		keys = append(keys, k)
+		return false
+	})
+	if !reflect.DeepEqual(keys, []string{"b"}) {
+		t.Errorf("Walk() stopping after one entry visits %v, want [b]", keys)
+	}
+
+	m.Clear()
+	if m.Len() != 0 || m.Contains("b") {
+		t.Errorf("Clear() left %d entries", m.Len())
+	}
+}
+
+func TestMapResize(t *testing.T) {
+	m := NewComparable[int, int]()
+	model := make(map[int]int)
+	var order []int
+
+	buckets, resizes := m.Buckets(), 0
+	for i := 0; i < 1000; i++ {
+		m.Put(i, -i)
+		model[i] = -i
+		order = append(order, i)
+		if m.Buckets() != buckets {
+			buckets = m.Buckets()
+			resizes++
+			checkMap(t, m, model, order) // in the middle of a resize
+		}
+		if m.LoadFactor() > DefaultMaxLoadFactor {
+			t.Fatalf("Len() = %d: LoadFactor() = %v, want at most %v", m.Len(), m.LoadFactor(), DefaultMaxLoadFactor)
+		}
+	}
+	if resizes != 7 || m.Buckets() != 1024 {
+		t.Errorf("%d resizes to %d buckets, want 7 to 1024", resizes, m.Buckets())
+	}
+	checkMap(t, m, model, order)
+}
+
+func TestMapResizeLoadFactor(t *testing.T) {
+	for _, load := range []float64{0.05, 0.1, 0.2, 0.25, 0.3, 0.5, 0.75, 1, 2, 8} {
+		m := NewWithOptions[int, int](nil, Options{MaxLoadFactor: load})
+		buckets := m.Buckets()
+		for i := 0; i < 5000; i++ {
+			// Put moves migrateStep buckets before it may grow: the resize must be over by then
+			left := 0
+			if m.Resizing() {
+				left = len(m.old.buckets) - m.migrated
+			}
+			m.Put(i, i)
+			if m.Buckets() != buckets {
+				buckets = m.Buckets()
+				if left > m.migrateStep {
+					t.Fatalf("load %v, Len() = %d: grow entered while resizing, %d buckets left", load, m.Len(), left)
+				}
+			}
+		}
+	}
+}
+
+func TestMapOptions(t *testing.T) {
+	seed := maphash.MakeSeed()
+	m := NewWithOptions[int, int](nil, Options{Capacity: 100, MaxLoadFactor: 4, Seed: seed})
+	if m.Buckets() != 32 || m.seed != seed {
+		t.Errorf("Buckets() = %d, want 32, or the seed was not used", m.Buckets())
+	}
+	for i := 0; i < 128; i++ {
+		m.Put(i, i)
+	}
+	if m.Buckets() != 32 || m.Resizing() {
+		t.Errorf("Buckets() = %d after 128 entries, want 32 and no resize", m.Buckets())
+	}
+	m.Put(128, 128)
+	if m.Buckets() != 64 || !m.Resizing() {
+		t.Errorf("Buckets() = %d after 129 entries, want 64 and a resize", m.Buckets())
+	}
+
+	// the same seed gives the same hashes
+	m2 := NewWithOptions[int, int](nil, Options{Seed: seed})
+	if m.hash(m.seed, 42) != m2.hash(m2.seed, 42) {
+		t.Errorf("maps with the same seed hash 42 differently")
+	}
+
+	for _, opts := range []Options{{Capacity: -1}, {MaxLoadFactor: -1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewWithOptions(%+v) did not panic", opts)
+				}
+			}()
+			NewWithOptions[int, int](nil, opts)
+		}()
+	}
+}
+
+func TestMapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, maxLoad := range []float64{0.25, 1, 6.5} {
+		m := NewWithOptions[int, int](nil, Options{MaxLoadFactor: maxLoad})
+		model := make(map[int]int)
+		var order []int
+		for step := 0; step < 5000; step++ {
+			k := r.Intn(2000)
+			switch v, ok := model[k]; {
+			case r.Intn(3) > 0:
+				if old, replaced := m.Put(k, step); old != v || replaced != ok {
+					t.Fatalf("max load %v, step %d: Put(%d) = %d, %v, want %d, %v", maxLoad, step, k, old, replaced, v, ok)
+				}
+				if !ok {
+					order = append(order, k)
+				}
+				model[k] = step
+			default:
+				if old, deleted := m.Delete(k); old != v || deleted != ok {
+					t.Fatalf("max load %v, step %d: Delete(%d) = %d, %v, want %d, %v", maxLoad, step, k, old, deleted, v, ok)
+				}
+				for i, x := range order {
+					if x == k {
+						order = append(order[:i], order[i+1:]...)
+						break
+					}
+				}
+				delete(model, k)
+			}
+
+			k = r.Intn(2000)
+			want, wantOK := model[k]
+			if v, ok := m.Get(k); v != want || ok != wantOK || m.Contains(k) != wantOK {
+				t.Fatalf("max load %v, step %d: Get(%d) = %d, %v, want %d, %v", maxLoad, step, k, v, ok, want, wantOK)
+			}
+			if step%500 == 0 {
+				checkMap(t, m, model, order)
+			}
+		}
+		checkMap(t, m, model, order)
+	}
+}
+
+func TestMapGetAllocs(t *testing.T) {
+	m := New[string, int](maphash.String)
+	for i, k := range []string{"a", "b", "c", "d"} {
+		m.Put(k, i)
+	}
+	if n := testing.AllocsPerRun(100, func() { m.Get("c") }); n != 0 {
+		t.Errorf("Get allocates %v times, want 0", n)
+	}
+}
@@ -0,0 +1,186 @@
+// Package heap implements a binary min-heap with handles.
+//
+// Push returns a handle to the pushed item. The handle follows the item as it moves in the heap, so the item can be
+// updated (decrease-key and increase-key) or removed in O(log n), which is what Dijkstra's and Prim's algorithms need.
+//
+// Structure is not thread safe.
+// To pop every item of a heap h in ascending order:
+//
+//	for x := h.Pop(); x != nil; x = h.Pop() {
+//		// do something with x.Value
+//	}
+package heap
+
+import "golang.org/x/exp/constraints"
+
+// Handle is an item of a Heap. Value must not be modified while the item is in the heap, use Heap.Update instead.
+type Handle[T any] struct {
+	index int      // index in Heap.items
+	heap  *Heap[T] // nil once the item is popped or removed
+
+	Value T
+}
+
+// Heap is a binary min-heap ordered by a comparison function: Pop returns the smallest item first.
+type Heap[T any] struct {
+	items []*Handle[T]
+	cmp   func(a, b T) int
+}
+
+// New returns an empty heap ordered by cmp.
+// cmp(a, b) must return a negative number when a < b, a positive number when a > b and zero when a == b.
+func New[T any](cmp func(a, b T) int) *Heap[T] {
+	return &Heap[T]{cmp: cmp}
+}
+
+// NewOrdered returns an empty min-heap of an ordered type.
+func NewOrdered[T constraints.Ordered]() *Heap[T] {
+	return New(func(a, b T) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	})
+}
+
+// From returns a heap ordered by cmp holding values, and the handles of values, in the same order.
+// The complexity is O(n).
+func From[T any](cmp func(a, b T) int, values ...T) (*Heap[T], []*Handle[T]) {
+	h := New(cmp)
+	h.items = make([]*Handle[T], len(values))
+	for i, v := range values {
+		h.items[i] = &Handle[T]{index: i, heap: h, Value: v}
+	}
+	handles := append([]*Handle[T](nil), h.items...)
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h, handles
+}
+
+// Len returns the number of items in the heap.
+// The complexity is O(1).
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Clear removes every item from the heap. The handles of the previous items are no longer in the heap.
+// The complexity is O(n).
+func (h *Heap[T]) Clear() {
+	for _, x := range h.items {
+		x.heap = nil
+	}
+	h.items = nil
+}
+
+// Push adds value v to the heap and returns its handle.
+// The complexity is O(log n).
+func (h *Heap[T]) Push(v T) *Handle[T] {
+	x := &Handle[T]{index: len(h.items), heap: h, Value: v}
+	h.items = append(h.items, x)
+	h.up(x.index)
+	return x
+}
+
+// Peek returns the smallest item without removing it. Return nil if the heap is empty.
+// The complexity is O(1).
+func (h *Heap[T]) Peek() *Handle[T] {
+	if len(h.items) == 0 {
+		return nil
+	}
+	return h.items[0]
+}
+
+// Pop removes the smallest item from the heap and returns it. Return nil if the heap is empty.
+// The complexity is O(log n).
+func (h *Heap[T]) Pop() *Handle[T] {
+	if len(h.items) == 0 {
+		return nil
+	}
+	x := h.items[0]
+	h.Remove(x)
+	return x
+}
+
+// Contains reports whether the item of handle x is in the heap.
+func (h *Heap[T]) Contains(x *Handle[T]) bool {
+	return x != nil && x.heap == h
+}
+
+// Remove removes the item of handle x from the heap. Return false if it is not in the heap.
+// The complexity is O(log n).
+func (h *Heap[T]) Remove(x *Handle[T]) bool {
+	if !h.Contains(x) {
+		return false
+	}
+
+	i, last := x.index, len(h.items)-1
+	if i != last {
+		h.swap(i, last)
+	}
+	h.items[last] = nil
+	h.items = h.items[:last]
+	if i != last {
+		h.fix(i)
+	}
+	x.heap = nil
+	return true
+}
+
+// Update sets the value of the item of handle x to v and restores the heap order, whether v is smaller
+// (decrease-key) or larger than the previous value. Return false if the item is not in the heap.
+// The complexity is O(log n).
+func (h *Heap[T]) Update(x *Handle[T], v T) bool {
+	if !h.Contains(x) {
+		return false
+	}
+	x.Value = v
+	h.fix(x.index)
+	return true
+}
+
+// fix moves the item at index i up or down to its place.
+func (h *Heap[T]) fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+// up moves the item at index i up to its place.
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if h.cmp(h.items[i].Value, h.items[parent].Value) >= 0 {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the item at index i down to its place, and reports whether it moved.
+func (h *Heap[T]) down(i int) bool {
+	start := i
+	for {
+		smallest := i
+		for _, child := range [2]int{2*i + 1, 2*i + 2} {
+			if child < len(h.items) && h.cmp(h.items[child].Value, h.items[smallest].Value) < 0 {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			return i != start
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
@@ -0,0 +1,142 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkHeap verifies the heap order and the indexes of the handles.
+func checkHeap[T any](t *testing.T, h *Heap[T]) {
+	t.Helper()
+	for i, x := range h.items {
+		if x.index != i || x.heap != h {
+			t.Errorf("item %d has index %d, heap %p", i, x.index, x.heap)
+		}
+		if i > 0 && h.cmp(h.items[(i-1)/2].Value, x.Value) > 0 {
+			t.Errorf("item %d (%v) is smaller than its parent (%v)", i, x.Value, h.items[(i-1)/2].Value)
+		}
+	}
+}
+
+// drain pops every item of h.
+func drain[T any](h *Heap[T]) []T {
+	var vs []T
+	for x := h.Pop(); x != nil; x = h.Pop() {
+		vs = append(vs, x.Value)
+	}
+	return vs
+}
+
+func TestHeap(t *testing.T) {
+	h := NewOrdered[int]()
+	if h.Peek() != nil || h.Pop() != nil || h.Len() != 0 {
+		t.Errorf("empty heap: Peek(), Pop() not nil or Len() = %d", h.Len())
+	}
+
+	for _, v := range []int{5, 3, 8, 1, 9, 2} {
+		h.Push(v)
+		checkHeap(t, h)
+	}
+	if h.Len() != 6 || h.Peek().Value != 1 {
+		t.Errorf("Len(), Peek() = %d, %v, want 6, 1", h.Len(), h.Peek().Value)
+	}
+
+	x := h.Pop()
+	if x.Value != 1 || h.Contains(x) || h.Remove(x) || h.Update(x, 0) {
+		t.Errorf("a popped handle is still in the heap")
+	}
+	checkHeap(t, h)
+
+	if got := drain(h); !sort.IntsAreSorted(got) || len(got) != 5 {
+		t.Errorf("drain = %v, want 5 sorted values", got)
+	}
+}
+
+func TestHeapUpdate(t *testing.T) {
+	h := NewOrdered[int]()
+	handles := make([]*Handle[int], 10)
+	for i := range handles {
+		handles[i] = h.Push(10 * i)
+	}
+
+	// decrease-key
+	if !h.Update(handles[7], -1) || h.Peek() != handles[7] {
+		t.Errorf("Update(70 -> -1) did not move the item to the top")
+	}
+	checkHeap(t, h)
+
+	// increase-key
+	if !h.Update(handles[7], 1000) || !h.Update(handles[0], 55) {
+		t.Errorf("Update() = false, want true")
+	}
+	checkHeap(t, h)
+	if h.Peek() != handles[1] {
+		t.Errorf("Peek() = %v, want 10", h.Peek().Value)
+	}
+
+	if !h.Remove(handles[4]) || h.Remove(handles[4]) {
+		t.Errorf("Remove() twice = false/true, want true/false")
+	}
+	checkHeap(t, h)
+
+	want := []int{10, 20, 30, 50, 55, 60, 80, 90, 1000}
+	got := drain(h)
+	for i := range want {
+		if i >= len(got) || got[i] != want[i] {
+			t.Fatalf("drain = %v, want %v", got, want)
+		}
+	}
+
+	other := NewOrdered[int]()
+	x := other.Push(1)
+	if h.Contains(x) || h.Remove(x) || h.Update(x, 2) {
+		t.Errorf("a heap accepted the handle of another heap")
+	}
+}
+
+func TestHeapFrom(t *testing.T) {
+	values := []int{4, 8, 1, 7, 3, 3, 9}
+	h, handles := From(func(a, b int) int { return a - b }, values...)
+	checkHeap(t, h)
+	for i, x := range handles {
+		if x.Value != values[i] || !h.Contains(x) {
+			t.Errorf("handle %d = %v, want %d in the heap", i, x.Value, values[i])
+		}
+	}
+
+	h.Clear()
+	if h.Len() != 0 || h.Contains(handles[0]) {
+		t.Errorf("Clear() left items in the heap")
+	}
+}
+
+func TestHeapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := NewOrdered[int]()
+	var live []*Handle[int]
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(4); {
+		case op == 0 || len(live) == 0:
+			live = append(live, h.Push(r.Intn(1000)))
+		case op == 1:
+			j := r.Intn(len(live))
+			h.Update(live[j], r.Intn(1000))
+		case op == 2:
+			j := r.Intn(len(live))
+			h.Remove(live[j])
+			live = append(live[:j], live[j+1:]...)
+		default:
+			min := h.Peek()
+			for _, x := range live {
+				if x.Value < min.Value {
+					t.Fatalf("Peek() = %d, but %d is in the heap", min.Value, x.Value)
+				}
+			}
+		}
+	}
+	checkHeap(t, h)
+	if h.Len() != len(live) {
+		t.Errorf("Len() = %d, want %d", h.Len(), len(live))
+	}
+}
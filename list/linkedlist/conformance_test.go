@@ -0,0 +1,41 @@
+package linkedlist_test
+
+import (
+	"testing"
+
+	"github.com/nnhatnam/skale/container"
+	"github.com/nnhatnam/skale/container/containertest"
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+var (
+	_ container.Deque[int, *linkedlist.Node[int]]      = (*linkedlist.List[int])(nil)
+	_ container.Iterable[*linkedlist.Node[int]]        = (*linkedlist.List[int])(nil)
+	_ container.BidiCursor[int, *linkedlist.Node[int]] = (*linkedlist.Cursor[int])(nil)
+)
+
+func nodeValue(n *linkedlist.Node[int]) int {
+	return n.Value
+}
+
+func TestListConformance(t *testing.T) {
+	containertest.TestDeque(t, func() container.Deque[int, *linkedlist.Node[int]] {
+		return linkedlist.New[int]()
+	}, nodeValue)
+
+	containertest.TestIterable(t, func(values []int) container.Iterable[*linkedlist.Node[int]] {
+		return linkedlist.From(values...)
+	}, nodeValue)
+}
+
+func TestZeroListConformance(t *testing.T) {
+	containertest.TestDeque(t, func() container.Deque[int, *linkedlist.Node[int]] {
+		return &linkedlist.List[int]{}
+	}, nodeValue)
+}
+
+func TestCursorConformance(t *testing.T) {
+	containertest.TestBidiCursor(t, func(values []int) container.BidiCursor[int, *linkedlist.Node[int]] {
+		return linkedlist.From(values...).Cursor()
+	})
+}
@@ -1,11 +1,22 @@
 package linkedlist
 
-// Cursor is a read-only object that points to a node in a list. It contains a reference to the list and the node it's currently pointing to.
+// Cursor is an object that points to a node in a list. It contains a reference to the list and the node it's currently pointing to.
+// A cursor can read and walk the list, and edit the node it points to without invalidating other cursors.
 type Cursor[T any] struct {
 	list    *List[T]
-	current *node[T]
+	current *Node[T]
 }
 
+// Advance tells Cursor.Remove where the cursor goes after the removal.
+type Advance int
+
+const (
+	// AdvanceNext moves the cursor to the node after the removed one, like List.RemoveAt.
+	AdvanceNext Advance = iota
+	// AdvancePrev moves the cursor to the node before the removed one.
+	AdvancePrev
+)
+
 // Equal returns true if the two cursors point to the same node in the same list.
 // if either cursor is not valid, it returns false.
 func (c *Cursor[T]) Equal(c2 *Cursor[T]) bool {
@@ -22,7 +33,70 @@ func (c *Cursor[T]) Value() T {
 	if !c.IsValid() {
 		panic("cursor is not valid when calling Value()")
 	}
-	return c.current.value
+	return c.current.Value
+}
+
+// Set replaces the value of the node that the cursor points to. The node stays in place, so other cursors pointing to it stay valid.
+// Return false if the cursor is not valid or is pointing to the sentinel node.
+func (c *Cursor[T]) Set(v T) bool {
+	if !c.IsValid() || c.current == &c.list.root {
+		return false
+	}
+	c.current.Value = v
+	return true
+}
+
+// Update calls f with a pointer to the value of the node that the cursor points to, so the value can be modified in place.
+// Return false, without calling f, if the cursor is not valid or is pointing to the sentinel node.
+func (c *Cursor[T]) Update(f func(v *T)) bool {
+	if !c.IsValid() || c.current == &c.list.root {
+		return false
+	}
+	f(&c.current.Value)
+	return true
+}
+
+// SwapNext exchanges the node that the cursor points to with the next node. The cursor keeps pointing to the same node,
+// which is now one position further in the list.
+// Return false if the cursor is not valid, or is pointing to the sentinel node or the last node.
+// The complexity is O(1).
+func (c *Cursor[T]) SwapNext() bool {
+	if !c.IsValid() || c.current == &c.list.root || c.current.next == &c.list.root {
+		return false
+	}
+	c.list.move(c.current, c.current.next)
+	return true
+}
+
+// SwapPrev exchanges the node that the cursor points to with the previous node. The cursor keeps pointing to the same node,
+// which is now one position earlier in the list.
+// Return false if the cursor is not valid, or is pointing to the sentinel node or the first node.
+// The complexity is O(1).
+func (c *Cursor[T]) SwapPrev() bool {
+	if !c.IsValid() || c.current == &c.list.root || c.current.prev == &c.list.root {
+		return false
+	}
+	c.list.move(c.current.prev, c.current)
+	return true
+}
+
+// Remove removes the node that the cursor points to from the list and returns it.
+// The cursor moves to the next node (AdvanceNext) or the previous node (AdvancePrev), which is the sentinel node
+// when the removed node was the last (or first) one. Other cursors pointing to the removed node become invalid.
+// Return nil if the cursor is not valid or is pointing to the sentinel node.
+// The complexity is O(1).
+func (c *Cursor[T]) Remove(advance Advance) *Node[T] {
+	if !c.IsValid() || c.current == &c.list.root {
+		return nil
+	}
+
+	n := c.current
+	if advance == AdvancePrev {
+		c.current = n.prev
+	} else {
+		c.current = n.next
+	}
+	return c.list.remove(n)
 }
 
 // Clone creates a new cursor that points to the same node as the current cursor.
@@ -58,26 +132,26 @@ func (c *Cursor[T]) ClonePrev() *Cursor[T] {
 	return nil // invalid cursor
 }
 
-// node returns the node that the cursor points to.
-func (c *Cursor[T]) node() *node[T] {
+// Node returns the node that the cursor points to.
+func (c *Cursor[T]) Node() *Node[T] {
 	if c.current != &c.list.root && c.IsValid() {
 		return c.current
 	}
 	return nil
 }
 
-// nodeNext returns the node after the node the cursor is currently pointing to.
+// NodeNext returns the node after the node the cursor is currently pointing to.
 // Return nil if the cursor is pointing to the last node in the list.
-func (c *Cursor[T]) nodeNext() *node[T] {
+func (c *Cursor[T]) NodeNext() *Node[T] {
 	if c.current.next != &c.list.root && c.IsValid() {
 		return c.current.next
 	}
 	return nil
 }
 
-// nodePrev returns the node before the node the cursor is currently pointing to.
+// NodePrev returns the node before the node the cursor is currently pointing to.
 // Return nil if the cursor is pointing to the first node in the list.
-func (c *Cursor[T]) nodePrev() *node[T] {
+func (c *Cursor[T]) NodePrev() *Node[T] {
 	if c.current.prev != &c.list.root && c.IsValid() {
 		return c.current.prev
 	}
@@ -86,7 +160,7 @@ func (c *Cursor[T]) nodePrev() *node[T] {
 
 // MoveNext moves the cursor to the next node in the list and return the node.
 // Move to sentinel node and return nil if the cursor is pointing to the last node in the list.
-func (c *Cursor[T]) MoveNext() *node[T] {
+func (c *Cursor[T]) MoveNext() *Node[T] {
 
 	c.current = c.current.next
 	if c.current == &c.list.root {
@@ -97,7 +171,7 @@ func (c *Cursor[T]) MoveNext() *node[T] {
 
 // MovePrev moves the cursor to the previous node in the list and return the node.
 // Move to sentinel node and return nil if the cursor is pointing to the first node in the list.
-func (c *Cursor[T]) MovePrev() *node[T] {
+func (c *Cursor[T]) MovePrev() *Node[T] {
 
 	c.current = c.current.prev
 	if c.current == &c.list.root {
@@ -136,7 +210,7 @@ func (c *Cursor[T]) MoveToBack() bool {
 
 // WalkAscending moves the cursor to the next node in the list and call the function f with the node.
 // Keep walking until f returns false or the cursor reach the sentinel node.
-func (c *Cursor[T]) WalkAscending(f func(n *node[T]) bool) {
+func (c *Cursor[T]) WalkAscending(f func(n *Node[T]) bool) {
 	if c.list.len > 0 && c.IsValid() {
 
 		if c.current != &c.list.root {
@@ -155,7 +229,7 @@ func (c *Cursor[T]) WalkAscending(f func(n *node[T]) bool) {
 
 // WalkDescending moves the cursor to the previous node in the list and call the function f with the node.
 // Keep walking until f returns false or the cursor reach the sentinel node.
-func (c *Cursor[T]) WalkDescending(f func(n *node[T]) bool) {
+func (c *Cursor[T]) WalkDescending(f func(n *Node[T]) bool) {
 	if c.list.len > 0 && c.IsValid() {
 
 		if c.current != &c.list.root {
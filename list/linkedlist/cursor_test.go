@@ -4,10 +4,19 @@ import "testing"
 
 func checkCursor[T any](t *testing.T, l *List[T], cursors []*Cursor[T]) {
 
+	// nodes currently linked into l, the sentinel included
+	nodes := map[*Node[T]]bool{&l.root: true}
+	if l.root.next != nil {
+		for n := l.root.next; n != &l.root; n = n.next {
+			nodes[n] = true
+		}
+	}
+
 	for _, c := range cursors {
 
 		if c == nil {
 			t.Errorf("Cursor = nil, want non-nil")
+			continue
 		}
 
 		if c.current == nil {
@@ -18,6 +27,10 @@ func checkCursor[T any](t *testing.T, l *List[T], cursors []*Cursor[T]) {
 			t.Errorf("Cursor.list = %v, want %v", c.list, l)
 		}
 
+		if c.current != nil && !nodes[c.current] {
+			t.Errorf("Cursor.current = %p, want a node of the list", c.current)
+		}
+
 		if l.len == 0 {
 			//check cursor at sentinel node
 			if c.current != &l.root {
@@ -557,3 +570,104 @@ func TestCursorInvalid(t *testing.T) {
 	}
 
 }
+
+func TestCursorSet(t *testing.T) {
+	l := From(1, 2, 3)
+	c := l.FrontCursor()
+	c.MoveNext()
+	other := c.Clone()
+
+	if !c.Set(20) {
+		t.Errorf("Cursor.Set() = false, want true")
+	}
+	checkList(t, l, []int{1, 20, 3})
+
+	if !c.Update(func(v *int) { *v++ }) {
+		t.Errorf("Cursor.Update() = false, want true")
+	}
+	checkList(t, l, []int{1, 21, 3})
+
+	if !other.IsValid() || other.Value() != 21 {
+		t.Errorf("other cursor = %v after Set(), want a valid cursor to 21", other.current)
+	}
+
+	sentinel := l.Cursor()
+	if sentinel.Set(0) || sentinel.Update(func(v *int) { t.Errorf("Update() called f on the sentinel") }) {
+		t.Errorf("Cursor.Set() on the sentinel = true, want false")
+	}
+	checkCursor(t, l, []*Cursor[int]{c, other, sentinel})
+}
+
+func TestCursorSwapNextPrev(t *testing.T) {
+	l := From(1, 2, 3)
+	c1 := l.FrontCursor()
+	c2 := c1.CloneNext()
+	c3 := l.BackCursor()
+
+	if !c1.SwapNext() {
+		t.Errorf("Cursor.SwapNext() = false, want true")
+	}
+	checkListPointers(t, l, []*Node[int]{c2.Node(), c1.Node(), c3.Node()})
+
+	if !c1.SwapNext() {
+		t.Errorf("Cursor.SwapNext() = false, want true")
+	}
+	checkListPointers(t, l, []*Node[int]{c2.Node(), c3.Node(), c1.Node()})
+
+	if c1.SwapNext() {
+		t.Errorf("Cursor.SwapNext() on the last node = true, want false")
+	}
+
+	if !c1.SwapPrev() || !c1.SwapPrev() {
+		t.Errorf("Cursor.SwapPrev() = false, want true")
+	}
+	checkListPointers(t, l, []*Node[int]{c1.Node(), c2.Node(), c3.Node()})
+
+	if c1.SwapPrev() {
+		t.Errorf("Cursor.SwapPrev() on the first node = true, want false")
+	}
+	if l.Cursor().SwapNext() || l.Cursor().SwapPrev() {
+		t.Errorf("Cursor.SwapNext() on the sentinel = true, want false")
+	}
+	checkList(t, l, []int{1, 2, 3})
+}
+
+func TestCursorRemove(t *testing.T) {
+	l := From(1, 2, 3, 4)
+	c := l.FrontCursor()
+	c.MoveNext()
+	stale := c.Clone()
+
+	if n := c.Remove(AdvanceNext); n == nil || n.Value != 2 {
+		t.Errorf("Cursor.Remove(AdvanceNext) = %v, want 2", n)
+	}
+	checkList(t, l, []int{1, 3, 4})
+	if c.Value() != 3 {
+		t.Errorf("cursor = %v after Remove(AdvanceNext), want 3", c.Value())
+	}
+	if stale.IsValid() {
+		t.Errorf("cursor to the removed node is still valid")
+	}
+
+	if n := c.Remove(AdvancePrev); n == nil || n.Value != 3 {
+		t.Errorf("Cursor.Remove(AdvancePrev) = %v, want 3", n)
+	}
+	checkList(t, l, []int{1, 4})
+	if c.Value() != 1 {
+		t.Errorf("cursor = %v after Remove(AdvancePrev), want 1", c.Value())
+	}
+
+	// removing the first node moving backward lands on the sentinel
+	c.Remove(AdvancePrev)
+	if c.Node() != nil {
+		t.Errorf("cursor = %v after removing the first node, want the sentinel", c.Node())
+	}
+	if c.Remove(AdvanceNext) != nil {
+		t.Errorf("Cursor.Remove() on the sentinel = non-nil, want nil")
+	}
+
+	c.MoveNext()
+	c.Remove(AdvanceNext)
+	checkListPointers(t, l, []*Node[int]{})
+	checkCursor(t, l, []*Cursor[int]{c})
+}
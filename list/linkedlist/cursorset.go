@@ -0,0 +1,260 @@
+package linkedlist
+
+// CursorPolicy defines what a CursorSet does with cursors that collide (end up on the same node)
+// or point to a node removed or moved by the set.
+type CursorPolicy int
+
+const (
+	// PolicyMerge advances the cursors of removed or moved nodes to the next remaining node,
+	// and merges cursors that end up on the same node into one.
+	PolicyMerge CursorPolicy = iota
+	// PolicyAdvance advances the cursors of removed or moved nodes to the next remaining node, and keeps colliding
+	// cursors.
+	PolicyAdvance
+	// PolicyInvalidate closes the cursors of removed or moved nodes and drops them from the set, and keeps colliding
+	// cursors.
+	PolicyInvalidate
+)
+
+// CursorSet tracks many cursors of the same list and applies edits at all of them in one pass.
+// The set holds the cursors given to Add and moves them in place, so callers can keep their own references.
+// Cursors that become invalid through edits made outside the set (e.g. List.RemoveAt) are dropped from the set.
+type CursorSet[T any] struct {
+	list    *List[T]
+	policy  CursorPolicy
+	cursors []*Cursor[T]
+}
+
+// NewCursorSet returns an empty cursor set for list l.
+func NewCursorSet[T any](l *List[T], policy CursorPolicy) *CursorSet[T] {
+	l.lazyInit()
+	return &CursorSet[T]{list: l, policy: policy}
+}
+
+// Add adds cursor c to the set. Return false if c is not valid or not associated with the list of the set.
+// With PolicyMerge, a cursor pointing to a node that is already tracked is merged: it is not added and Add returns false.
+func (s *CursorSet[T]) Add(c *Cursor[T]) bool {
+	if c.list != s.list || !c.IsValid() {
+		return false
+	}
+	for _, other := range s.cursors {
+		if other == c || s.policy == PolicyMerge && other.current == c.current {
+			return false
+		}
+	}
+	s.cursors = append(s.cursors, c)
+	return true
+}
+
+// Len returns the number of cursors in the set.
+func (s *CursorSet[T]) Len() int {
+	s.normalize()
+	return len(s.cursors)
+}
+
+// Cursors returns the cursors of the set, in the order they were added.
+func (s *CursorSet[T]) Cursors() []*Cursor[T] {
+	s.normalize()
+	cursors := make([]*Cursor[T], len(s.cursors))
+	copy(cursors, s.cursors)
+	return cursors
+}
+
+// normalize drops the cursors invalidated outside the set, and merges colliding cursors with PolicyMerge.
+func (s *CursorSet[T]) normalize() {
+	var seen map[*Node[T]]bool
+	if s.policy == PolicyMerge {
+		seen = make(map[*Node[T]]bool, len(s.cursors))
+	}
+
+	kept := s.cursors[:0]
+	for _, c := range s.cursors {
+		if c.list != s.list || !c.IsValid() {
+			continue
+		}
+		if seen != nil {
+			if seen[c.current] {
+				continue
+			}
+			seen[c.current] = true
+		}
+		kept = append(kept, c)
+	}
+	for i := len(kept); i < len(s.cursors); i++ {
+		s.cursors[i] = nil // avoid memory leaks
+	}
+	s.cursors = kept
+}
+
+// nodes returns the distinct nodes the cursors point to, skipping the sentinel node.
+func (s *CursorSet[T]) nodes() []*Node[T] {
+	s.normalize()
+	seen := make(map[*Node[T]]bool, len(s.cursors))
+	nodes := make([]*Node[T], 0, len(s.cursors))
+	for _, c := range s.cursors {
+		if c.current == &s.list.root || seen[c.current] {
+			continue
+		}
+		seen[c.current] = true
+		nodes = append(nodes, c.current)
+	}
+	return nodes
+}
+
+// InsertBefore inserts a new value v before every distinct node pointed to by the cursors. Cursors stay at the same position.
+// Cursors pointing to the sentinel node are skipped.
+// The complexity is O(k), where k is the number of cursors.
+func (s *CursorSet[T]) InsertBefore(v T) {
+	for _, n := range s.nodes() {
+		s.list.insertValue(v, n.prev)
+	}
+}
+
+// InsertAfter inserts a new value v after every distinct node pointed to by the cursors. Cursors stay at the same position.
+// Cursors pointing to the sentinel node are skipped.
+// The complexity is O(k).
+func (s *CursorSet[T]) InsertAfter(v T) {
+	for _, n := range s.nodes() {
+		s.list.insertValue(v, n)
+	}
+}
+
+// Remove removes every distinct node pointed to by the cursors, and returns the number of removed nodes.
+// Cursors pointing to a removed node are handled according to the policy of the set: they either advance to the
+// next remaining node (which may be the sentinel node), or are closed and dropped.
+// Cursors pointing to the sentinel node are skipped.
+// The complexity is O(k + r), where r is the number of removed nodes a cursor has to skip over.
+func (s *CursorSet[T]) Remove() int {
+	nodes := s.nodes()
+	leaving := leavingSet(nodes)
+
+	// find where every cursor lands before unlinking anything
+	landing := s.landing(leaving)
+	for _, n := range nodes {
+		s.list.remove(n)
+	}
+	s.land(landing)
+	return len(nodes)
+}
+
+// leavingSet returns the set of the nodes leaving their position.
+func leavingSet[T any](nodes []*Node[T]) map[*Node[T]]bool {
+	leaving := make(map[*Node[T]]bool, len(nodes))
+	for _, n := range nodes {
+		leaving[n] = true
+	}
+	return leaving
+}
+
+// skip returns the first node from n on that is not leaving its position. The sentinel node never leaves.
+func skip[T any](n *Node[T], leaving map[*Node[T]]bool) *Node[T] {
+	for leaving[n] {
+		n = n.next
+	}
+	return n
+}
+
+// landing returns the node every cursor lands on when the leaving nodes leave their position: the next node staying
+// in place.
+func (s *CursorSet[T]) landing(leaving map[*Node[T]]bool) []*Node[T] {
+	landing := make([]*Node[T], len(s.cursors))
+	for i, c := range s.cursors {
+		landing[i] = skip(c.current, leaving)
+	}
+	return landing
+}
+
+// land moves the cursors to their landing nodes, or closes the cursors that had to move with PolicyInvalidate.
+func (s *CursorSet[T]) land(landing []*Node[T]) {
+	for i, c := range s.cursors {
+		if landing[i] != c.current && s.policy == PolicyInvalidate {
+			c.Close()
+			continue
+		}
+		c.current = landing[i]
+	}
+	s.normalize()
+}
+
+// MoveToFront moves every distinct node pointed to by the cursors to the front of the list, and returns the number of
+// moved nodes. See MoveBefore.
+// The complexity is O(n).
+func (s *CursorSet[T]) MoveToFront() int {
+	return s.moveBefore(func(leaving map[*Node[T]]bool) *Node[T] { return skip(s.list.root.next, leaving) })
+}
+
+// MoveToBack moves every distinct node pointed to by the cursors to the back of the list, and returns the number of
+// moved nodes. See MoveBefore.
+// The complexity is O(n).
+func (s *CursorSet[T]) MoveToBack() int {
+	return s.moveBefore(func(map[*Node[T]]bool) *Node[T] { return &s.list.root })
+}
+
+// MoveBefore moves every distinct node pointed to by the cursors before the node at cursor mark, and returns the
+// number of moved nodes. If mark points to a moved node, the nodes are moved before the next node that stays in place.
+// A mark pointing to the sentinel node moves the nodes to the back of the list.
+// The moved nodes keep their order in the list. They leave their position as removed nodes do: the cursors of the set
+// pointing to them are handled according to the policy of the set, while cursors outside the set follow them.
+// Cursors pointing to the sentinel node are skipped. It does nothing and returns 0 if mark is not valid or not
+// associated with the list of the set.
+// The complexity is O(n).
+func (s *CursorSet[T]) MoveBefore(mark *Cursor[T]) int {
+	if mark.list != s.list || !mark.IsValid() {
+		return 0
+	}
+	return s.moveBefore(func(leaving map[*Node[T]]bool) *Node[T] { return skip(mark.current, leaving) })
+}
+
+// MoveAfter moves every distinct node pointed to by the cursors after the node at cursor mark, and returns the number
+// of moved nodes. If mark points to a moved node, the nodes are moved after the next node that stays in place.
+// A mark pointing to the sentinel node moves the nodes to the front of the list. See MoveBefore.
+// The complexity is O(n).
+func (s *CursorSet[T]) MoveAfter(mark *Cursor[T]) int {
+	if mark.list != s.list || !mark.IsValid() {
+		return 0
+	}
+	return s.moveBefore(func(leaving map[*Node[T]]bool) *Node[T] {
+		return skip(skip(mark.current, leaving).next, leaving)
+	})
+}
+
+// moveBefore moves the nodes pointed to by the cursors before the node returned by anchor, which stays in place.
+func (s *CursorSet[T]) moveBefore(anchor func(leaving map[*Node[T]]bool) *Node[T]) int {
+	leaving := leavingSet(s.nodes())
+	if len(leaving) == 0 {
+		return 0
+	}
+	before := anchor(leaving)
+	landing := s.landing(leaving)
+
+	// collect the nodes in list order, then relink them one after the other before the anchor
+	nodes := make([]*Node[T], 0, len(leaving))
+	for n := s.list.root.next; n != &s.list.root; n = n.next {
+		if leaving[n] {
+			nodes = append(nodes, n)
+		}
+	}
+	for _, n := range nodes {
+		s.list.move(n, before.prev)
+	}
+	s.land(landing)
+	return len(nodes)
+}
+
+// MoveNext moves every cursor to the next node in the list. Cursors pointing to the last node move to the sentinel node.
+func (s *CursorSet[T]) MoveNext() {
+	s.normalize()
+	for _, c := range s.cursors {
+		c.MoveNext()
+	}
+	s.normalize()
+}
+
+// MovePrev moves every cursor to the previous node in the list. Cursors pointing to the first node move to the sentinel node.
+func (s *CursorSet[T]) MovePrev() {
+	s.normalize()
+	for _, c := range s.cursors {
+		c.MovePrev()
+	}
+	s.normalize()
+}
@@ -0,0 +1,229 @@
+package linkedlist
+
+import "testing"
+
+// checkCursorSet checks the cursors of s with checkCursor, and that they point to the given values, in order.
+// A nil entry in values stands for the sentinel node.
+func checkCursorSet[T comparable](t *testing.T, l *List[T], s *CursorSet[T], values []any) {
+	cursors := s.Cursors()
+	checkCursor(t, l, cursors)
+
+	if len(cursors) != len(values) {
+		t.Errorf("CursorSet.Len() = %d, want %d", len(cursors), len(values))
+		return
+	}
+
+	for i, c := range cursors {
+		if values[i] == nil {
+			if c.Node() != nil {
+				t.Errorf("cursor[%d] = %v, want the sentinel", i, c.Node().Value)
+			}
+			continue
+		}
+		if c.Node() == nil || any(c.Node().Value) != values[i] {
+			t.Errorf("cursor[%d] = %v, want %v", i, c.Node(), values[i])
+		}
+	}
+
+	if s.policy == PolicyMerge {
+		seen := make(map[*Node[T]]bool)
+		for i, c := range cursors {
+			if seen[c.current] {
+				t.Errorf("cursor[%d] collides with another cursor under PolicyMerge", i)
+			}
+			seen[c.current] = true
+		}
+	}
+}
+
+func TestCursorSetAdd(t *testing.T) {
+	l := From(1, 2, 3)
+	s := NewCursorSet(l, PolicyMerge)
+
+	c := l.FrontCursor()
+	if !s.Add(c) {
+		t.Errorf("CursorSet.Add() = false, want true")
+	}
+	if s.Add(c) || s.Add(l.FrontCursor()) {
+		t.Errorf("CursorSet.Add() of a colliding cursor = true, want false")
+	}
+	if s.Add(From(1).FrontCursor()) {
+		t.Errorf("CursorSet.Add() of a cursor of another list = true, want false")
+	}
+	s.Add(l.BackCursor())
+	checkCursorSet(t, l, s, []any{1, 3})
+
+	// cursors invalidated outside the set are dropped
+	l.RemoveAt(l.BackCursor())
+	checkCursorSet(t, l, s, []any{1})
+
+	s = NewCursorSet(l, PolicyAdvance)
+	s.Add(l.FrontCursor())
+	if !s.Add(l.FrontCursor()) {
+		t.Errorf("CursorSet.Add() of a colliding cursor under PolicyAdvance = false, want true")
+	}
+	checkCursorSet(t, l, s, []any{1, 1})
+}
+
+func TestCursorSetInsert(t *testing.T) {
+	l := From("a", "b", "c")
+	s := NewCursorSet(l, PolicyAdvance)
+	s.Add(l.FrontCursor())
+	s.Add(l.BackCursor())
+	s.Add(l.BackCursor()) // collides, the insertion is done once
+	s.Add(l.Cursor())     // sentinel is skipped
+
+	s.InsertBefore("<")
+	checkList(t, l, []string{"<", "a", "b", "<", "c"})
+	s.InsertAfter(">")
+	checkList(t, l, []string{"<", "a", ">", "b", "<", "c", ">"})
+	checkCursorSet(t, l, s, []any{"a", "c", "c", nil})
+}
+
+func TestCursorSetRemove(t *testing.T) {
+	tests := []struct {
+		policy CursorPolicy
+		want   []any
+	}{
+		{PolicyMerge, []any{3, nil}},
+		{PolicyAdvance, []any{3, 3, 3, nil}},
+		{PolicyInvalidate, []any{nil}},
+	}
+
+	for _, tt := range tests {
+		l := From(1, 2, 3, 4, 5)
+		s := NewCursorSet(l, tt.policy)
+		s.Add(cursorAtIndex(l, 0))
+		s.Add(cursorAtIndex(l, 1))
+		s.Add(cursorAtIndex(l, 1))
+		s.Add(l.Cursor())
+		outside := cursorAtIndex(l, 1)
+
+		if n := s.Remove(); n != 2 {
+			t.Errorf("policy %d: CursorSet.Remove() = %d, want 2", tt.policy, n)
+		}
+		checkList(t, l, []int{3, 4, 5})
+
+		if outside.IsValid() {
+			t.Errorf("policy %d: cursor outside the set to a removed node is still valid", tt.policy)
+		}
+
+		// the sentinel cursor did not move, the others landed on 3 or were dropped
+		checkCursorSet(t, l, s, tt.want)
+	}
+}
+
+func TestCursorSetRemoveAdjacent(t *testing.T) {
+	l := From(1, 2, 3)
+	s := NewCursorSet(l, PolicyMerge)
+	s.Add(cursorAtIndex(l, 1))
+	s.Add(cursorAtIndex(l, 2))
+
+	if n := s.Remove(); n != 2 {
+		t.Errorf("CursorSet.Remove() = %d, want 2", n)
+	}
+	checkList(t, l, []int{1})
+	checkCursorSet(t, l, s, []any{nil})
+
+	// nothing to remove at the sentinel
+	if n := s.Remove(); n != 0 {
+		t.Errorf("CursorSet.Remove() = %d, want 0", n)
+	}
+}
+
+func TestCursorSetMove(t *testing.T) {
+	l := From(1, 2, 3)
+	s := NewCursorSet(l, PolicyMerge)
+	s.Add(cursorAtIndex(l, 0))
+	s.Add(cursorAtIndex(l, 2))
+
+	s.MoveNext()
+	checkCursorSet(t, l, s, []any{2, nil})
+	s.MoveNext()
+	checkCursorSet(t, l, s, []any{3, 1})
+	s.MovePrev()
+	s.MovePrev()
+	checkCursorSet(t, l, s, []any{1, 3})
+}
+
+func TestCursorSetMoveNodes(t *testing.T) {
+	tests := []struct {
+		policy CursorPolicy
+		want   []any
+	}{
+		// the cursors of 2 and 3 collide on 4 when their nodes move away
+		{PolicyMerge, []any{4, nil}},
+		{PolicyAdvance, []any{4, 4, nil}},
+		{PolicyInvalidate, []any{nil}},
+	}
+
+	for _, tt := range tests {
+		l := From(1, 2, 3, 4, 5)
+		s := NewCursorSet(l, tt.policy)
+		s.Add(cursorAtIndex(l, 1))
+		s.Add(cursorAtIndex(l, 2))
+		s.Add(l.Cursor())
+		outside := cursorAtIndex(l, 2)
+
+		if n := s.MoveToBack(); n != 2 {
+			t.Errorf("policy %d: CursorSet.MoveToBack() = %d, want 2", tt.policy, n)
+		}
+		checkList(t, l, []int{1, 4, 5, 2, 3})
+		checkCursorSet(t, l, s, tt.want)
+
+		// cursors outside the set follow their node
+		if outside.Node() == nil || outside.Node().Value != 3 {
+			t.Errorf("policy %d: cursor outside the set = %v, want 3", tt.policy, outside.Node())
+		}
+	}
+}
+
+func TestCursorSetMoveMark(t *testing.T) {
+	tests := []struct {
+		name string
+		move func(s *CursorSet[int], l *List[int]) int
+		want []int
+	}{
+		{"MoveToFront", func(s *CursorSet[int], l *List[int]) int { return s.MoveToFront() }, []int{2, 4, 1, 3, 5}},
+		{"MoveToBack", func(s *CursorSet[int], l *List[int]) int { return s.MoveToBack() }, []int{1, 3, 5, 2, 4}},
+		{"MoveBefore", func(s *CursorSet[int], l *List[int]) int { return s.MoveBefore(cursorAtIndex(l, 0)) }, []int{2, 4, 1, 3, 5}},
+		{"MoveAfter", func(s *CursorSet[int], l *List[int]) int { return s.MoveAfter(cursorAtIndex(l, 2)) }, []int{1, 3, 2, 4, 5}},
+		// a mark on a moved node stands for the next node staying in place
+		{"MoveBefore moved", func(s *CursorSet[int], l *List[int]) int { return s.MoveBefore(cursorAtIndex(l, 3)) }, []int{1, 3, 2, 4, 5}},
+		{"MoveAfter moved", func(s *CursorSet[int], l *List[int]) int { return s.MoveAfter(cursorAtIndex(l, 3)) }, []int{1, 3, 5, 2, 4}},
+		// the sentinel node stands before the front and after the back
+		{"MoveBefore sentinel", func(s *CursorSet[int], l *List[int]) int { return s.MoveBefore(l.Cursor()) }, []int{1, 3, 5, 2, 4}},
+		{"MoveAfter sentinel", func(s *CursorSet[int], l *List[int]) int { return s.MoveAfter(l.Cursor()) }, []int{2, 4, 1, 3, 5}},
+	}
+
+	for _, tt := range tests {
+		l := From(1, 2, 3, 4, 5)
+		s := NewCursorSet(l, PolicyAdvance)
+		s.Add(cursorAtIndex(l, 3)) // the moved nodes keep their order in the list, not the order of the cursors
+		s.Add(cursorAtIndex(l, 1))
+
+		if n := tt.move(s, l); n != 2 {
+			t.Errorf("%s: moved %d nodes, want 2", tt.name, n)
+		}
+		checkList(t, l, tt.want)
+		checkCursor(t, l, s.Cursors())
+	}
+
+	// an invalid mark or a mark of another list moves nothing
+	l := From(1, 2, 3)
+	s := NewCursorSet(l, PolicyMerge)
+	s.Add(cursorAtIndex(l, 1))
+	closed := l.FrontCursor()
+	closed.Close()
+	if s.MoveBefore(closed) != 0 || s.MoveAfter(From(4).FrontCursor()) != 0 {
+		t.Errorf("CursorSet.MoveBefore() or MoveAfter() with a bad mark moved nodes")
+	}
+	checkList(t, l, []int{1, 2, 3})
+
+	// nothing to move at the sentinel
+	s = NewCursorSet(l, PolicyMerge)
+	s.Add(l.Cursor())
+	if n := s.MoveToFront(); n != 0 {
+		t.Errorf("CursorSet.MoveToFront() = %d, want 0", n)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !skaledebug
+
+package linkedlist
+
+// debugValidate validates l after the mutation op in debug mode. It does nothing otherwise.
+func (l *List[T]) debugValidate(op string) {}
@@ -0,0 +1,15 @@
+//go:build skaledebug
+
+package linkedlist
+
+import "fmt"
+
+// debugValidate validates l after the mutation op, and panics with a dump of the list if it is broken.
+// It is enabled by the skaledebug build tag:
+//
+//	go test -tags skaledebug ./...
+func (l *List[T]) debugValidate(op string) {
+	if err := l.Validate(); err != nil {
+		panic(fmt.Sprintf("linkedlist: %s left the list broken: %v\n%s", op, err, l.dump()))
+	}
+}
@@ -0,0 +1,54 @@
+//go:build skaledebug
+
+package linkedlist
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDebugForeignNode(t *testing.T) {
+	l := From(1, 2, 3, 4)
+	c := l.FrontCursor()
+	c.MoveNext()
+
+	// a stale cursor after Detach still claims l, while its node moved to another list
+	v := l.Range(c, c.CloneNext())
+	detached := v.Detach()
+	if err := detached.Validate(); err != nil {
+		t.Fatalf("detached.Validate() = %v, want nil", err)
+	}
+
+	defer func() {
+		r := recover()
+		msg, _ := r.(string)
+		if !strings.Contains(msg, ErrForeignNode.Error()) || !strings.Contains(msg, "value") {
+			t.Errorf("moving a foreign node panicked with %v, want a foreign node error and a dump", r)
+		}
+	}()
+	l.MoveToFront(c)
+}
+
+func TestDebugValidateOwner(t *testing.T) {
+	a, b := From(1, 2), From(3)
+	b.AdoptBack(a.FrontCursor())
+	if err := a.Validate(); err != nil {
+		t.Errorf("a.Validate() = %v, want nil", err)
+	}
+	if err := b.Validate(); err != nil {
+		t.Errorf("b.Validate() = %v, want nil", err)
+	}
+
+	// splice a node of a into b by hand
+	n := a.root.next
+	a.remove(n)
+	n.owner = a.ident()
+	n.prev, n.next = &b.root, b.root.next
+	b.root.next.prev = n
+	b.root.next = n
+	b.len++
+	if err := b.Validate(); !errors.Is(err, ErrForeignNode) {
+		t.Errorf("b.Validate() = %v, want %v", err, ErrForeignNode)
+	}
+}
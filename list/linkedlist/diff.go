@@ -0,0 +1,275 @@
+package linkedlist
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Op is the kind of an Edit.
+type Op int
+
+const (
+	// OpInsert inserts a value.
+	OpInsert Op = iota
+	// OpDelete deletes a value.
+	OpDelete
+)
+
+// String returns "insert" or "delete".
+func (op Op) String() string {
+	switch op {
+	case OpInsert:
+		return "insert"
+	case OpDelete:
+		return "delete"
+	}
+	return fmt.Sprintf("Op(%d)", int(op))
+}
+
+// Edit is one step of an edit script.
+// Index is the 0-based position the edit applies at, in the list as it is when the edit is applied, i.e. after all the
+// previous edits of the script. For OpInsert, Value is inserted before the element at Index (at the back if Index is
+// the length of the list). For OpDelete, the element at Index is removed and Value is the removed value.
+type Edit[T any] struct {
+	Op    Op
+	Index int
+	Value T
+}
+
+// ErrEditOutOfRange is returned by Apply when an edit does not fit the list it is applied to.
+var ErrEditOutOfRange = errors.New("linkedlist: edit index out of range")
+
+// values returns the values of list l as a slice.
+func values[T any](l *List[T]) []T {
+	vs := make([]T, 0, l.len)
+	if l.len == 0 {
+		return vs
+	}
+	for n := l.root.next; n != &l.root; n = n.next {
+		vs = append(vs, n.Value)
+	}
+	return vs
+}
+
+// Diff returns a minimal edit script that transforms list a into list b. The edits are ordered by Index.
+// The complexity is O((N+M)D) time and O(N+M+D²) space, where N and M are the lengths of the lists and D the length of
+// the script.
+func Diff[T comparable](a, b *List[T]) []Edit[T] {
+	return DiffFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// DiffFunc is like Diff but compares values with eq.
+func DiffFunc[T any](a, b *List[T], eq func(x, y T) bool) []Edit[T] {
+	return myers(values(a), values(b), eq)
+}
+
+// myers computes the shortest edit script between a and b with the Myers O(ND) algorithm.
+// Within a changed block, deletions come before insertions.
+func myers[T any](a, b []T, eq func(x, y T) bool) []Edit[T] {
+	n, m := len(a), len(b)
+	max := n + m
+	off := max + 1
+	v := make([]int, 2*max+3)
+
+	// trace[d] holds the diagonals -d to d of v as it was before step d, the only ones the walk back reads at step d:
+	// trace[d][d+k] is v[off+k]
+	var trace [][]int
+
+search:
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v[off-d:off+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[off+k-1] < v[off+k+1] {
+				x = v[off+k+1] // down: insertion
+			} else {
+				x = v[off+k-1] + 1 // right: deletion
+			}
+			y := x - k
+			for x < n && y < m && eq(a[x], b[y]) {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// walk back from (n, m), collecting the steps in reverse order
+	type step struct {
+		op   Op
+		x, y int
+	}
+	var steps []step
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || k != d && v[d+k-1] < v[d+k+1] {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[d+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+		}
+		if x == prevX {
+			steps = append(steps, step{op: OpInsert, x: prevX, y: prevY})
+		} else {
+			steps = append(steps, step{op: OpDelete, x: prevX, y: prevY})
+		}
+		x, y = prevX, prevY
+	}
+
+	// replay the steps forward, tracking the index in the list being edited
+	edits := make([]Edit[T], 0, len(steps))
+	inserted, deleted := 0, 0
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		idx := s.x + inserted - deleted
+		if s.op == OpInsert {
+			edits = append(edits, Edit[T]{Op: OpInsert, Index: idx, Value: b[s.y]})
+			inserted++
+		} else {
+			edits = append(edits, Edit[T]{Op: OpDelete, Index: idx, Value: a[s.x]})
+			deleted++
+		}
+	}
+	return edits
+}
+
+// Apply applies an edit script, as returned by Diff, to list l.
+// The edits are applied through a cursor walking the list, so the nodes that are not deleted stay in place and
+// cursors pointing to them stay valid. Return ErrEditOutOfRange, leaving the edits before the faulty one applied,
+// if an edit does not fit the list.
+// The complexity is O(n + len(edits)) when the edits are ordered by Index.
+func Apply[T any](l *List[T], edits []Edit[T]) error {
+	l.lazyInit()
+	c := l.FrontCursor()
+	pos := 0 // index of c.current, l.len for the sentinel
+
+	for _, e := range edits {
+		if e.Index < 0 || e.Index > l.len || e.Op == OpDelete && e.Index == l.len {
+			return ErrEditOutOfRange
+		}
+
+		for ; pos < e.Index; pos++ {
+			c.MoveNext()
+		}
+		for ; pos > e.Index; pos-- {
+			c.MovePrev()
+		}
+
+		switch e.Op {
+		case OpInsert:
+			l.insertValue(e.Value, c.current.prev)
+			pos++
+		case OpDelete:
+			c.Remove(AdvanceNext)
+		default:
+			return fmt.Errorf("linkedlist: unknown edit op %v", e.Op)
+		}
+	}
+	return nil
+}
+
+// diffLine is a line of a rendered diff.
+type diffLine[T any] struct {
+	kind   byte // ' ', '-' or '+'
+	value  T
+	ai, bi int // 0-based line numbers in a and b
+}
+
+// WriteUnified writes the edit script as a unified diff of list a, one value per line formatted with %v.
+// Hunks show context unchanged values around every change. It is intended for debugging.
+func WriteUnified[T any](w io.Writer, a *List[T], edits []Edit[T], context int) error {
+	if context < 0 {
+		context = 0
+	}
+
+	av := values(a)
+	lines := make([]diffLine[T], 0, len(av)+len(edits))
+	ai, bi, idx := 0, 0, 0
+	for _, e := range edits {
+		for ; idx < e.Index && ai < len(av); idx++ {
+			lines = append(lines, diffLine[T]{' ', av[ai], ai, bi})
+			ai++
+			bi++
+		}
+		if e.Op == OpInsert {
+			lines = append(lines, diffLine[T]{'+', e.Value, ai, bi})
+			bi++
+			idx++
+		} else {
+			lines = append(lines, diffLine[T]{'-', e.Value, ai, bi})
+			ai++
+		}
+	}
+	for ; ai < len(av); ai++ {
+		lines = append(lines, diffLine[T]{' ', av[ai], ai, bi})
+		bi++
+	}
+
+	bw := bufio.NewWriter(w)
+	for i := 0; i < len(lines); {
+		if lines[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// extend the hunk while the next change is close enough to share context
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(lines); j++ {
+			if lines[j].kind != ' ' {
+				end = j
+			} else if j-end > 2*context {
+				break
+			}
+		}
+		end += context + 1
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		var aLen, bLen int
+		for _, ln := range lines[start:end] {
+			if ln.kind != '+' {
+				aLen++
+			}
+			if ln.kind != '-' {
+				bLen++
+			}
+		}
+		fmt.Fprintf(bw, "@@ -%s +%s @@\n", hunkRange(lines[start].ai, aLen), hunkRange(lines[start].bi, bLen))
+		for _, ln := range lines[start:end] {
+			fmt.Fprintf(bw, "%c%v\n", ln.kind, ln.value)
+		}
+		i = end
+	}
+	return bw.Flush()
+}
+
+// hunkRange formats the range of a hunk header, with 1-based line numbers as diff does.
+func hunkRange(start, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if n == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, n)
+}
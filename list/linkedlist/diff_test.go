@@ -0,0 +1,166 @@
+package linkedlist
+
+import (
+	"bytes"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b string
+		d    int
+	}{
+		{"", "", 0},
+		{"abc", "abc", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"abcabba", "cbabac", 5},
+		{"kitten", "sitting", 5},
+		{"abc", "xyz", 6},
+	}
+
+	for _, tt := range tests {
+		a := From([]byte(tt.a)...)
+		b := From([]byte(tt.b)...)
+		edits := Diff(a, b)
+		if len(edits) != tt.d {
+			t.Errorf("Diff(%q, %q) has %d edits, want %d", tt.a, tt.b, len(edits), tt.d)
+		}
+
+		if err := Apply(a, edits); err != nil {
+			t.Errorf("Apply(%q, Diff()) = %v", tt.a, err)
+		}
+		checkList(t, a, []byte(tt.b))
+	}
+}
+
+func TestDiffFunc(t *testing.T) {
+	a := From("A", "b", "C")
+	b := From("a", "B", "d")
+	edits := DiffFunc(a, b, strings.EqualFold)
+	if len(edits) != 2 || edits[0].Op != OpDelete || edits[0].Index != 2 || edits[1].Op != OpInsert || edits[1].Value != "d" {
+		t.Errorf("DiffFunc() = %v, want [{delete 2 C} {insert 2 d}]", edits)
+	}
+}
+
+func TestApplyKeepsCursors(t *testing.T) {
+	a := From(1, 2, 3, 4, 5)
+	b := From(0, 2, 3, 9, 5, 6)
+
+	c2 := cursorAtIndex(a, 1)
+	c5 := cursorAtIndex(a, 4)
+	c4 := cursorAtIndex(a, 3)
+
+	if err := Apply(a, Diff(a, b)); err != nil {
+		t.Fatalf("Apply() = %v", err)
+	}
+	checkList(t, a, []int{0, 2, 3, 9, 5, 6})
+	checkCursor(t, a, []*Cursor[int]{c2, c5})
+
+	if c2.Value() != 2 || c5.Value() != 5 {
+		t.Errorf("cursors = %v, %v after Apply(), want 2, 5", c2.Value(), c5.Value())
+	}
+	if c4.IsValid() {
+		t.Errorf("cursor to a deleted node is still valid")
+	}
+}
+
+func TestApplyOutOfRange(t *testing.T) {
+	l := From(1, 2)
+	bad := [][]Edit[int]{
+		{{Op: OpInsert, Index: 3}},
+		{{Op: OpDelete, Index: 2}},
+		{{Op: OpDelete, Index: -1}},
+	}
+	for _, edits := range bad {
+		if err := Apply(l, edits); err != ErrEditOutOfRange {
+			t.Errorf("Apply(%v) = %v, want ErrEditOutOfRange", edits, err)
+		}
+	}
+	checkList(t, l, []int{1, 2})
+
+	var zero List[int]
+	if err := Apply(&zero, []Edit[int]{{Op: OpInsert, Index: 0, Value: 1}}); err != nil {
+		t.Errorf("Apply() on a zero list = %v", err)
+	}
+	checkList(t, &zero, []int{1})
+}
+
+func TestDiffRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	gen := func() []int {
+		vs := make([]int, r.Intn(30))
+		for i := range vs {
+			vs[i] = r.Intn(4)
+		}
+		return vs
+	}
+
+	for i := 0; i < 200; i++ {
+		av, bv := gen(), gen()
+		a, b := From(av...), From(bv...)
+		edits := Diff(a, b)
+
+		// a minimal script is never longer than deleting everything and inserting everything
+		if len(edits) > len(av)+len(bv) {
+			t.Fatalf("Diff(%v, %v) has %d edits", av, bv, len(edits))
+		}
+		if err := Apply(a, edits); err != nil {
+			t.Fatalf("Apply() = %v", err)
+		}
+		checkList(t, a, bv)
+	}
+}
+
+func TestDiffMemory(t *testing.T) {
+	// long lists with a short script: the trace holds O(D²) values, not O(D(N+M))
+	const n, changes = 20000, 50
+	av := make([]int, n)
+	bv := make([]int, n)
+	for i := range av {
+		av[i], bv[i] = i, i
+	}
+	for i := 0; i < changes; i++ {
+		bv[i*n/changes] = -1
+	}
+	a, b := From(av...), From(bv...)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	edits := Diff(a, b)
+	runtime.ReadMemStats(&after)
+
+	if len(edits) != 2*changes {
+		t.Errorf("Diff() has %d edits, want %d", len(edits), 2*changes)
+	}
+	// the values of both lists and the array of diagonals take about 640KB, a full copy of it per step 32MB
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 8<<20 {
+		t.Errorf("Diff() allocated %d bytes, want at most 8MB", alloc)
+	}
+}
+
+func TestWriteUnified(t *testing.T) {
+	a := From("a", "b", "c", "d", "e", "f", "g", "h", "i", "j")
+	b := From("a", "B", "c", "d", "e", "f", "g", "h", "i", "j", "k")
+
+	var buf bytes.Buffer
+	if err := WriteUnified(&buf, a, Diff(a, b), 1); err != nil {
+		t.Fatalf("WriteUnified() = %v", err)
+	}
+
+	want := "@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n@@ -10 +10,2 @@\n j\n+k\n"
+	if buf.String() != want {
+		t.Errorf("WriteUnified() =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// close changes share a hunk
+	buf.Reset()
+	b = From("a", "X", "c", "Y", "e", "f", "g", "h", "i", "j")
+	WriteUnified(&buf, a, Diff(a, b), 1)
+	if strings.Count(buf.String(), "@@ -") != 1 {
+		t.Errorf("WriteUnified() =\n%s\nwant a single hunk", buf.String())
+	}
+}
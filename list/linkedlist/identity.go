@@ -0,0 +1,43 @@
+package linkedlist
+
+// identity identifies a list. Every node records the identity of the list holding it, which lets Validate and View
+// tell the nodes of a list from the nodes of another one.
+//
+// Splicing a list into another one does not visit the nodes: the identity of the emptied list forwards to the identity
+// of the list receiving its nodes, and the emptied list takes a new identity. The forwarding chains are compressed when
+// they are followed, as in a disjoint-set forest.
+type identity struct {
+	forward *identity
+}
+
+// resolve returns the identity that id forwards to, id itself if it does not forward.
+func (id *identity) resolve() *identity {
+	root := id
+	for root != nil && root.forward != nil {
+		root = root.forward
+	}
+	for id != root {
+		next := id.forward
+		id.forward = root
+		id = next
+	}
+	return root
+}
+
+// ident returns the identity of list l, allocating it if needed.
+func (l *List[T]) ident() *identity {
+	if l.id == nil {
+		l.id = &identity{}
+	}
+	return l.id
+}
+
+// owns reports whether node n belongs to list l.
+// The complexity is O(1) amortized.
+func (l *List[T]) owns(n *Node[T]) bool {
+	if n.owner == nil || l.id == nil {
+		return false
+	}
+	n.owner = n.owner.resolve()
+	return n.owner == l.id
+}
@@ -0,0 +1,349 @@
+package linkedlist
+
+// Hook is the link embedded in a user struct so the struct itself can be linked into an IntrusiveList,
+// without a separate node allocation per value.
+// A struct can embed several hooks to be linked into several lists at the same time, one hook per list.
+//
+//	type Conn struct {
+//		byIdle linkedlist.Hook[Conn]
+//		byHost linkedlist.Hook[Conn]
+//		// ...
+//	}
+//
+//	idle := linkedlist.NewIntrusive(func(c *Conn) *linkedlist.Hook[Conn] { return &c.byIdle })
+//	host := linkedlist.NewIntrusive(func(c *Conn) *linkedlist.Hook[Conn] { return &c.byHost })
+//
+// The zero value of Hook is an unlinked hook.
+type Hook[T any] struct {
+	next, prev *Hook[T]
+
+	list  *IntrusiveList[T]
+	owner *T
+}
+
+// Linked reports whether the hook is currently linked into a list.
+func (h *Hook[T]) Linked() bool {
+	return h.list != nil
+}
+
+// IntrusiveList represents a doubly linked list of user structs that embed a Hook.
+// The list does not own its values, it only links them together through their hooks.
+type IntrusiveList[T any] struct {
+	root Hook[T]
+	len  int
+	hook func(*T) *Hook[T]
+}
+
+// NewIntrusive returns an initialized intrusive list. hook returns the Hook of a value that the list links through.
+func NewIntrusive[T any](hook func(*T) *Hook[T]) *IntrusiveList[T] {
+	l := &IntrusiveList[T]{hook: hook}
+	return l.Init()
+}
+
+// Init initializes or clears list l. Values still linked into l are unlinked.
+// The complexity is O(n).
+func (l *IntrusiveList[T]) Init() *IntrusiveList[T] {
+	for h := l.root.next; h != nil && h != &l.root; {
+		next := h.next
+		h.next, h.prev, h.list, h.owner = nil, nil, nil, nil
+		h = next
+	}
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.len = 0
+	return l
+}
+
+// insert links the hook of v after mark. The value must not be linked into any list.
+func (l *IntrusiveList[T]) insert(v *T, mark *Hook[T]) *Hook[T] {
+	h := l.hook(v)
+	if h.list != nil {
+		panic("linkedlist: value is already linked into a list")
+	}
+
+	h.owner = v
+	h.list = l
+
+	h.prev = mark
+	h.next = mark.next
+	mark.next = h
+	h.next.prev = h
+	l.len++
+	return h
+}
+
+// move moves h to next to at.
+func (l *IntrusiveList[T]) move(h, at *Hook[T]) {
+	if h == at {
+		return
+	}
+	h.prev.next = h.next
+	h.next.prev = h.prev
+
+	h.prev = at
+	h.next = at.next
+	h.prev.next = h
+	h.next.prev = h
+}
+
+// remove unlinks h from the list. The hook must not be nil.
+func (l *IntrusiveList[T]) remove(h *Hook[T]) *T {
+	h.prev.next = h.next
+	h.next.prev = h.prev
+
+	v := h.owner
+	h.next, h.prev, h.list, h.owner = nil, nil, nil, nil // avoid memory leaks
+	l.len--
+	return v
+}
+
+// Len returns the number of values linked into list l. The complexity is O(1).
+func (l *IntrusiveList[T]) Len() int {
+	return l.len
+}
+
+// Front returns the first value of list l. Return nil if the list is empty.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) Front() *T {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next.owner
+}
+
+// Back returns the last value of list l. Return nil if the list is empty.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) Back() *T {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev.owner
+}
+
+// Contains reports whether v is linked into list l.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) Contains(v *T) bool {
+	return l.hook(v).list == l
+}
+
+// PushBack links v at the back of list l. It panics if v is already linked into a list through the same hook.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) PushBack(v *T) {
+	l.insert(v, l.root.prev)
+}
+
+// PushFront links v at the front of list l. It panics if v is already linked into a list through the same hook.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) PushFront(v *T) {
+	l.insert(v, &l.root)
+}
+
+// PopFront unlinks the first value from list l and returns it. Return nil if the list is empty.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) PopFront() *T {
+	if l.len == 0 {
+		return nil
+	}
+	return l.remove(l.root.next)
+}
+
+// PopBack unlinks the last value from list l and returns it. Return nil if the list is empty.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) PopBack() *T {
+	if l.len == 0 {
+		return nil
+	}
+	return l.remove(l.root.prev)
+}
+
+// Remove unlinks v from list l. Return false if v is not linked into l.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) Remove(v *T) bool {
+	h := l.hook(v)
+	if h.list != l {
+		return false
+	}
+	l.remove(h)
+	return true
+}
+
+// InsertBefore links v before the cursor c. cursor c stays at the same position after the insertion.
+// If c is point to the sentinel node, or c is not associated with l or invalid, InsertBefore does nothing and returns false.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) InsertBefore(v *T, c *IntrusiveCursor[T]) bool {
+	if c.list != l || c.current == &l.root || !c.IsValid() {
+		return false
+	}
+	l.insert(v, c.current.prev)
+	return true
+}
+
+// InsertAfter links v after the cursor c. cursor c stays at the same position after the insertion.
+// If c is point to the sentinel node, or c is not associated with l or invalid, InsertAfter does nothing and returns false.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) InsertAfter(v *T, c *IntrusiveCursor[T]) bool {
+	if c.list != l || c.current == &l.root || !c.IsValid() {
+		return false
+	}
+	l.insert(v, c.current)
+	return true
+}
+
+// RemoveAt unlinks the value at the cursor c and returns it. Cursor c move to the next node after the removal.
+// If c is point to the sentinel node, or c is not associated with l or invalid, RemoveAt returns nil.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) RemoveAt(c *IntrusiveCursor[T]) *T {
+	if c.list != l || c.current == &l.root || !c.IsValid() {
+		return nil
+	}
+
+	h := c.current
+	c.current = h.next
+	return l.remove(h)
+}
+
+// MoveToFront moves the value at the cursor c to the front of the list.
+// It does nothing if c is point to the sentinel node, invalid or not associated with l.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) MoveToFront(c *IntrusiveCursor[T]) {
+	if c.list != l || c.current == &l.root || l.root.next == c.current {
+		return
+	}
+
+	if c.IsValid() {
+		l.move(c.current, &l.root)
+	}
+}
+
+// MoveToBack moves the value at the cursor c to the back of the list.
+// It does nothing if c is point to the sentinel node, invalid or not associated with l.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) MoveToBack(c *IntrusiveCursor[T]) {
+	if c.list != l || c.current == &l.root || l.root.prev == c.current {
+		return
+	}
+
+	if c.IsValid() {
+		l.move(c.current, l.root.prev)
+	}
+}
+
+// MoveBefore moves the value at the cursor c to the position before the cursor mark.
+// It does nothing if c is point to the sentinel node, or c or mark are not associated with l.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) MoveBefore(c, mark *IntrusiveCursor[T]) {
+	if c.list != l || mark.list != l || c.current == mark.current || c.current == &l.root {
+		return
+	}
+
+	if c.IsValid() && mark.IsValid() {
+		l.move(c.current, mark.current.prev)
+	}
+}
+
+// MoveAfter moves the value at the cursor c to the position after the cursor mark.
+// It does nothing if c is point to the sentinel node, or c or mark are not associated with l.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) MoveAfter(c, mark *IntrusiveCursor[T]) {
+	if c.list != l || mark.list != l || c.current == mark.current || c.current == &l.root {
+		return
+	}
+
+	if c.IsValid() && mark.IsValid() {
+		l.move(c.current, mark.current)
+	}
+}
+
+// Cursor returns a cursor pointing to the sentinel node of the list.
+func (l *IntrusiveList[T]) Cursor() *IntrusiveCursor[T] {
+	return &IntrusiveCursor[T]{list: l, current: &l.root}
+}
+
+// FrontCursor returns a cursor pointing to the first value of the list.
+func (l *IntrusiveList[T]) FrontCursor() *IntrusiveCursor[T] {
+	return &IntrusiveCursor[T]{list: l, current: l.root.next}
+}
+
+// BackCursor returns a cursor pointing to the last value of the list.
+func (l *IntrusiveList[T]) BackCursor() *IntrusiveCursor[T] {
+	return &IntrusiveCursor[T]{list: l, current: l.root.prev}
+}
+
+// CursorAt returns a cursor pointing to v. Return nil if v is not linked into l.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) CursorAt(v *T) *IntrusiveCursor[T] {
+	h := l.hook(v)
+	if h.list != l {
+		return nil
+	}
+	return &IntrusiveCursor[T]{list: l, current: h}
+}
+
+// IntrusiveCursor points to a value linked into an IntrusiveList. It follows the same contract as Cursor.
+type IntrusiveCursor[T any] struct {
+	list    *IntrusiveList[T]
+	current *Hook[T]
+}
+
+// Equal returns true if the two cursors point to the same value in the same list.
+// if either cursor is not valid, it returns false.
+func (c *IntrusiveCursor[T]) Equal(c2 *IntrusiveCursor[T]) bool {
+	if !c.IsValid() || !c2.IsValid() {
+		return false
+	}
+
+	return c.list == c2.list && c.current == c2.current
+}
+
+// Value returns the value that the cursor points to. Return nil if the cursor points to the sentinel node.
+// If the cursor is not valid, it will panic.
+func (c *IntrusiveCursor[T]) Value() *T {
+	if !c.IsValid() {
+		panic("cursor is not valid when calling Value()")
+	}
+	return c.current.owner
+}
+
+// Clone creates a new cursor that points to the same value as the current cursor.
+// Return nil if the current cursor is not valid.
+func (c *IntrusiveCursor[T]) Clone() *IntrusiveCursor[T] {
+	if c.IsValid() {
+		return &IntrusiveCursor[T]{list: c.list, current: c.current}
+	}
+	return nil
+}
+
+// MoveNext moves the cursor to the next value in the list and return it.
+// Move to sentinel node and return nil if the cursor is pointing to the last value in the list.
+func (c *IntrusiveCursor[T]) MoveNext() *T {
+	c.current = c.current.next
+	return c.current.owner
+}
+
+// MovePrev moves the cursor to the previous value in the list and return it.
+// Move to sentinel node and return nil if the cursor is pointing to the first value in the list.
+func (c *IntrusiveCursor[T]) MovePrev() *T {
+	c.current = c.current.prev
+	return c.current.owner
+}
+
+// Close closes the cursor and release the reference to the list. The cursor can no longer be used.
+func (c *IntrusiveCursor[T]) Close() {
+	c.list = nil
+	c.current = nil
+}
+
+// IsValid detects if the cursor is valid.
+// A cursor is not valid if it is closed, or it is pointing to a value that is no longer linked into the list.
+// If the cursor is not valid, Close() will be called automatically.
+func (c *IntrusiveCursor[T]) IsValid() bool {
+	if c.list == nil || c.current == nil || c.current.next == nil {
+		c.Close()
+		return false
+	}
+	if c.current != &c.list.root && c.current.list != c.list {
+		c.Close()
+		return false
+	}
+	return true
+}
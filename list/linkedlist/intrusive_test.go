@@ -0,0 +1,188 @@
+package linkedlist
+
+import "testing"
+
+type conn struct {
+	id int
+
+	byIdle Hook[conn]
+	byHost Hook[conn]
+}
+
+func idleHook(c *conn) *Hook[conn] { return &c.byIdle }
+func hostHook(c *conn) *Hook[conn] { return &c.byHost }
+
+func checkIntrusiveList(t *testing.T, l *IntrusiveList[conn], ids []int) {
+	if l.Len() != len(ids) {
+		t.Errorf("l.Len() = %d, want %d", l.Len(), len(ids))
+		return
+	}
+
+	i := 0
+	for h := l.root.next; h != &l.root; h = h.next {
+		if h.list != l {
+			t.Errorf("elt[%d].list = %p, want %p", i, h.list, l)
+		}
+		if h.next.prev != h || h.prev.next != h {
+			t.Errorf("elt[%d] has broken prev/next links", i)
+		}
+		if h.owner.id != ids[i] {
+			t.Errorf("elt[%d].id = %d, want %d", i, h.owner.id, ids[i])
+		}
+		i++
+	}
+}
+
+func TestIntrusiveList(t *testing.T) {
+	l := NewIntrusive(idleHook)
+	checkIntrusiveList(t, l, []int{})
+
+	if l.Front() != nil || l.Back() != nil {
+		t.Errorf("l.Front(), l.Back() = %v, %v, want nil, nil", l.Front(), l.Back())
+	}
+
+	c1, c2, c3 := &conn{id: 1}, &conn{id: 2}, &conn{id: 3}
+	l.PushBack(c2)
+	l.PushFront(c1)
+	l.PushBack(c3)
+	checkIntrusiveList(t, l, []int{1, 2, 3})
+
+	if l.Front() != c1 || l.Back() != c3 {
+		t.Errorf("l.Front(), l.Back() = %v, %v, want %v, %v", l.Front(), l.Back(), c1, c3)
+	}
+
+	if !l.Contains(c2) || !c2.byIdle.Linked() || c2.byHost.Linked() {
+		t.Errorf("c2 hooks linked = %v, %v, want true, false", c2.byIdle.Linked(), c2.byHost.Linked())
+	}
+
+	if !l.Remove(c2) {
+		t.Errorf("l.Remove(c2) = false, want true")
+	}
+	checkIntrusiveList(t, l, []int{1, 3})
+
+	if l.Remove(c2) {
+		t.Errorf("l.Remove(c2) = true, want false")
+	}
+
+	if v := l.PopFront(); v != c1 {
+		t.Errorf("l.PopFront() = %v, want %v", v, c1)
+	}
+	if v := l.PopBack(); v != c3 {
+		t.Errorf("l.PopBack() = %v, want %v", v, c3)
+	}
+	if v := l.PopBack(); v != nil {
+		t.Errorf("l.PopBack() = %v, want nil", v)
+	}
+	checkIntrusiveList(t, l, []int{})
+}
+
+func TestIntrusiveListMultipleLists(t *testing.T) {
+	idle := NewIntrusive(idleHook)
+	host := NewIntrusive(hostHook)
+
+	conns := []*conn{{id: 1}, {id: 2}, {id: 3}}
+	for _, c := range conns {
+		idle.PushBack(c)
+		host.PushFront(c)
+	}
+	checkIntrusiveList(t, idle, []int{1, 2, 3})
+	checkIntrusiveList(t, host, []int{3, 2, 1})
+
+	idle.Remove(conns[1])
+	checkIntrusiveList(t, idle, []int{1, 3})
+	checkIntrusiveList(t, host, []int{3, 2, 1})
+
+	c := host.CursorAt(conns[0])
+	host.MoveToFront(c)
+	checkIntrusiveList(t, host, []int{1, 3, 2})
+	checkIntrusiveList(t, idle, []int{1, 3})
+
+	if idle.CursorAt(conns[1]) != nil {
+		t.Errorf("idle.CursorAt(conns[1]) = non-nil, want nil")
+	}
+
+	idle.Init()
+	checkIntrusiveList(t, idle, []int{})
+	if conns[0].byIdle.Linked() {
+		t.Errorf("conns[0].byIdle.Linked() = true after Init, want false")
+	}
+	checkIntrusiveList(t, host, []int{1, 3, 2})
+}
+
+func TestIntrusiveListDoubleLink(t *testing.T) {
+	l1 := NewIntrusive(idleHook)
+	l2 := NewIntrusive(idleHook)
+	c := &conn{id: 1}
+	l1.PushBack(c)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("l2.PushBack() of a linked value did not panic")
+		}
+		checkIntrusiveList(t, l1, []int{1})
+		checkIntrusiveList(t, l2, []int{})
+	}()
+	l2.PushBack(c)
+}
+
+func TestIntrusiveCursor(t *testing.T) {
+	l := NewIntrusive(idleHook)
+	c1, c2, c3, c4 := &conn{id: 1}, &conn{id: 2}, &conn{id: 3}, &conn{id: 4}
+	l.PushBack(c2)
+
+	cur := l.FrontCursor()
+	if !l.InsertBefore(c1, cur) || !l.InsertAfter(c3, cur) {
+		t.Errorf("InsertBefore/InsertAfter at a valid cursor returned false")
+	}
+	checkIntrusiveList(t, l, []int{1, 2, 3})
+
+	if l.InsertBefore(c4, l.Cursor()) {
+		t.Errorf("InsertBefore at the sentinel returned true")
+	}
+	if c4.byIdle.Linked() {
+		t.Errorf("c4 is linked after a failed insert")
+	}
+
+	var ids []int
+	walker := l.Cursor()
+	for v := walker.MoveNext(); v != nil; v = walker.MoveNext() {
+		ids = append(ids, v.id)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+		t.Errorf("walk = %v, want [1 2 3]", ids)
+	}
+
+	back := l.BackCursor()
+	l.MoveToFront(back)
+	checkIntrusiveList(t, l, []int{3, 1, 2})
+	l.MoveToBack(back)
+	checkIntrusiveList(t, l, []int{1, 2, 3})
+
+	front := l.FrontCursor()
+	l.MoveAfter(front, back)
+	checkIntrusiveList(t, l, []int{2, 3, 1})
+	l.MoveBefore(front, cur)
+	checkIntrusiveList(t, l, []int{1, 2, 3})
+
+	stale := cur.Clone()
+	if v := l.RemoveAt(cur); v != c2 {
+		t.Errorf("l.RemoveAt() = %v, want %v", v, c2)
+	}
+	checkIntrusiveList(t, l, []int{1, 3})
+
+	if cur.Value() != c3 {
+		t.Errorf("cursor after RemoveAt points to %v, want %v", cur.Value(), c3)
+	}
+	if stale.IsValid() {
+		t.Errorf("cursor to a removed value is still valid")
+	}
+
+	// relinking the value into another list must not revive the stale cursor
+	stale = l.CursorAt(c3)
+	l.Remove(c3)
+	other := NewIntrusive(idleHook)
+	other.PushBack(c3)
+	if stale.IsValid() {
+		t.Errorf("cursor to a value moved to another list is still valid")
+	}
+}
@@ -13,27 +13,34 @@
 //
 //	cursor := l.Cursor() // create a cursor point to the sentinel node
 //	for cursor.MoveNext() != nil {
-//		n := cursor.node()
+//		n := cursor.Node()
 //		// do something with n
 //	}
+//
+// Built with the skaledebug tag, the package runs List.Validate after every mutation and panics with a dump of the
+// list as soon as it is broken, for example by a cursor used after its node moved to another list.
 package linkedlist
 
-// node is a node in a doubly linked list
-type node[T any] struct {
-	next, prev *node[T]
+import "math/rand"
+
+// Node is a node in a doubly linked list
+type Node[T any] struct {
+	next, prev *Node[T]
+	owner      *identity // the identity of the list of the node, nil once removed
 
-	value T
+	Value T
 }
 
-// Newnode creates a new node with the given value
-func newNode[T any](value T) *node[T] {
-	return &node[T]{value: value}
+// newNode creates a new node with the given value
+func newNode[T any](value T) *Node[T] {
+	return &Node[T]{Value: value}
 }
 
 // List represents a doubly linked list.
 type List[T any] struct {
-	root node[T]
+	root Node[T]
 	len  int
+	id   *identity // stamped on the nodes of the list, allocated by the first insertion
 }
 
 // New returns an initialized list.
@@ -50,6 +57,7 @@ func (l *List[T]) Init() *List[T] {
 	l.root.next = &l.root
 	l.root.prev = &l.root
 	l.len = 0
+	l.id = nil // the nodes of a cleared list belong to no list
 	return l
 }
 
@@ -61,7 +69,7 @@ func (l *List[T]) lazyInit() {
 }
 
 // first returns the first node in the list
-func (l *List[T]) front() *node[T] {
+func (l *List[T]) front() *Node[T] {
 	if l.len == 0 {
 		return nil
 	}
@@ -69,7 +77,7 @@ func (l *List[T]) front() *node[T] {
 }
 
 // last returns the last node in the list
-func (l *List[T]) back() *node[T] {
+func (l *List[T]) back() *Node[T] {
 	if l.len == 0 {
 		return nil
 	}
@@ -77,7 +85,7 @@ func (l *List[T]) back() *node[T] {
 }
 
 // insert inserts a node after mark. The mask must not be nil.
-func (l *List[T]) insert(n, mark *node[T]) *node[T] {
+func (l *List[T]) insert(n, mark *Node[T]) *Node[T] {
 
 	//n after mark, n before mark.next
 	n.prev = mark
@@ -89,16 +97,18 @@ func (l *List[T]) insert(n, mark *node[T]) *node[T] {
 	//n before mark.next
 	n.next.prev = n
 	l.len++
+	n.owner = l.ident()
+	l.debugValidate("insert")
 	return n
 }
 
-// insertValue is a convenience wrapper for insert(&node{Value: v}, at)
-func (l *List[T]) insertValue(v T, mark *node[T]) *node[T] {
+// insertValue is a convenience wrapper for insert(&Node{Value: v}, at)
+func (l *List[T]) insertValue(v T, mark *Node[T]) *Node[T] {
 	return l.insert(newNode(v), mark)
 }
 
 // move moves e to next to at.
-func (l *List[T]) move(e, at *node[T]) {
+func (l *List[T]) move(e, at *Node[T]) {
 	if e == at {
 		return
 	}
@@ -109,10 +119,11 @@ func (l *List[T]) move(e, at *node[T]) {
 	e.next = at.next
 	e.prev.next = e
 	e.next.prev = e
+	l.debugValidate("move")
 }
 
 // remove removes n from the list. The node must not be nil.
-func (l *List[T]) remove(n *node[T]) *node[T] {
+func (l *List[T]) remove(n *Node[T]) *Node[T] {
 
 	//node before n is now before n.next
 	n.prev.next = n.next
@@ -122,6 +133,8 @@ func (l *List[T]) remove(n *node[T]) *node[T] {
 	n.next = nil // avoid memory leaks
 	n.prev = nil // avoid memory leaks
 	l.len--
+	n.owner = nil
+	l.debugValidate("remove")
 	return n
 }
 
@@ -141,13 +154,13 @@ func (l *List[T]) Len() int {
 
 // Front returns the first element of list l. Return nil if the list is empty.
 // The complexity is O(1).
-func (l *List[T]) Front() *node[T] {
+func (l *List[T]) Front() *Node[T] {
 	return l.front()
 }
 
 // Back returns the last element of list l. Return nil if the list is empty.
 // The complexity is O(1).
-func (l *List[T]) Back() *node[T] {
+func (l *List[T]) Back() *Node[T] {
 	return l.back()
 }
 
@@ -185,7 +198,7 @@ func (l *List[T]) PushFrontBulk(values ...T) {
 
 // PopFront removes the first element (front) from list l and returns it. Return nil if the list is empty.
 // The complexity is O(1).
-func (l *List[T]) PopFront() *node[T] {
+func (l *List[T]) PopFront() *Node[T] {
 	l.lazyInit()
 
 	n := l.front()
@@ -198,7 +211,7 @@ func (l *List[T]) PopFront() *node[T] {
 
 // PopBack removes the last element (back) from list l and returns it. Return nil if the list is empty.
 // The complexity is O(1).
-func (l *List[T]) PopBack() *node[T] {
+func (l *List[T]) PopBack() *Node[T] {
 	l.lazyInit()
 
 	if l.len == 0 {
@@ -217,7 +230,7 @@ func (l *List[T]) PopBack() *node[T] {
 // If c is point to the sentinel node, InsertBefore inserts to the tail (same effect as PushBack).
 // If c is not associated with l, InsertBefore returns nil.
 // The complexity is O(1).
-func (l *List[T]) InsertBefore(v T, c *Cursor[T]) *node[T] {
+func (l *List[T]) InsertBefore(v T, c *Cursor[T]) *Node[T] {
 	if c.list != l || c.current == &c.list.root {
 		return nil
 	}
@@ -232,7 +245,7 @@ func (l *List[T]) InsertBefore(v T, c *Cursor[T]) *node[T] {
 // If c is point to the sentinel node, InsertAfter inserts to the head (same effect as PushFront).
 // If c is not associated with l or invalid, InsertAfter returns nil.
 // The complexity is O(1).
-func (l *List[T]) InsertAfter(v T, c *Cursor[T]) *node[T] {
+func (l *List[T]) InsertAfter(v T, c *Cursor[T]) *Node[T] {
 	if c.list != l || c.current == &c.list.root {
 		return nil
 	}
@@ -245,7 +258,7 @@ func (l *List[T]) InsertAfter(v T, c *Cursor[T]) *node[T] {
 
 // RemoveAt removes the node at the cursor c, return the removed node. Cursor c move to the next node after the removal.
 // If c is point to the sentinel node, RemoveAt returns nil.
-func (l *List[T]) RemoveAt(c *Cursor[T]) *node[T] {
+func (l *List[T]) RemoveAt(c *Cursor[T]) *Node[T] {
 
 	if c.list != l || c.current == &c.list.root {
 		return nil
@@ -265,7 +278,7 @@ func (l *List[T]) RemoveAt(c *Cursor[T]) *node[T] {
 // If c is point to the sentinel node, RemoveAfter removes the first element of the list (same effect as RemoveFront).
 // If c is not associated with l, RemoveAfter returns nil.
 // The complexity is O(1).
-func (l *List[T]) RemoveAfter(c *Cursor[T]) *node[T] {
+func (l *List[T]) RemoveAfter(c *Cursor[T]) *Node[T] {
 	if c.list != l || l.root.prev == c.current {
 		return nil
 	}
@@ -282,7 +295,7 @@ func (l *List[T]) RemoveAfter(c *Cursor[T]) *node[T] {
 // If c is point to the sentinel node, RemoveBefore removes the last element of the list (same effect as RemoveBack).
 // If c is not associated with l, RemoveBefore returns nil.
 // The complexity is O(1).
-func (l *List[T]) RemoveBefore(c *Cursor[T]) *node[T] {
+func (l *List[T]) RemoveBefore(c *Cursor[T]) *Node[T] {
 
 	if c.list != l || l.root.next == c.current {
 		return nil
@@ -326,7 +339,7 @@ func (l *List[T]) MoveToBack(c *Cursor[T]) {
 // It does nothing if c is point to the sentinel node, or c or mark are not associated with l.
 // The complexity is O(1).
 func (l *List[T]) MoveBefore(c, mark *Cursor[T]) {
-	if c.list != l || c.current == mark.current || mark.list != l {
+	if c.list != l || c.current == mark.current || mark.list != l || c.current == &l.root {
 		return
 	}
 
@@ -340,7 +353,7 @@ func (l *List[T]) MoveBefore(c, mark *Cursor[T]) {
 // It does nothing if c is point to the sentinel node, or c or mark are not associated with l.
 // The complexity is O(1).
 func (l *List[T]) MoveAfter(c, mark *Cursor[T]) {
-	if c.list != l || c.current == mark.current || mark.list != l {
+	if c.list != l || c.current == mark.current || mark.list != l || c.current == &l.root {
 		return
 	}
 
@@ -349,6 +362,44 @@ func (l *List[T]) MoveAfter(c, mark *Cursor[T]) {
 	}
 }
 
+// AdoptFront moves the node at the cursor c, which may belong to another list, to the front of list l.
+// The node is relinked, not copied, and c follows it into l. Other cursors pointing to the node are still associated
+// with its previous list and must not be used.
+// It does nothing if c is point to the sentinel node or invalid.
+// The complexity is O(1).
+func (l *List[T]) AdoptFront(c *Cursor[T]) {
+	l.adopt(c, nil)
+}
+
+// AdoptBack moves the node at the cursor c, which may belong to another list, to the back of list l.
+// The node is relinked, not copied, and c follows it into l. Other cursors pointing to the node are still associated
+// with its previous list and must not be used.
+// It does nothing if c is point to the sentinel node or invalid.
+// The complexity is O(1).
+func (l *List[T]) AdoptBack(c *Cursor[T]) {
+	l.lazyInit()
+	l.adopt(c, l.root.prev)
+}
+
+// adopt moves the node at the cursor c after mark, which is the sentinel of l if nil.
+func (l *List[T]) adopt(c *Cursor[T], mark *Node[T]) {
+	if !c.IsValid() || c.current == &c.list.root {
+		return
+	}
+	l.lazyInit()
+	if mark == nil {
+		mark = &l.root
+	}
+	if c.list == l {
+		l.move(c.current, mark)
+		return
+	}
+
+	n := c.list.remove(c.current)
+	l.insert(n, mark)
+	c.list = l
+}
+
 // Cursor returns a cursor pointing to the sentinel node of the list.
 func (l *List[T]) Cursor() *Cursor[T] {
 	l.lazyInit()
@@ -365,13 +416,25 @@ func (l *List[T]) BackCursor() *Cursor[T] {
 	return &Cursor[T]{list: l, current: l.root.prev}
 }
 
+// WalkAscending calls f on every node from the front to the back of the list, until f returns false.
+// f must not remove nodes from the list.
+func (l *List[T]) WalkAscending(f func(n *Node[T]) bool) {
+	l.Cursor().WalkAscending(f)
+}
+
+// WalkDescending calls f on every node from the back to the front of the list, until f returns false.
+// f must not remove nodes from the list.
+func (l *List[T]) WalkDescending(f func(n *Node[T]) bool) {
+	l.Cursor().WalkDescending(f)
+}
+
 // PushBackList inserts a copy of an `other` list at the back of `l`.
 func (l *List[T]) PushBackList(other *List[T]) {
 	l.lazyInit()
-	back := other.BackCursor().node()
+	back := other.BackCursor().Node()
 
-	other.Cursor().WalkAscending(func(n *node[T]) bool {
-		l.insertValue(n.value, l.root.prev)
+	other.Cursor().WalkAscending(func(n *Node[T]) bool {
+		l.insertValue(n.Value, l.root.prev)
 		if n == back {
 			return false
 		}
@@ -382,14 +445,165 @@ func (l *List[T]) PushBackList(other *List[T]) {
 // PushFrontList inserts a copy of an `other` list at the front of `l`.
 func (l *List[T]) PushFrontList(other *List[T]) {
 	l.lazyInit()
-	front := other.FrontCursor().node()
+	front := other.FrontCursor().Node()
 
-	other.Cursor().WalkDescending(func(n *node[T]) bool {
+	other.Cursor().WalkDescending(func(n *Node[T]) bool {
 
-		l.insertValue(n.value, &l.root)
+		l.insertValue(n.Value, &l.root)
 		if n == front {
 			return false
 		}
 		return true
 	})
 }
+
+// SpliceBackList moves the elements of an `other` list to the back of `l`, leaving `other` empty.
+// The nodes are relinked, not copied. Cursors pointing to them are still associated with `other` and must not be used.
+// It does nothing if other is l.
+// The complexity is O(1).
+func (l *List[T]) SpliceBackList(other *List[T]) {
+	l.lazyInit()
+	l.splice(other, l.root.prev)
+}
+
+// SpliceFrontList moves the elements of an `other` list to the front of `l`, leaving `other` empty.
+// The nodes are relinked, not copied. Cursors pointing to them are still associated with `other` and must not be used.
+// It does nothing if other is l.
+// The complexity is O(1).
+func (l *List[T]) SpliceFrontList(other *List[T]) {
+	l.lazyInit()
+	l.splice(other, &l.root)
+}
+
+// splice moves the nodes of other after mark.
+func (l *List[T]) splice(other *List[T], mark *Node[T]) {
+	if other == l || other.len == 0 {
+		return
+	}
+	first, last := other.root.next, other.root.prev
+	other.id.forward, other.id = l.ident(), nil // the nodes of other now resolve to l
+
+	first.prev = mark
+	last.next = mark.next
+	mark.next.prev = last
+	mark.next = first
+	l.len += other.len
+
+	other.root.next = &other.root
+	other.root.prev = &other.root
+	other.len = 0
+	l.debugValidate("SpliceList")
+	other.debugValidate("SpliceList")
+}
+
+// Reverse reverses the order of the elements of list l in place by swapping the links of every node.
+// Cursors keep pointing to the same elements.
+// The complexity is O(n).
+func (l *List[T]) Reverse() {
+	l.lazyInit()
+	n := &l.root
+	for {
+		n.next, n.prev = n.prev, n.next
+		n = n.prev // the old next
+		if n == &l.root {
+			l.debugValidate("Reverse")
+			return
+		}
+	}
+}
+
+// Rotate rotates list l to the left by k positions: the first k elements are moved, in order, to the back of the list.
+// A negative k rotates to the right. Only the sentinel node is relinked and cursors keep pointing to the same elements.
+// The complexity is O(min(k, n-k)).
+func (l *List[T]) Rotate(k int) {
+	if l.len == 0 {
+		return
+	}
+	k %= l.len
+	if k < 0 {
+		k += l.len
+	}
+	if k == 0 {
+		return
+	}
+
+	// find the node that becomes the last one, walking from the closest end
+	mark := &l.root
+	if k <= l.len/2 {
+		for i := 0; i < k; i++ {
+			mark = mark.next
+		}
+	} else {
+		for i := 0; i <= l.len-k; i++ {
+			mark = mark.prev
+		}
+	}
+
+	// unlink the sentinel and relink it after mark
+	root := &l.root
+	root.prev.next = root.next
+	root.next.prev = root.prev
+
+	root.prev = mark
+	root.next = mark.next
+	mark.next.prev = root
+	mark.next = root
+	l.debugValidate("Rotate")
+}
+
+// Swap exchanges the positions of the nodes at the cursors c1 and c2. Values are not copied, so both cursors keep
+// pointing to their elements, now at each other's position.
+// It does nothing if either cursor is point to the sentinel node, invalid or not associated with l.
+// The complexity is O(1).
+func (l *List[T]) Swap(c1, c2 *Cursor[T]) {
+	if c1.list != l || c2.list != l || c1.current == &l.root || c2.current == &l.root {
+		return
+	}
+	if !c1.IsValid() || !c2.IsValid() {
+		return
+	}
+
+	a, b := c1.current, c2.current
+	switch {
+	case a == b:
+	case a.next == b:
+		l.move(a, b)
+	case b.next == a:
+		l.move(b, a)
+	default:
+		prev := a.prev
+		l.move(a, b)
+		l.move(b, prev)
+	}
+}
+
+// Shuffle randomly reorders the elements of list l with the Fisher-Yates algorithm, using r as the source of randomness.
+// If r is nil, the default source of math/rand is used. Nodes are relinked, so cursors keep pointing to the same elements.
+// The complexity is O(n).
+func (l *List[T]) Shuffle(r *rand.Rand) {
+	if l.len < 2 {
+		return
+	}
+
+	nodes := make([]*Node[T], 0, l.len)
+	for n := l.root.next; n != &l.root; n = n.next {
+		nodes = append(nodes, n)
+	}
+
+	swap := func(i, j int) { nodes[i], nodes[j] = nodes[j], nodes[i] }
+	if r != nil {
+		r.Shuffle(len(nodes), swap)
+	} else {
+		rand.Shuffle(len(nodes), swap)
+	}
+
+	prev := &l.root
+	for _, n := range nodes {
+		prev.next = n
+		n.prev = prev
+		prev = n
+	}
+	prev.next = &l.root
+	l.root.prev = prev
+	l.debugValidate("Shuffle")
+}
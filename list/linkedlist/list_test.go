@@ -2,6 +2,7 @@ package linkedlist
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 )
 
@@ -72,7 +73,7 @@ func checkListPointers[T any](t *testing.T, l *List[T], es []*Node[T]) {
 }
 
 func checkList[T comparable](t *testing.T, l *List[T], es []T) {
-	if !checkListLen(t, l, len(es)) {
+	if !checkListLen(t, l, len(es)) || len(es) == 0 {
 		return
 	}
 
@@ -277,6 +278,36 @@ func TestExtendingV2(t *testing.T) {
 	checkList(t, l1, []int{1, 2, 3})
 }
 
+func TestSpliceList(t *testing.T) {
+	l1 := New[int]()
+	l1.PushBackBulk(1, 2, 3)
+	l2 := New[int]()
+	l2.PushBackBulk(4, 5)
+
+	l1.SpliceBackList(l2)
+	checkList(t, l1, []int{1, 2, 3, 4, 5})
+	checkList(t, l2, []int{})
+
+	l2.PushBack(6)
+	checkList(t, l2, []int{6})
+	l2.SpliceFrontList(l1)
+	checkList(t, l2, []int{1, 2, 3, 4, 5, 6})
+	checkList(t, l1, []int{})
+
+	l2.SpliceBackList(l1)
+	checkList(t, l2, []int{1, 2, 3, 4, 5, 6})
+	l2.SpliceBackList(l2)
+	checkList(t, l2, []int{1, 2, 3, 4, 5, 6})
+
+	var l3 List[int]
+	l3.SpliceFrontList(l2)
+	checkList(t, &l3, []int{1, 2, 3, 4, 5, 6})
+	checkList(t, l2, []int{})
+	if err := l3.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
 func TestIssue4103(t *testing.T) {
 	l1 := New[int]()
 	l1.PushBack(1)
@@ -428,6 +459,25 @@ func TestMoveUnknownMark(t *testing.T) {
 	checkList(t, &l2, []int{2})
 }
 
+// Test that a linked list l is not rotated when calling MoveAfter or MoveBefore with a cursor at the sentinel node.
+func TestMoveSentinel(t *testing.T) {
+	l := From(1, 2, 3)
+	root := l.Cursor()
+	mark := l.FrontCursor()
+	mark.MoveNext()
+
+	l.MoveAfter(root, mark)
+	checkList(t, l, []int{1, 2, 3})
+	l.MoveBefore(root, mark)
+	checkList(t, l, []int{1, 2, 3})
+
+	// the sentinel node is a valid mark: before it is the back, after it is the front
+	l.MoveBefore(mark, root)
+	checkList(t, l, []int{1, 3, 2})
+	l.MoveAfter(mark, root)
+	checkList(t, l, []int{2, 1, 3})
+}
+
 func TestPopFront(t *testing.T) {
 	var l = &List[int]{}
 
@@ -494,3 +544,152 @@ func TestPopBack(t *testing.T) {
 		t.Errorf("PopBack() = %v, want nil", n)
 	}
 }
+
+func TestReverse(t *testing.T) {
+	l := From(1, 2, 3, 4)
+	c1 := l.FrontCursor()
+	c4 := l.BackCursor()
+	es := []*Node[int]{c4.Node(), c4.ClonePrev().Node(), c1.CloneNext().Node(), c1.Node()}
+
+	l.Reverse()
+	checkListPointers(t, l, es)
+	checkList(t, l, []int{4, 3, 2, 1})
+
+	if c1.Value() != 1 || c4.Value() != 4 {
+		t.Errorf("cursors = %v, %v after Reverse(), want 1, 4", c1.Value(), c4.Value())
+	}
+
+	var zero List[int]
+	zero.Reverse()
+	checkListPointers(t, &zero, []*Node[int]{})
+
+	l = From(1)
+	l.Reverse()
+	checkList(t, l, []int{1})
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		k    int
+		want []int
+	}{
+		{0, []int{1, 2, 3, 4, 5}},
+		{1, []int{2, 3, 4, 5, 1}},
+		{2, []int{3, 4, 5, 1, 2}},
+		{4, []int{5, 1, 2, 3, 4}},
+		{5, []int{1, 2, 3, 4, 5}},
+		{7, []int{3, 4, 5, 1, 2}},
+		{-1, []int{5, 1, 2, 3, 4}},
+		{-6, []int{5, 1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		l := From(1, 2, 3, 4, 5)
+		c := l.FrontCursor()
+		l.Rotate(tt.k)
+		checkList(t, l, tt.want)
+
+		var es []*Node[int]
+		for n := l.root.next; n != &l.root; n = n.next {
+			es = append(es, n)
+		}
+		checkListPointers(t, l, es)
+
+		if c.Value() != 1 {
+			t.Errorf("Rotate(%d): cursor = %v, want 1", tt.k, c.Value())
+		}
+	}
+
+	l := New[int]()
+	l.Rotate(3)
+	checkListPointers(t, l, []*Node[int]{})
+}
+
+func TestSwap(t *testing.T) {
+	l := From(1, 2, 3, 4, 5)
+	cursors := make([]*Cursor[int], 0, 5)
+	for c := l.FrontCursor(); c.Node() != nil; c = c.CloneNext() {
+		cursors = append(cursors, c)
+	}
+	e := func(i int) *Node[int] { return cursors[i].Node() }
+
+	l.Swap(cursors[0], cursors[4]) // ends
+	checkListPointers(t, l, []*Node[int]{e(4), e(1), e(2), e(3), e(0)})
+
+	l.Swap(cursors[1], cursors[2]) // adjacent
+	checkListPointers(t, l, []*Node[int]{e(4), e(2), e(1), e(3), e(0)})
+
+	l.Swap(cursors[3], cursors[1]) // adjacent, reversed order
+	checkListPointers(t, l, []*Node[int]{e(4), e(2), e(3), e(1), e(0)})
+
+	l.Swap(cursors[2], cursors[2]) // no-op
+	l.Swap(cursors[2], l.Cursor()) // sentinel: no-op
+	l.Swap(cursors[2], From(9).FrontCursor())
+	checkListPointers(t, l, []*Node[int]{e(4), e(2), e(3), e(1), e(0)})
+	checkList(t, l, []int{5, 3, 4, 2, 1})
+
+	for i, c := range cursors {
+		if c.Value() != i+1 {
+			t.Errorf("cursors[%d] = %v, want %d", i, c.Value(), i+1)
+		}
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	l := From(1, 2, 3, 4, 5, 6, 7, 8)
+	c := l.FrontCursor()
+	l.Shuffle(rand.New(rand.NewSource(1)))
+
+	seen := make(map[int]bool)
+	var es []*Node[int]
+	for n := l.root.next; n != &l.root; n = n.next {
+		seen[n.Value] = true
+		es = append(es, n)
+	}
+	checkListPointers(t, l, es)
+
+	if len(seen) != 8 {
+		t.Errorf("Shuffle() lost elements: %v", seen)
+	}
+	if c.Value() != 1 {
+		t.Errorf("cursor = %v after Shuffle(), want 1", c.Value())
+	}
+
+	l = From(1)
+	l.Shuffle(nil)
+	checkList(t, l, []int{1})
+}
+
+func TestAdopt(t *testing.T) {
+	l1 := From(1, 2, 3)
+	l2 := From(4, 5)
+	e4, e5 := l2.FrontCursor().Node(), l2.BackCursor().Node()
+
+	c := l1.FrontCursor()
+	c.MoveNext()
+	e1, e2, e3 := l1.Front(), c.Node(), l1.Back()
+
+	l2.AdoptFront(c)
+	checkListPointers(t, l1, []*Node[int]{e1, e3})
+	checkListPointers(t, l2, []*Node[int]{e2, e4, e5})
+	checkCursor(t, l2, []*Cursor[int]{c})
+
+	l1.AdoptBack(c)
+	checkListPointers(t, l1, []*Node[int]{e1, e3, e2})
+	checkListPointers(t, l2, []*Node[int]{e4, e5})
+	checkCursor(t, l1, []*Cursor[int]{c})
+
+	// adopting a node of the same list moves it
+	l1.AdoptFront(c)
+	checkListPointers(t, l1, []*Node[int]{e2, e1, e3})
+
+	// sentinel cursors are ignored
+	l2.AdoptFront(l1.Cursor())
+
+	// a zero list can adopt nodes
+	var zero List[int]
+	zero.AdoptBack(l1.BackCursor())
+	checkListPointers(t, &zero, []*Node[int]{e3})
+	checkListPointers(t, l1, []*Node[int]{e2, e1})
+	checkListPointers(t, l2, []*Node[int]{e4, e5})
+}
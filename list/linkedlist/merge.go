@@ -0,0 +1,144 @@
+package linkedlist
+
+import "container/heap"
+
+// mergeSource is a heap entry of a k-way merge: a cursor pointing to the next candidate node of one input.
+type mergeSource[T any] struct {
+	c   *Cursor[T]
+	src int // index of the input, used to keep the merge stable
+}
+
+// mergeHeap is a min-heap of merge sources ordered by the value of their current node.
+type mergeHeap[T any] struct {
+	cmp     func(a, b T) int
+	sources []mergeSource[T]
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.sources) }
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	a, b := h.sources[i], h.sources[j]
+	if r := h.cmp(a.c.current.Value, b.c.current.Value); r != 0 {
+		return r < 0
+	}
+	return a.src < b.src
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) { h.sources[i], h.sources[j] = h.sources[j], h.sources[i] }
+
+func (h *mergeHeap[T]) Push(x any) { h.sources = append(h.sources, x.(mergeSource[T])) }
+
+func (h *mergeHeap[T]) Pop() any {
+	old := h.sources
+	x := old[len(old)-1]
+	old[len(old)-1] = mergeSource[T]{}
+	h.sources = old[:len(old)-1]
+	return x
+}
+
+// newMergeHeap builds a heap from cursors pointing to the first candidate node of every input.
+// Cursors that are invalid or point to the sentinel node are skipped.
+func newMergeHeap[T any](cmp func(a, b T) int, cursors []*Cursor[T]) *mergeHeap[T] {
+	h := &mergeHeap[T]{cmp: cmp, sources: make([]mergeSource[T], 0, len(cursors))}
+	for i, c := range cursors {
+		if c.IsValid() && c.current != &c.list.root {
+			h.sources = append(h.sources, mergeSource[T]{c: c, src: i})
+		}
+	}
+	heap.Init(h)
+	return h
+}
+
+// next returns the smallest candidate node and advances its cursor. Return nil if every input is exhausted.
+// If detach is true, the node is removed from its input list.
+func (h *mergeHeap[T]) next(detach bool) *Node[T] {
+	if len(h.sources) == 0 {
+		return nil
+	}
+
+	c := h.sources[0].c
+	n := c.current
+	c.current = n.next
+	if detach {
+		c.list.remove(n)
+	}
+
+	if c.current == &c.list.root {
+		heap.Pop(h)
+	} else {
+		heap.Fix(h, 0)
+	}
+	return n
+}
+
+// headCursors returns a cursor pointing to the front of every list.
+func headCursors[T any](lists []*List[T]) []*Cursor[T] {
+	cursors := make([]*Cursor[T], len(lists))
+	for i, l := range lists {
+		l.lazyInit()
+		cursors[i] = l.FrontCursor()
+	}
+	return cursors
+}
+
+// Merge merges sorted lists into a new sorted list by relinking their nodes, no node is allocated or copied.
+// The input lists are left empty. Equal values keep their relative order, values from earlier lists first.
+// Cursors pointing into the inputs must not be used after the merge.
+// The complexity is O(N log k), where N is the total number of elements and k the number of lists.
+func Merge[T any](cmp func(a, b T) int, lists ...*List[T]) *List[T] {
+	out := New[T]()
+	h := newMergeHeap(cmp, headCursors(lists))
+	for n := h.next(true); n != nil; n = h.next(true) {
+		out.insert(n, out.root.prev)
+	}
+	return out
+}
+
+// MergeDedupe is like Merge but keeps only the first of every run of equal values, which merges set-like inputs into a set.
+// The dropped nodes are removed from their lists, so the input lists are left empty.
+// The complexity is O(N log k).
+func MergeDedupe[T any](cmp func(a, b T) int, lists ...*List[T]) *List[T] {
+	out := New[T]()
+	h := newMergeHeap(cmp, headCursors(lists))
+	for n := h.next(true); n != nil; n = h.next(true) {
+		if out.len > 0 && cmp(out.root.prev.Value, n.Value) == 0 {
+			continue
+		}
+		out.insert(n, out.root.prev)
+	}
+	return out
+}
+
+// MergeCopy merges sorted lists into a new sorted list holding copies of their values. The input lists are not modified.
+// The complexity is O(N log k).
+func MergeCopy[T any](cmp func(a, b T) int, lists ...*List[T]) *List[T] {
+	out := New[T]()
+	it := NewMergeIterator(cmp, headCursors(lists)...)
+	for n := it.Next(); n != nil; n = it.Next() {
+		out.insertValue(n.Value, out.root.prev)
+	}
+	return out
+}
+
+// MergeIterator lazily walks several sorted sequences in merged order without modifying them.
+type MergeIterator[T any] struct {
+	h *mergeHeap[T]
+}
+
+// NewMergeIterator returns an iterator merging the sequences that start at the given cursors.
+// A cursor pointing to the sentinel node starts at the front of its list. Invalid cursors are ignored.
+// The cursors are consumed: every cursor is moved past the nodes the iterator has returned from its list.
+func NewMergeIterator[T any](cmp func(a, b T) int, cursors ...*Cursor[T]) *MergeIterator[T] {
+	for _, c := range cursors {
+		if c.IsValid() && c.current == &c.list.root {
+			c.MoveNext()
+		}
+	}
+	return &MergeIterator[T]{h: newMergeHeap(cmp, cursors)}
+}
+
+// Next returns the next node in merged order. Return nil if every sequence is exhausted.
+// The complexity is O(log k).
+func (it *MergeIterator[T]) Next() *Node[T] {
+	return it.h.next(false)
+}
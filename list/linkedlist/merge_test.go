@@ -0,0 +1,102 @@
+package linkedlist
+
+import "testing"
+
+func cmpInt(a, b int) int {
+	return a - b
+}
+
+func TestMerge(t *testing.T) {
+	l1 := From(1, 4, 7)
+	l2 := From(2, 5, 8, 9)
+	l3 := New[int]()
+	l4 := From(3, 6)
+
+	n1 := l1.Front()
+	out := Merge(cmpInt, l1, l2, l3, l4)
+	checkList(t, out, []int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	for _, l := range []*List[int]{l1, l2, l3, l4} {
+		checkListPointers(t, l, []*Node[int]{})
+	}
+
+	// nodes are relinked, not copied
+	if out.Front() != n1 {
+		t.Errorf("Merge() copied the first node, want it relinked")
+	}
+
+	var zero List[int]
+	checkListLen(t, Merge(cmpInt, &zero), 0)
+	checkListLen(t, Merge[int](cmpInt), 0)
+}
+
+func TestMergeStable(t *testing.T) {
+	type item struct {
+		key, src int
+	}
+	byKey := func(a, b item) int { return a.key - b.key }
+
+	l1 := From(item{1, 1}, item{2, 1})
+	l2 := From(item{1, 2}, item{2, 2})
+	out := Merge(byKey, l1, l2)
+	checkList(t, out, []item{{1, 1}, {1, 2}, {2, 1}, {2, 2}})
+}
+
+func TestMergeDedupe(t *testing.T) {
+	l1 := From(1, 3, 5, 5)
+	l2 := From(1, 2, 3, 6)
+	l3 := From(5)
+
+	out := MergeDedupe(cmpInt, l1, l2, l3)
+	checkList(t, out, []int{1, 2, 3, 5, 6})
+	checkListLen(t, l1, 0)
+	checkListLen(t, l2, 0)
+	checkListLen(t, l3, 0)
+}
+
+func TestMergeCopy(t *testing.T) {
+	l1 := From(1, 3, 5)
+	l2 := From(2, 4)
+
+	out := MergeCopy(cmpInt, l1, l2)
+	checkList(t, out, []int{1, 2, 3, 4, 5})
+	checkList(t, l1, []int{1, 3, 5})
+	checkList(t, l2, []int{2, 4})
+
+	if out.Front() == l1.Front() {
+		t.Errorf("MergeCopy() relinked the first node, want it copied")
+	}
+}
+
+func TestMergeIterator(t *testing.T) {
+	l1 := From(1, 3, 5)
+	l2 := From(2, 4, 6)
+
+	c1 := l1.Cursor() // sentinel: starts at the front
+	c2 := l2.FrontCursor()
+	c2.MoveNext() // starts at 4
+
+	it := NewMergeIterator(cmpInt, c1, c2)
+
+	var got []int
+	for i := 0; i < 3; i++ {
+		got = append(got, it.Next().Value)
+	}
+	checkList(t, From(got...), []int{1, 3, 4})
+
+	// the cursors have been consumed up to the next candidates
+	if c1.Value() != 5 || c2.Value() != 6 {
+		t.Errorf("cursors = %v, %v, want 5, 6", c1.Value(), c2.Value())
+	}
+
+	for n := it.Next(); n != nil; n = it.Next() {
+		got = append(got, n.Value)
+	}
+	checkList(t, From(got...), []int{1, 3, 4, 5, 6})
+	checkCursor(t, l1, []*Cursor[int]{c1})
+	checkCursor(t, l2, []*Cursor[int]{c2})
+
+	// the inputs are not modified
+	checkList(t, l1, []int{1, 3, 5})
+	checkList(t, l2, []int{2, 4, 6})
+}
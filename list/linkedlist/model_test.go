@@ -0,0 +1,388 @@
+package linkedlist_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/nnhatnam/skale/container"
+	"github.com/nnhatnam/skale/container/containertest"
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+// modelCursors is the number of cursors used by the list machine.
+const modelCursors = 4
+
+// sentinel and invalid are the model positions of a cursor pointing to the sentinel node, and of an invalid cursor.
+// The other positions are the values of the nodes, which are all distinct.
+const (
+	sentinel = 0
+	invalid  = -1
+)
+
+// listState is a list with its cursors, and their model: the values of the list and the positions of the cursors.
+type listState struct {
+	l       *linkedlist.List[int]
+	cursors [modelCursors]*linkedlist.Cursor[int]
+	model   []int
+	at      [modelCursors]int
+	next    int // the next value pushed
+}
+
+// index returns the index of value v in the model, or -1.
+func (s *listState) index(v int) int {
+	for i, x := range s.model {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// push adds a new value to the model at index i, and returns it.
+func (s *listState) push(i int) int {
+	s.next++
+	s.model = append(s.model[:i], append([]int{s.next}, s.model[i:]...)...)
+	return s.next
+}
+
+// remove removes the value at index i from the model. The cursors pointing to it become invalid.
+func (s *listState) remove(i int) int {
+	v := s.model[i]
+	s.model = append(s.model[:i], s.model[i+1:]...)
+	for c := range s.at {
+		if s.at[c] == v {
+			s.at[c] = invalid
+		}
+	}
+	return v
+}
+
+// neighbor returns the position after (or before) position p, wrapping through the sentinel node.
+func (s *listState) neighbor(p int, after bool) int {
+	i := len(s.model) // the sentinel node
+	if p != sentinel {
+		i = s.index(p)
+	}
+	if after {
+		i++
+	} else {
+		i--
+	}
+	i = (i + len(s.model) + 1) % (len(s.model) + 1)
+	if i == len(s.model) {
+		return sentinel
+	}
+	return s.model[i]
+}
+
+// moveTo moves value v at index i of the model.
+func (s *listState) moveTo(v, i int) {
+	s.model = append(s.model[:s.index(v)], s.model[s.index(v)+1:]...)
+	s.model = append(s.model[:i], append([]int{v}, s.model[i:]...)...)
+}
+
+// checkRemoved checks the node returned by a removal against the value removed from the model, if any.
+func checkRemoved(n *linkedlist.Node[int], want int, ok bool) error {
+	switch {
+	case !ok && n != nil:
+		return fmt.Errorf("removed node %v, want nil", n.Value)
+	case ok && n == nil:
+		return fmt.Errorf("removed nil, want node %d", want)
+	case ok && n.Value != want:
+		return fmt.Errorf("removed node %v, want %d", n.Value, want)
+	}
+	return nil
+}
+
+func listMachine() containertest.Machine[*listState] {
+	type op = containertest.Op[*listState]
+	type input = containertest.Input
+
+	// removeAt removes the node at index i of the model, if i is in range, and returns the removed value.
+	removeAt := func(s *listState, i int) (int, bool) {
+		if i < 0 || i >= len(s.model) {
+			return 0, false
+		}
+		return s.remove(i), true
+	}
+
+	pop := func(front bool) func(s *listState, in *input) error {
+		return func(s *listState, in *input) error {
+			if front {
+				v, ok := removeAt(s, 0)
+				return checkRemoved(s.l.PopFront(), v, ok)
+			}
+			v, ok := removeAt(s, len(s.model)-1)
+			return checkRemoved(s.l.PopBack(), v, ok)
+		}
+	}
+
+	insert := func(after bool) func(s *listState, in *input) error {
+		return func(s *listState, in *input) error {
+			c := in.Intn(modelCursors)
+			p := s.at[c]
+
+			var n *linkedlist.Node[int]
+			if after {
+				n = s.l.InsertAfter(s.next+1, s.cursors[c])
+			} else {
+				n = s.l.InsertBefore(s.next+1, s.cursors[c])
+			}
+			if p == invalid || p == sentinel {
+				if n != nil {
+					return fmt.Errorf("insertion at cursor %d (position %d) returned a node", c, p)
+				}
+				return nil
+			}
+
+			i := s.index(p)
+			if after {
+				i++
+			}
+			if v := s.push(i); n == nil || n.Value != v {
+				return fmt.Errorf("insertion at cursor %d returned %v, want node %d", c, n, v)
+			}
+			return nil
+		}
+	}
+
+	removeNear := func(after bool) func(s *listState, in *input) error {
+		return func(s *listState, in *input) error {
+			c := in.Intn(modelCursors)
+			p := s.at[c]
+
+			var n *linkedlist.Node[int]
+			if after {
+				n = s.l.RemoveAfter(s.cursors[c])
+			} else {
+				n = s.l.RemoveBefore(s.cursors[c])
+			}
+			if p == invalid {
+				return checkRemoved(n, 0, false)
+			}
+			target := s.neighbor(p, after)
+			if target == sentinel {
+				return checkRemoved(n, 0, false)
+			}
+			v, ok := removeAt(s, s.index(target))
+			return checkRemoved(n, v, ok)
+		}
+	}
+
+	remove := func(advance linkedlist.Advance) func(s *listState, in *input) error {
+		return func(s *listState, in *input) error {
+			c := in.Intn(modelCursors)
+			p := s.at[c]
+
+			var n *linkedlist.Node[int]
+			if advance == linkedlist.AdvanceNext && in.Bool() {
+				n = s.l.RemoveAt(s.cursors[c])
+			} else {
+				n = s.cursors[c].Remove(advance)
+			}
+			if p == invalid || p == sentinel {
+				return checkRemoved(n, 0, false)
+			}
+
+			to := s.neighbor(p, advance == linkedlist.AdvanceNext)
+			v, ok := removeAt(s, s.index(p))
+			s.at[c] = to
+			return checkRemoved(n, v, ok)
+		}
+	}
+
+	moveToEnd := func(front bool) func(s *listState, in *input) error {
+		return func(s *listState, in *input) error {
+			c := in.Intn(modelCursors)
+			p := s.at[c]
+			if front {
+				s.l.MoveToFront(s.cursors[c])
+			} else {
+				s.l.MoveToBack(s.cursors[c])
+			}
+			if p != invalid && p != sentinel {
+				if front {
+					s.moveTo(p, 0)
+				} else {
+					s.moveTo(p, len(s.model)-1)
+				}
+			}
+			return nil
+		}
+	}
+
+	moveNear := func(after bool) func(s *listState, in *input) error {
+		return func(s *listState, in *input) error {
+			c, m := in.Intn(modelCursors), in.Intn(modelCursors)
+			p, mark := s.at[c], s.at[m]
+			if after {
+				s.l.MoveAfter(s.cursors[c], s.cursors[m])
+			} else {
+				s.l.MoveBefore(s.cursors[c], s.cursors[m])
+			}
+			if p == invalid || p == sentinel || mark == invalid || p == mark {
+				return nil
+			}
+
+			// the sentinel node is before the front and after the back
+			var i int
+			switch {
+			case mark == sentinel && after:
+				i = 0
+			case mark == sentinel:
+				i = len(s.model) - 1
+			case s.index(p) < s.index(mark) && !after:
+				i = s.index(mark) - 1
+			case s.index(p) < s.index(mark):
+				i = s.index(mark)
+			case !after:
+				i = s.index(mark)
+			default:
+				i = s.index(mark) + 1
+			}
+			s.moveTo(p, i)
+			return nil
+		}
+	}
+
+	step := func(next bool) func(s *listState, in *input) error {
+		return func(s *listState, in *input) error {
+			c := in.Intn(modelCursors)
+			if s.at[c] == invalid {
+				return nil // moving an invalid cursor is not supported
+			}
+			if next {
+				s.cursors[c].MoveNext()
+			} else {
+				s.cursors[c].MovePrev()
+			}
+			s.at[c] = s.neighbor(s.at[c], next)
+			return nil
+		}
+	}
+
+	return containertest.Machine[*listState]{
+		New: func() *listState {
+			s := &listState{l: linkedlist.New[int]()}
+			for c := range s.cursors {
+				s.cursors[c] = s.l.Cursor()
+			}
+			return s
+		},
+		Ops: []op{
+			{Name: "PushBack", Run: func(s *listState, in *input) error {
+				s.l.PushBack(s.push(len(s.model)))
+				return nil
+			}},
+			{Name: "PushFront", Run: func(s *listState, in *input) error {
+				s.l.PushFront(s.push(0))
+				return nil
+			}},
+			{Name: "PopFront", Run: pop(true)},
+			{Name: "PopBack", Run: pop(false)},
+			{Name: "InsertBefore", Run: insert(false)},
+			{Name: "InsertAfter", Run: insert(true)},
+			{Name: "RemoveAt", Run: remove(linkedlist.AdvanceNext)},
+			{Name: "Cursor.RemovePrev", Run: remove(linkedlist.AdvancePrev)},
+			{Name: "RemoveBefore", Run: removeNear(false)},
+			{Name: "RemoveAfter", Run: removeNear(true)},
+			{Name: "MoveToFront", Run: moveToEnd(true)},
+			{Name: "MoveToBack", Run: moveToEnd(false)},
+			{Name: "MoveBefore", Run: moveNear(false)},
+			{Name: "MoveAfter", Run: moveNear(true)},
+			{Name: "Cursor.MoveNext", Run: step(true)},
+			{Name: "Cursor.MovePrev", Run: step(false)},
+			{Name: "Cursor.Reset", Run: func(s *listState, in *input) error {
+				c := in.Intn(modelCursors)
+				switch in.Intn(3) {
+				case 0:
+					s.cursors[c], s.at[c] = s.l.Cursor(), sentinel
+				case 1:
+					s.cursors[c], s.at[c] = s.l.FrontCursor(), s.neighbor(sentinel, true)
+				default:
+					s.cursors[c], s.at[c] = s.l.BackCursor(), s.neighbor(sentinel, false)
+				}
+				return nil
+			}},
+		},
+		Check: checkListState,
+	}
+}
+
+// checkListState compares the list and its cursors with the model.
+func checkListState(s *listState) error {
+	if err := s.l.Validate(); err != nil {
+		return err
+	}
+	if s.l.Len() != len(s.model) {
+		return fmt.Errorf("Len() = %d, want %d", s.l.Len(), len(s.model))
+	}
+
+	i := 0
+	var err error
+	s.l.WalkAscending(func(n *linkedlist.Node[int]) bool {
+		if n.Value != s.model[i] {
+			err = fmt.Errorf("value %d = %d, want %d; list %v", i, n.Value, s.model[i], s.model)
+			return false
+		}
+		i++
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for c, p := range s.at {
+		cursor := s.cursors[c]
+		switch valid := cursor.IsValid(); {
+		case p == invalid && valid:
+			return fmt.Errorf("cursor %d is valid, want invalid", c)
+		case p == invalid:
+		case !valid:
+			return fmt.Errorf("cursor %d is invalid, want at %d", c, p)
+		case p == sentinel && cursor.Node() != nil:
+			return fmt.Errorf("cursor %d is at node %d, want the sentinel node", c, cursor.Node().Value)
+		case p != sentinel && (cursor.Node() == nil || cursor.Node().Value != p):
+			return fmt.Errorf("cursor %d is at %v, want node %d", c, cursor.Node(), p)
+		}
+	}
+	return nil
+}
+
+func TestListModel(t *testing.T) {
+	m := listMachine()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		m.RunRandom(t, r, 200)
+	}
+}
+
+func TestDequeModel(t *testing.T) {
+	m := containertest.DequeMachine(func() container.Deque[int, *linkedlist.Node[int]] {
+		return &linkedlist.List[int]{}
+	}, nodeValue)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		m.RunRandom(t, r, 200)
+	}
+}
+
+func FuzzList(f *testing.F) {
+	f.Add([]byte{0, 0, 1, 4, 0, 12, 1, 13, 2, 0})
+	f.Add([]byte{1, 1, 1, 16, 0, 1, 14, 0, 6, 0, 1, 8, 1})
+	m := listMachine()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m.Run(t, data)
+	})
+}
+
+func FuzzDeque(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 2, 3, 3})
+	m := containertest.DequeMachine(func() container.Deque[int, *linkedlist.Node[int]] {
+		return linkedlist.New[int]()
+	}, nodeValue)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m.Run(t, data)
+	})
+}
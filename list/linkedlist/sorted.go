@@ -0,0 +1,267 @@
+package linkedlist
+
+import (
+	"math/rand"
+
+	"golang.org/x/exp/constraints"
+)
+
+const (
+	// sortedMaxLevel is the number of index levels above the list. With sortedP = 1/4 it is enough for 4^16 elements.
+	sortedMaxLevel = 16
+	// A tower grows one more level with probability 1/sortedP.
+	sortedP = 4
+)
+
+// skipLink is a forward pointer of an index level. width is the number of list nodes the link skips over.
+type skipLink[T any] struct {
+	next  *skipTower[T]
+	width int
+}
+
+// skipTower is the index entry of a list node that has been promoted to at least one index level.
+type skipTower[T any] struct {
+	node  *Node[T] // nil for the head tower
+	links []skipLink[T]
+}
+
+// SortedList is a doubly linked list that keeps its values ordered by a comparator.
+// An index of skip pointers is kept over the nodes, so searches, inserts, removals and ranks take O(log n) expected time.
+// Cursors returned by SortedList point into the underlying list and follow the usual Cursor contract.
+// They must only be used to read and walk the list: editing through them (Set, Update, SwapNext, Remove, ...)
+// bypasses the index and breaks the order.
+type SortedList[T any] struct {
+	l    List[T]
+	cmp  func(a, b T) int
+	head skipTower[T]
+	rnd  *rand.Rand
+}
+
+// NewSorted returns an empty sorted list ordered by cmp.
+// cmp(a, b) must return a negative number when a < b, a positive number when a > b and zero when a == b.
+func NewSorted[T any](cmp func(a, b T) int) *SortedList[T] {
+	s := &SortedList[T]{cmp: cmp, rnd: rand.New(rand.NewSource(rand.Int63()))}
+	s.l.Init()
+	s.head.links = make([]skipLink[T], sortedMaxLevel)
+	for i := range s.head.links {
+		s.head.links[i].width = 1
+	}
+	return s
+}
+
+// NewSortedOrdered returns an empty sorted list of an ordered type, in ascending order.
+func NewSortedOrdered[T constraints.Ordered]() *SortedList[T] {
+	return NewSorted(func(a, b T) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	})
+}
+
+// randomLevel returns the number of index levels of a new tower, 0 means the node is not indexed.
+func (s *SortedList[T]) randomLevel() int {
+	h := 0
+	for h < sortedMaxLevel && s.rnd.Intn(sortedP) == 0 {
+		h++
+	}
+	return h
+}
+
+// search finds, for every index level, the last tower whose node satisfies before, and its position.
+// It then walks the list from the lowest of those towers and returns the last node satisfying before,
+// which is the sentinel if there is none, together with its 1-based position (0 for the sentinel).
+// update and rank may be nil when the caller does not need them.
+func (s *SortedList[T]) search(before func(v T) bool, update []*skipTower[T], rank []int) (*Node[T], int) {
+	x := &s.head
+	pos := 0
+	for i := sortedMaxLevel - 1; i >= 0; i-- {
+		for x.links[i].next != nil && before(x.links[i].next.node.Value) {
+			pos += x.links[i].width
+			x = x.links[i].next
+		}
+		if update != nil {
+			update[i] = x
+			rank[i] = pos
+		}
+	}
+
+	n := &s.l.root
+	if x.node != nil {
+		n = x.node
+	}
+	for n.next != &s.l.root && before(n.next.Value) {
+		n = n.next
+		pos++
+	}
+	return n, pos
+}
+
+// less returns a predicate matching the values strictly less than v.
+func (s *SortedList[T]) less(v T) func(T) bool {
+	return func(x T) bool { return s.cmp(x, v) < 0 }
+}
+
+// lessOrEqual returns a predicate matching the values less than or equal to v.
+func (s *SortedList[T]) lessOrEqual(v T) func(T) bool {
+	return func(x T) bool { return s.cmp(x, v) <= 0 }
+}
+
+// insert inserts v after every value matching before and returns a cursor pointing to the new node.
+func (s *SortedList[T]) insert(v T, before func(T) bool) *Cursor[T] {
+	var update [sortedMaxLevel]*skipTower[T]
+	var rank [sortedMaxLevel]int
+
+	mark, pos := s.search(before, update[:], rank[:])
+	n := s.l.insertValue(v, mark)
+	pos++ // position of the new node
+
+	h := s.randomLevel()
+	var t *skipTower[T]
+	if h > 0 {
+		t = &skipTower[T]{node: n, links: make([]skipLink[T], h)}
+	}
+
+	for i := 0; i < sortedMaxLevel; i++ {
+		u := update[i]
+		if i < h {
+			// u's link used to reach position rank[i]+width, which moved one step to the right
+			t.links[i].next = u.links[i].next
+			t.links[i].width = rank[i] + u.links[i].width + 1 - pos
+			u.links[i].next = t
+			u.links[i].width = pos - rank[i]
+		} else {
+			u.links[i].width++
+		}
+	}
+
+	return &Cursor[T]{list: &s.l, current: n}
+}
+
+// Len returns the number of elements of the sorted list. The complexity is O(1).
+func (s *SortedList[T]) Len() int {
+	return s.l.len
+}
+
+// Front returns the smallest element of the sorted list. Return nil if the list is empty.
+// The complexity is O(1).
+func (s *SortedList[T]) Front() *Node[T] {
+	return s.l.front()
+}
+
+// Back returns the largest element of the sorted list. Return nil if the list is empty.
+// The complexity is O(1).
+func (s *SortedList[T]) Back() *Node[T] {
+	return s.l.back()
+}
+
+// Cursor returns a cursor pointing to the sentinel node of the sorted list.
+func (s *SortedList[T]) Cursor() *Cursor[T] {
+	return s.l.Cursor()
+}
+
+// Insert inserts v in order and returns a cursor pointing to the new node.
+// v is placed before the elements equal to it, so the order of equal elements is not preserved.
+// The complexity is O(log n) expected.
+func (s *SortedList[T]) Insert(v T) *Cursor[T] {
+	return s.insert(v, s.less(v))
+}
+
+// InsertStable inserts v in order and returns a cursor pointing to the new node.
+// v is placed after the elements equal to it, so equal elements stay in insertion order.
+// The complexity is O(log n) expected.
+func (s *SortedList[T]) InsertStable(v T) *Cursor[T] {
+	return s.insert(v, s.lessOrEqual(v))
+}
+
+// Remove removes the first element equal to v. Return false if there is no such element.
+// Cursors pointing to the removed node become invalid.
+// The complexity is O(log n) expected.
+func (s *SortedList[T]) Remove(v T) bool {
+	var update [sortedMaxLevel]*skipTower[T]
+	var rank [sortedMaxLevel]int
+
+	mark, _ := s.search(s.less(v), update[:], rank[:])
+	n := mark.next
+	if n == &s.l.root || s.cmp(n.Value, v) != 0 {
+		return false
+	}
+
+	for i := 0; i < sortedMaxLevel; i++ {
+		u := update[i]
+		if t := u.links[i].next; t != nil && t.node == n {
+			u.links[i].width += t.links[i].width - 1
+			u.links[i].next = t.links[i].next
+		} else {
+			u.links[i].width--
+		}
+	}
+
+	s.l.remove(n)
+	return true
+}
+
+// Contains reports whether an element equal to v is in the sorted list.
+// The complexity is O(log n) expected.
+func (s *SortedList[T]) Contains(v T) bool {
+	c := s.Ceiling(v)
+	return c != nil && s.cmp(c.Value(), v) == 0
+}
+
+// cursorAt returns a cursor pointing to n, or nil if n is the sentinel node.
+func (s *SortedList[T]) cursorAt(n *Node[T]) *Cursor[T] {
+	if n == &s.l.root {
+		return nil
+	}
+	return &Cursor[T]{list: &s.l, current: n}
+}
+
+// Floor returns a cursor pointing to the last element less than or equal to v. Return nil if there is none.
+// The complexity is O(log n) expected.
+func (s *SortedList[T]) Floor(v T) *Cursor[T] {
+	n, _ := s.search(s.lessOrEqual(v), nil, nil)
+	return s.cursorAt(n)
+}
+
+// Ceiling returns a cursor pointing to the first element greater than or equal to v. Return nil if there is none.
+// The complexity is O(log n) expected.
+func (s *SortedList[T]) Ceiling(v T) *Cursor[T] {
+	n, _ := s.search(s.less(v), nil, nil)
+	return s.cursorAt(n.next)
+}
+
+// Lower returns a cursor pointing to the last element strictly less than v. Return nil if there is none.
+// The complexity is O(log n) expected.
+func (s *SortedList[T]) Lower(v T) *Cursor[T] {
+	n, _ := s.search(s.less(v), nil, nil)
+	return s.cursorAt(n)
+}
+
+// Higher returns a cursor pointing to the first element strictly greater than v. Return nil if there is none.
+// The complexity is O(log n) expected.
+func (s *SortedList[T]) Higher(v T) *Cursor[T] {
+	n, _ := s.search(s.lessOrEqual(v), nil, nil)
+	return s.cursorAt(n.next)
+}
+
+// Rank returns the number of elements strictly less than v, which is the 0-based index v would be inserted at.
+// The complexity is O(log n) expected.
+func (s *SortedList[T]) Rank(v T) int {
+	_, pos := s.search(s.less(v), nil, nil)
+	return pos
+}
+
+// RangeFrom calls f, in ascending order, with every node whose value is in the half-open range [lo, hi).
+// Keep walking until f returns false or the range is exhausted.
+// The complexity is O(log n + k) expected, where k is the number of visited nodes.
+func (s *SortedList[T]) RangeFrom(lo, hi T, f func(n *Node[T]) bool) {
+	n, _ := s.search(s.less(lo), nil, nil)
+	for n = n.next; n != &s.l.root && s.cmp(n.Value, hi) < 0; n = n.next {
+		if !f(n) {
+			return
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package linkedlist
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkSortedIndex verifies that the skip pointers of s are consistent with the underlying list.
+func checkSortedIndex[T any](t *testing.T, s *SortedList[T]) {
+	pos := make(map[*Node[T]]int, s.Len())
+	i := 0
+	for n := s.l.root.next; n != &s.l.root; n = n.next {
+		i++
+		pos[n] = i
+	}
+
+	for lvl := 0; lvl < sortedMaxLevel; lvl++ {
+		x := &s.head
+		at := 0
+		for x.links[lvl].next != nil {
+			next := x.links[lvl].next
+			p, ok := pos[next.node]
+			if !ok {
+				t.Errorf("level %d: tower points to a node that is not in the list", lvl)
+				return
+			}
+			if at+x.links[lvl].width != p {
+				t.Errorf("level %d: link from position %d has width %d, want %d", lvl, at, x.links[lvl].width, p-at)
+			}
+			at = p
+			x = next
+		}
+		if at+x.links[lvl].width != s.Len()+1 {
+			t.Errorf("level %d: last link from position %d has width %d, want %d", lvl, at, x.links[lvl].width, s.Len()+1-at)
+		}
+	}
+}
+
+func TestSortedListInsertRemove(t *testing.T) {
+	s := NewSortedOrdered[int]()
+	checkListLen(t, &s.l, 0)
+
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		if c := s.Insert(v); c.Value() != v {
+			t.Errorf("s.Insert(%d) cursor value = %d", v, c.Value())
+		}
+	}
+	checkList(t, &s.l, []int{1, 2, 3, 4, 5})
+	checkSortedIndex(t, s)
+
+	if s.Front().Value != 1 || s.Back().Value != 5 {
+		t.Errorf("s.Front(), s.Back() = %d, %d, want 1, 5", s.Front().Value, s.Back().Value)
+	}
+
+	if !s.Remove(3) {
+		t.Errorf("s.Remove(3) = false, want true")
+	}
+	if s.Remove(3) {
+		t.Errorf("s.Remove(3) = true, want false")
+	}
+	if s.Remove(42) {
+		t.Errorf("s.Remove(42) = true, want false")
+	}
+	checkList(t, &s.l, []int{1, 2, 4, 5})
+	checkSortedIndex(t, s)
+
+	if !s.Contains(4) || s.Contains(3) {
+		t.Errorf("s.Contains(4), s.Contains(3) = %v, %v, want true, false", s.Contains(4), s.Contains(3))
+	}
+}
+
+func TestSortedListStable(t *testing.T) {
+	type item struct {
+		key, seq int
+	}
+	byKey := func(a, b item) int { return a.key - b.key }
+
+	s := NewSorted(byKey)
+	for i, k := range []int{2, 1, 2, 1, 2} {
+		s.InsertStable(item{key: k, seq: i})
+	}
+	want := []item{{1, 1}, {1, 3}, {2, 0}, {2, 2}, {2, 4}}
+	checkList(t, &s.l, want)
+
+	s = NewSorted(byKey)
+	for i, k := range []int{2, 1, 2, 1, 2} {
+		s.Insert(item{key: k, seq: i})
+	}
+	want = []item{{1, 3}, {1, 1}, {2, 4}, {2, 2}, {2, 0}}
+	checkList(t, &s.l, want)
+
+	// Remove removes the first equal element
+	s.Remove(item{key: 2})
+	checkList(t, &s.l, []item{{1, 3}, {1, 1}, {2, 2}, {2, 0}})
+	checkSortedIndex(t, s)
+}
+
+func TestSortedListSearch(t *testing.T) {
+	s := NewSortedOrdered[int]()
+	for _, v := range []int{10, 20, 20, 30} {
+		s.Insert(v)
+	}
+
+	value := func(c *Cursor[int]) any {
+		if c == nil {
+			return nil
+		}
+		return c.Value()
+	}
+
+	tests := []struct {
+		v                             int
+		floor, ceiling, lower, higher any
+		rank                          int
+	}{
+		{5, nil, 10, nil, 10, 0},
+		{10, 10, 10, nil, 20, 0},
+		{15, 10, 20, 10, 20, 1},
+		{20, 20, 20, 10, 30, 1},
+		{30, 30, 30, 20, nil, 3},
+		{35, 30, nil, 30, nil, 4},
+	}
+
+	for _, tt := range tests {
+		if got := value(s.Floor(tt.v)); got != tt.floor {
+			t.Errorf("s.Floor(%d) = %v, want %v", tt.v, got, tt.floor)
+		}
+		if got := value(s.Ceiling(tt.v)); got != tt.ceiling {
+			t.Errorf("s.Ceiling(%d) = %v, want %v", tt.v, got, tt.ceiling)
+		}
+		if got := value(s.Lower(tt.v)); got != tt.lower {
+			t.Errorf("s.Lower(%d) = %v, want %v", tt.v, got, tt.lower)
+		}
+		if got := value(s.Higher(tt.v)); got != tt.higher {
+			t.Errorf("s.Higher(%d) = %v, want %v", tt.v, got, tt.higher)
+		}
+		if got := s.Rank(tt.v); got != tt.rank {
+			t.Errorf("s.Rank(%d) = %d, want %d", tt.v, got, tt.rank)
+		}
+	}
+
+	// cursors returned by the search can walk the list
+	c := s.Ceiling(15)
+	if n := c.MoveNext(); n == nil || n.Value != 20 {
+		t.Errorf("s.Ceiling(15).MoveNext() = %v, want 20", n)
+	}
+}
+
+func TestSortedListRangeFrom(t *testing.T) {
+	s := NewSortedOrdered[int]()
+	for i := 0; i < 10; i++ {
+		s.Insert(i)
+	}
+
+	var got []int
+	s.RangeFrom(3, 7, func(n *Node[int]) bool {
+		got = append(got, n.Value)
+		return true
+	})
+	checkList(t, From(got...), []int{3, 4, 5, 6})
+
+	got = got[:0]
+	s.RangeFrom(3, 7, func(n *Node[int]) bool {
+		got = append(got, n.Value)
+		return n.Value < 4
+	})
+	checkList(t, From(got...), []int{3, 4})
+
+	got = got[:0]
+	s.RangeFrom(20, 30, func(n *Node[int]) bool {
+		got = append(got, n.Value)
+		return true
+	})
+	if len(got) != 0 {
+		t.Errorf("RangeFrom(20, 30) visited %v, want none", got)
+	}
+}
+
+func TestSortedListRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := NewSortedOrdered[int]()
+	var model []int
+
+	for i := 0; i < 2000; i++ {
+		v := r.Intn(200)
+		if r.Intn(3) == 0 {
+			removed := s.Remove(v)
+			j := sort.SearchInts(model, v)
+			found := j < len(model) && model[j] == v
+			if removed != found {
+				t.Fatalf("s.Remove(%d) = %v, want %v", v, removed, found)
+			}
+			if found {
+				model = append(model[:j], model[j+1:]...)
+			}
+		} else {
+			s.InsertStable(v)
+			j := sort.SearchInts(model, v+1)
+			model = append(model[:j], append([]int{v}, model[j:]...)...)
+		}
+
+		if got := s.Rank(v); got != sort.SearchInts(model, v) {
+			t.Fatalf("s.Rank(%d) = %d, want %d", v, got, sort.SearchInts(model, v))
+		}
+	}
+
+	checkList(t, &s.l, model)
+	checkSortedIndex(t, s)
+}
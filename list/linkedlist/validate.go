@@ -0,0 +1,101 @@
+package linkedlist
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrBrokenLink is reported by Validate when the next and prev links of two neighbor nodes do not match,
+	// or when a link is nil.
+	ErrBrokenLink = errors.New("linkedlist: broken link")
+	// ErrLenMismatch is reported by Validate when the number of nodes differs from the length of the list.
+	ErrLenMismatch = errors.New("linkedlist: length mismatch")
+	// ErrCycle is reported by Validate when the next links loop without going through the sentinel node.
+	ErrCycle = errors.New("linkedlist: cycle skipping the sentinel")
+	// ErrForeignNode is reported by Validate when a node linked in the list belongs to another list, or to none.
+	ErrForeignNode = errors.New("linkedlist: node owned by another list")
+)
+
+// Validate checks the structure of list l: every next link is matched by the prev link of the next node,
+// the nodes form a single ring through the sentinel node, and their number is the length of the list.
+// Every node records the list holding it, and Validate also checks that the nodes linked in l belong to it.
+// A next link leading into the ring of another list is reported as ErrCycle, the ring never coming back to the
+// sentinel node of l.
+// The returned error wraps ErrBrokenLink, ErrLenMismatch, ErrCycle or ErrForeignNode.
+// The complexity is O(n).
+func (l *List[T]) Validate() error {
+	root := &l.root
+	if root.next == nil || root.prev == nil {
+		// a zero list
+		if root.next != root.prev {
+			return fmt.Errorf("%w: the sentinel node is half initialized", ErrBrokenLink)
+		}
+		if l.len != 0 {
+			return fmt.Errorf("%w: zero list of length %d", ErrLenMismatch, l.len)
+		}
+		return nil
+	}
+
+	// follow the next links first, the tortoise moving at half the speed of the hare to detect cycles
+	slow, fast := root, root
+walk:
+	for {
+		for i := 0; i < 2; i++ {
+			if fast = fast.next; fast == nil {
+				return fmt.Errorf("%w: nil next link", ErrBrokenLink)
+			}
+			if fast == root {
+				break walk
+			}
+		}
+		if slow = slow.next; slow == fast {
+			return fmt.Errorf("%w: at node %p", ErrCycle, slow)
+		}
+	}
+
+	// the ring is closed: check the prev links and the owners
+	count := 0
+	n := root
+	for ; n.next != root; n = n.next {
+		next := n.next
+		if next.prev != n {
+			return fmt.Errorf("%w: wrong prev link of node %d (%p)", ErrBrokenLink, count, next)
+		}
+		if !l.owns(next) {
+			return fmt.Errorf("%w: node %d (%p)", ErrForeignNode, count, next)
+		}
+		count++
+	}
+	if root.prev != n {
+		return fmt.Errorf("%w: wrong prev link of the sentinel node", ErrBrokenLink)
+	}
+
+	if count != l.len {
+		return fmt.Errorf("%w: %d nodes, length %d", ErrLenMismatch, count, l.len)
+	}
+	return nil
+}
+
+// dumpLimit is the number of nodes printed by dump.
+const dumpLimit = 64
+
+// dump describes the links of list l, for debugging. It stops at a nil link, after dumpLimit nodes,
+// or when it comes back to the sentinel node.
+func (l *List[T]) dump() string {
+	var b strings.Builder
+	root := &l.root
+	fmt.Fprintf(&b, "list %p: len %d, sentinel prev %p next %p\n", l, l.len, root.prev, root.next)
+
+	n := root.next
+	for i := 0; n != nil && n != root; i++ {
+		if i == dumpLimit {
+			b.WriteString("\t...\n")
+			break
+		}
+		fmt.Fprintf(&b, "\t[%d] %p: prev %p next %p value %v\n", i, n, n.prev, n.next, n.Value)
+		n = n.next
+	}
+	return b.String()
+}
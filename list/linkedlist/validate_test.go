@@ -0,0 +1,102 @@
+package linkedlist
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	var zero List[int]
+	if err := zero.Validate(); err != nil {
+		t.Errorf("zero.Validate() = %v, want nil", err)
+	}
+
+	l := From(1, 2, 3, 4, 5)
+	l.Reverse()
+	l.Rotate(2)
+	l.MoveToBack(l.FrontCursor())
+	l.RemoveAt(l.BackCursor())
+	if err := l.Validate(); err != nil {
+		t.Errorf("l.Validate() = %v, want nil", err)
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func(l *List[int])
+		want    error
+	}{
+		{"prev link", func(l *List[int]) { l.root.next.next.prev = &l.root }, ErrBrokenLink},
+		{"sentinel prev link", func(l *List[int]) { l.root.prev = l.root.next }, ErrBrokenLink},
+		{"nil link", func(l *List[int]) { l.root.next.next.next = nil }, ErrBrokenLink},
+		{"half zero", func(l *List[int]) { l.root.prev = nil }, ErrBrokenLink},
+		{"len", func(l *List[int]) { l.len++ }, ErrLenMismatch},
+		{"zero len", func(l *List[int]) { l.root.next, l.root.prev = nil, nil }, ErrLenMismatch},
+		{"cycle", func(l *List[int]) { l.root.prev.next = l.root.next.next }, ErrCycle},
+		{"self loop", func(l *List[int]) { l.root.next.next = l.root.next }, ErrCycle},
+	}
+	for _, tt := range tests {
+		l := From(1, 2, 3, 4)
+		tt.corrupt(l)
+		if err := l.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: l.Validate() = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestValidateForeignNode(t *testing.T) {
+	// a next link leading into the ring of another list is a cycle skipping the sentinel, in every build
+	a, b := From(1, 2, 3), From(4, 5)
+	a.root.prev.next = b.root.next
+	if err := a.Validate(); !errors.Is(err, ErrCycle) {
+		t.Errorf("a.Validate() with a link into b = %v, want %v", err, ErrCycle)
+	}
+
+	// a node of b linked into a with matching links
+	a, b = From(1, 2, 3), From(4, 5)
+	n := b.root.next
+	n.prev, n.next = &a.root, a.root.next
+	a.root.next.prev, a.root.next = n, n
+	a.len++
+	if err := a.Validate(); !errors.Is(err, ErrForeignNode) {
+		t.Errorf("a.Validate() with a node of b = %v, want %v", err, ErrForeignNode)
+	}
+
+	// the nodes of a list spliced into another one belong to it, and so do the nodes of a detached view
+	a, b = From(1, 2, 3), From(4, 5)
+	c := From(6)
+	b.SpliceBackList(a)
+	c.SpliceFrontList(b)
+	a.PushBack(7)
+	v := c.Range(c.FrontCursor(), c.FrontCursor().CloneNext())
+	d := v.Detach()
+	for _, l := range []*List[int]{a, b, c, d} {
+		if err := l.Validate(); err != nil {
+			t.Errorf("Validate() after splicing = %v, want nil", err)
+		}
+	}
+
+	// the nodes of a cleared list belong to no list
+	a = From(1, 2)
+	n = a.root.next
+	a.Init()
+	n.prev, n.next = &a.root, &a.root
+	a.root.next, a.root.prev, a.len = n, n, 1
+	if err := a.Validate(); !errors.Is(err, ErrForeignNode) {
+		t.Errorf("a.Validate() with a node of a before Init = %v, want %v", err, ErrForeignNode)
+	}
+}
+
+func TestDump(t *testing.T) {
+	l := From(7, 8)
+	d := l.dump()
+	if !strings.Contains(d, "len 2") || !strings.Contains(d, "value 7") || !strings.Contains(d, "value 8") {
+		t.Errorf("l.dump() = %q, want the length and every value", d)
+	}
+
+	// a cycle skipping the sentinel is cut after dumpLimit nodes
+	l.root.prev.next = l.root.next
+	if d := l.dump(); !strings.Contains(d, "...") {
+		t.Errorf("l.dump() of a cycle = %q, want it truncated", d)
+	}
+}
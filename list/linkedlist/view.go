@@ -0,0 +1,180 @@
+package linkedlist
+
+import "sort"
+
+// View is a lightweight view of the sub-list between two nodes of a list, both included.
+// A view does not copy anything, it only remembers its two boundary nodes.
+// It becomes invalid when either boundary node is removed from the list or moved to another one, or when the
+// boundaries are no longer in order (e.g. after a Reverse).
+type View[T any] struct {
+	list     *List[T]
+	from, to *Node[T]
+}
+
+// Range returns a view of the elements between the cursors from and to, both included. to must not come before from.
+// The returned view is invalid if either cursor is point to the sentinel node, invalid or not associated with l.
+// The complexity is O(1).
+func (l *List[T]) Range(from, to *Cursor[T]) View[T] {
+	if from.list != l || to.list != l || from.current == &l.root || to.current == &l.root {
+		return View[T]{}
+	}
+	if !from.IsValid() || !to.IsValid() {
+		return View[T]{}
+	}
+	return View[T]{list: l, from: from.current, to: to.current}
+}
+
+// span walks the view and returns the number of its elements. Return false if the view is invalid.
+// Both boundaries must still belong to the list: a boundary removed, or moved to another list by AdoptBack or
+// SpliceBackList for example, makes the view invalid.
+func (v *View[T]) span() (int, bool) {
+	if v.list == nil || !v.list.owns(v.from) || !v.list.owns(v.to) {
+		v.list = nil
+		return 0, false
+	}
+
+	root := &v.list.root
+	k := 1
+	for n := v.from; n != v.to; n = n.next {
+		if n == root {
+			v.list = nil // to comes before from
+			return 0, false
+		}
+		k++
+	}
+	return k, true
+}
+
+// IsValid detects if the view is valid.
+// The complexity is O(k), where k is the number of elements of the view.
+func (v *View[T]) IsValid() bool {
+	_, ok := v.span()
+	return ok
+}
+
+// Len returns the number of elements of the view. Return 0 if the view is invalid.
+// The length is computed on every call, so it stays correct after the list is modified inside the range.
+// The complexity is O(k).
+func (v *View[T]) Len() int {
+	k, _ := v.span()
+	return k
+}
+
+// WalkAscending calls f with every node of the view, from the first to the last.
+// Keep walking until f returns false or the end of the view is reached.
+func (v *View[T]) WalkAscending(f func(n *Node[T]) bool) {
+	if !v.IsValid() {
+		return
+	}
+	for n, end := v.from, v.to.next; n != end; n = n.next {
+		if !f(n) {
+			return
+		}
+	}
+}
+
+// WalkDescending calls f with every node of the view, from the last to the first.
+// Keep walking until f returns false or the start of the view is reached.
+func (v *View[T]) WalkDescending(f func(n *Node[T]) bool) {
+	if !v.IsValid() {
+		return
+	}
+	for n, end := v.to, v.from.prev; n != end; n = n.prev {
+		if !f(n) {
+			return
+		}
+	}
+}
+
+// Clear removes the elements of the view from the list. The view becomes invalid.
+// The range is unlinked from the list in O(1), then its nodes are released one by one so that
+// cursors pointing into the range become invalid.
+// The complexity is O(k).
+func (v *View[T]) Clear() {
+	k, ok := v.span()
+	if !ok {
+		return
+	}
+
+	l := v.list
+	before, after := v.from.prev, v.to.next
+	before.next = after
+	after.prev = before
+	l.len -= k
+
+	for n := v.from; n != after; {
+		next := n.next
+		n.next = nil // avoid memory leaks
+		n.prev = nil // avoid memory leaks
+		n.owner = nil
+		n = next
+	}
+	v.list = nil
+	l.debugValidate("View.Clear")
+}
+
+// Detach moves the elements of the view out of the list into a new list, and returns it. The view becomes invalid.
+// Return nil if the view is invalid. The nodes are relinked, not copied.
+// Cursors pointing into the range are still associated with the original list and must not be used after Detach.
+// The complexity is O(k) to count the elements, the relinking itself is O(1).
+func (v *View[T]) Detach() *List[T] {
+	k, ok := v.span()
+	if !ok {
+		return nil
+	}
+
+	l := v.list
+	before, after := v.from.prev, v.to.next
+	before.next = after
+	after.prev = before
+	l.len -= k
+
+	out := New[T]()
+	out.root.next = v.from
+	out.root.prev = v.to
+	v.from.prev = &out.root
+	v.to.next = &out.root
+	out.len = k
+	for n, id := v.from, out.ident(); n != &out.root; n = n.next {
+		n.owner = id
+	}
+
+	v.list = nil
+	l.debugValidate("View.Detach")
+	out.debugValidate("View.Detach")
+	return out
+}
+
+// Sort sorts the elements of the view in place, leaving the rest of the list untouched.
+// cmp(a, b) must return a negative number when a < b, a positive number when a > b and zero when a == b.
+// The sort is stable and relinks the nodes, so cursors keep pointing to the same elements.
+// The view keeps covering the same positions of the list.
+// The complexity is O(k log k).
+func (v *View[T]) Sort(cmp func(a, b T) int) {
+	k, ok := v.span()
+	if !ok || k < 2 {
+		return
+	}
+
+	nodes := make([]*Node[T], 0, k)
+	after := v.to.next
+	for n := v.from; n != after; n = n.next {
+		nodes = append(nodes, n)
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return cmp(nodes[i].Value, nodes[j].Value) < 0
+	})
+
+	prev := v.from.prev
+	for _, n := range nodes {
+		prev.next = n
+		n.prev = prev
+		prev = n
+	}
+	prev.next = after
+	after.prev = prev
+
+	v.from = nodes[0]
+	v.to = nodes[len(nodes)-1]
+	v.list.debugValidate("View.Sort")
+}
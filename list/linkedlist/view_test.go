@@ -0,0 +1,212 @@
+package linkedlist
+
+import "testing"
+
+// cursorAtIndex returns a cursor pointing to the i-th element of l.
+func cursorAtIndex[T any](l *List[T], i int) *Cursor[T] {
+	c := l.FrontCursor()
+	for ; i > 0; i-- {
+		c.MoveNext()
+	}
+	return c
+}
+
+func viewValues[T any](v *View[T]) []T {
+	var vs []T
+	v.WalkAscending(func(n *Node[T]) bool {
+		vs = append(vs, n.Value)
+		return true
+	})
+	return vs
+}
+
+func TestViewWalk(t *testing.T) {
+	l := From(1, 2, 3, 4, 5, 6)
+	v := l.Range(cursorAtIndex(l, 1), cursorAtIndex(l, 3))
+
+	if v.Len() != 3 {
+		t.Errorf("v.Len() = %d, want 3", v.Len())
+	}
+	checkList(t, From(viewValues(&v)...), []int{2, 3, 4})
+
+	var desc []int
+	v.WalkDescending(func(n *Node[int]) bool {
+		desc = append(desc, n.Value)
+		return n.Value > 3
+	})
+	checkList(t, From(desc...), []int{4, 3})
+
+	// the length is recomputed after the list is modified inside the range
+	c := cursorAtIndex(l, 2)
+	l.InsertAfter(42, c)
+	if v.Len() != 4 {
+		t.Errorf("v.Len() = %d after an insert, want 4", v.Len())
+	}
+
+	single := l.Range(c, c)
+	if single.Len() != 1 {
+		t.Errorf("single.Len() = %d, want 1", single.Len())
+	}
+}
+
+func TestViewInvalid(t *testing.T) {
+	l := From(1, 2, 3, 4)
+
+	invalid := []View[int]{
+		l.Range(l.Cursor(), l.BackCursor()),
+		l.Range(l.FrontCursor(), l.Cursor()),
+		l.Range(From(1).FrontCursor(), l.BackCursor()),
+	}
+	for i, v := range invalid {
+		if v.IsValid() || v.Len() != 0 {
+			t.Errorf("invalid[%d].IsValid() = true, want false", i)
+		}
+		if v.Detach() != nil {
+			t.Errorf("invalid[%d].Detach() = non-nil, want nil", i)
+		}
+		v.Clear()
+	}
+	checkList(t, l, []int{1, 2, 3, 4})
+
+	// removing a boundary invalidates the view
+	v := l.Range(cursorAtIndex(l, 1), cursorAtIndex(l, 2))
+	l.RemoveAt(cursorAtIndex(l, 2))
+	if v.IsValid() {
+		t.Errorf("v.IsValid() = true after its boundary was removed, want false")
+	}
+
+	// boundaries out of order
+	v = l.Range(l.BackCursor(), l.FrontCursor())
+	if v.IsValid() {
+		t.Errorf("v.IsValid() = true for reversed boundaries, want false")
+	}
+	checkList(t, l, []int{1, 2, 4})
+}
+
+func TestViewMovedBoundaries(t *testing.T) {
+	// a boundary adopted by another list
+	a, b := From(1, 2, 3), From(9)
+	from := a.FrontCursor()
+	v := a.Range(from, a.BackCursor())
+	b.AdoptBack(from)
+	if v.IsValid() || v.Len() != 0 {
+		t.Errorf("v.Len() = %d after its first boundary was adopted, want an invalid view", v.Len())
+	}
+	checkList(t, a, []int{2, 3})
+	checkList(t, b, []int{9, 1})
+
+	// both boundaries adopted by another list
+	a, b = From(1, 2, 3), New[int]()
+	from, to := a.FrontCursor(), cursorAtIndex(a, 1)
+	v = a.Range(from, to)
+	b.AdoptBack(from)
+	b.AdoptBack(to)
+	if v.IsValid() || v.Detach() != nil {
+		t.Errorf("v is valid after its boundaries were adopted by another list")
+	}
+	v.Clear()
+	checkList(t, a, []int{3})
+	checkList(t, b, []int{1, 2})
+
+	// the whole list spliced into another one
+	a, b = From(1, 2, 3), From(0)
+	v = a.Range(a.FrontCursor(), a.BackCursor())
+	b.SpliceBackList(a)
+	if v.IsValid() || v.Len() != 0 {
+		t.Errorf("v.Len() = %d after its list was spliced into another one, want an invalid view", v.Len())
+	}
+	v.Clear()
+	checkList(t, a, []int{})
+	checkList(t, b, []int{0, 1, 2, 3})
+	for _, l := range []*List[int]{a, b} {
+		if err := l.Validate(); err != nil {
+			t.Errorf("Validate() = %v, want nil", err)
+		}
+	}
+}
+
+func TestViewWalksOnlyItself(t *testing.T) {
+	l := From(1, 2, 3, 4, 5)
+	v := l.Range(l.FrontCursor(), l.FrontCursor().CloneNext())
+
+	// a broken link after the view is never followed
+	last := l.root.prev
+	next := last.next
+	last.next = nil
+	if !v.IsValid() || v.Len() != 2 {
+		t.Errorf("v.IsValid(), v.Len() = %v, %d, want true, 2", v.IsValid(), v.Len())
+	}
+	last.next = next
+
+	// boundaries out of order stop at the sentinel node
+	w := l.Range(l.BackCursor(), l.FrontCursor())
+	if w.IsValid() {
+		t.Errorf("a view ending before its start is valid")
+	}
+}
+
+func TestViewClear(t *testing.T) {
+	l := From(1, 2, 3, 4, 5)
+	first, last := l.FrontCursor(), l.BackCursor()
+	inside := cursorAtIndex(l, 2)
+
+	v := l.Range(cursorAtIndex(l, 1), cursorAtIndex(l, 3))
+	v.Clear()
+	checkList(t, l, []int{1, 5})
+	checkListPointers(t, l, []*Node[int]{first.Node(), last.Node()})
+
+	if inside.IsValid() {
+		t.Errorf("cursor inside a cleared range is still valid")
+	}
+	if v.IsValid() {
+		t.Errorf("v.IsValid() = true after Clear(), want false")
+	}
+
+	// clear the whole list
+	v = l.Range(l.FrontCursor(), l.BackCursor())
+	v.Clear()
+	checkListPointers(t, l, []*Node[int]{})
+}
+
+func TestViewDetach(t *testing.T) {
+	l := From(1, 2, 3, 4, 5)
+	first, last := l.FrontCursor(), l.BackCursor()
+	n2, n4 := cursorAtIndex(l, 1).Node(), cursorAtIndex(l, 3).Node()
+
+	v := l.Range(cursorAtIndex(l, 1), cursorAtIndex(l, 3))
+	out := v.Detach()
+	checkListPointers(t, l, []*Node[int]{first.Node(), last.Node()})
+	checkList(t, out, []int{2, 3, 4})
+
+	if out.Front() != n2 || out.Back() != n4 {
+		t.Errorf("Detach() copied the nodes, want them relinked")
+	}
+	if v.IsValid() {
+		t.Errorf("v.IsValid() = true after Detach(), want false")
+	}
+
+	out.PushBack(6)
+	checkList(t, out, []int{2, 3, 4, 6})
+}
+
+func TestViewSort(t *testing.T) {
+	l := From(9, 5, 3, 4, 1, 0)
+	c3 := cursorAtIndex(l, 2)
+
+	v := l.Range(cursorAtIndex(l, 1), cursorAtIndex(l, 4))
+	v.Sort(cmpInt)
+	checkList(t, l, []int{9, 1, 3, 4, 5, 0})
+
+	var es []*Node[int]
+	for n := l.root.next; n != &l.root; n = n.next {
+		es = append(es, n)
+	}
+	checkListPointers(t, l, es)
+
+	if c3.Value() != 3 {
+		t.Errorf("cursor = %v after Sort(), want 3", c3.Value())
+	}
+
+	// the view still covers the same positions
+	checkList(t, From(viewValues(&v)...), []int{1, 3, 4, 5})
+}
@@ -0,0 +1,100 @@
+package linkedlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// nodes returns the sentinel node of list l followed by its nodes, in order. It stops after l.Len() nodes,
+// so that a list broken by a bug can still be rendered.
+func (l *List[T]) nodes() []*Node[T] {
+	l.lazyInit()
+	nodes := make([]*Node[T], 0, l.len+1)
+	nodes = append(nodes, &l.root)
+	for n := l.root.next; n != nil && n != &l.root && len(nodes) <= l.len; n = n.next {
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// cursorOf reports whether c is a valid cursor of list l.
+func cursorOf[T any](l *List[T], c *Cursor[T]) bool {
+	return c != nil && c.IsValid() && c.list == l
+}
+
+// WriteDOT writes list l to w in the Graphviz DOT language: the sentinel node, one node per value, the next and prev
+// links, and the position of every cursor. Cursors are labelled by their index in cursors, c0 being the first one.
+// Cursors that are not valid or belong to another list are left out.
+// The complexity is O(n).
+func WriteDOT[T any](w io.Writer, l *List[T], cursors ...*Cursor[T]) error {
+	nodes := l.nodes()
+	ids := make(map[*Node[T]]string, len(nodes))
+	ids[&l.root] = "root"
+	for i, n := range nodes[1:] {
+		ids[n] = "n" + strconv.Itoa(i)
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph list {\n")
+	bw.WriteString("\trankdir=LR;\n")
+	bw.WriteString("\tnode [shape=box];\n")
+	bw.WriteString("\troot [label=\"sentinel\", style=dashed];\n")
+	for _, n := range nodes[1:] {
+		fmt.Fprintf(bw, "\t%s [label=%s];\n", ids[n], strconv.Quote(fmt.Sprint(n.Value)))
+	}
+
+	for _, n := range nodes {
+		if next, ok := ids[n.next]; ok {
+			fmt.Fprintf(bw, "\t%s -> %s [label=next];\n", ids[n], next)
+		}
+		if prev, ok := ids[n.prev]; ok {
+			fmt.Fprintf(bw, "\t%s -> %s [label=prev, style=dashed];\n", ids[n], prev)
+		}
+	}
+
+	for i, c := range cursors {
+		if !cursorOf(l, c) {
+			continue
+		}
+		if id, ok := ids[c.current]; ok {
+			fmt.Fprintf(bw, "\tc%d [shape=plaintext, fontcolor=red];\n", i)
+			fmt.Fprintf(bw, "\tc%d -> %s [color=red];\n", i, id)
+		}
+	}
+
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// WriteASCII writes list l to w on a single line, like [1]<->[2]<->(3)<->[4]. Values pointed to by a cursor are
+// written in parentheses instead of brackets. A cursor pointing to the sentinel node is shown as () before the
+// first value. Cursors that are not valid or belong to another list are ignored.
+// The complexity is O(n).
+func WriteASCII[T any](w io.Writer, l *List[T], cursors ...*Cursor[T]) error {
+	nodes := l.nodes()
+	marked := make(map[*Node[T]]bool, len(cursors))
+	for _, c := range cursors {
+		if cursorOf(l, c) {
+			marked[c.current] = true
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	sep := ""
+	if marked[&l.root] {
+		bw.WriteString("()")
+		sep = "<->"
+	}
+	for _, n := range nodes[1:] {
+		bw.WriteString(sep)
+		if marked[n] {
+			fmt.Fprintf(bw, "(%v)", n.Value)
+		} else {
+			fmt.Fprintf(bw, "[%v]", n.Value)
+		}
+		sep = "<->"
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,78 @@
+package linkedlist
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteASCII(t *testing.T) {
+	l := From(1, 2, 3, 4)
+	c := l.FrontCursor()
+	c.MoveNext()
+	c.MoveNext()
+	stale := New[int]().Cursor()
+
+	tests := []struct {
+		name    string
+		l       *List[int]
+		cursors []*Cursor[int]
+		want    string
+	}{
+		{"no cursor", l, nil, "[1]<->[2]<->[3]<->[4]"},
+		{"one cursor", l, []*Cursor[int]{c}, "[1]<->[2]<->(3)<->[4]"},
+		{"sentinel", l, []*Cursor[int]{l.Cursor(), l.BackCursor()}, "()<->[1]<->[2]<->[3]<->(4)"},
+		{"foreign cursor", l, []*Cursor[int]{stale, nil}, "[1]<->[2]<->[3]<->[4]"},
+		{"empty", New[int](), nil, ""},
+		{"zero", &List[int]{}, nil, ""},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		if err := WriteASCII(&b, tt.l, tt.cursors...); err != nil {
+			t.Errorf("%s: WriteASCII() error = %v", tt.name, err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%s: WriteASCII() = %q, want %q", tt.name, b.String(), tt.want)
+		}
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	l := From("a", `say "hi"`)
+	c := l.BackCursor()
+
+	var b bytes.Buffer
+	if err := WriteDOT(&b, l, nil, c); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+	got := b.String()
+
+	for _, want := range []string{
+		"digraph list {\n",
+		"\troot [label=\"sentinel\", style=dashed];\n",
+		"\tn0 [label=\"a\"];\n",
+		"\tn1 [label=\"say \\\"hi\\\"\"];\n",
+		"\troot -> n0 [label=next];\n",
+		"\tn0 -> root [label=prev, style=dashed];\n",
+		"\tn1 -> root [label=next];\n",
+		"\troot -> n1 [label=prev, style=dashed];\n",
+		"\tc1 -> n1 [color=red];\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteDOT() output misses %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "c0") {
+		t.Errorf("WriteDOT() rendered the nil cursor c0:\n%s", got)
+	}
+	if n := strings.Count(got, "->"); n != 7 {
+		t.Errorf("WriteDOT() wrote %d edges, want 7", n)
+	}
+
+	// the output is deterministic
+	var b2 bytes.Buffer
+	WriteDOT(&b2, l, nil, c)
+	if b2.String() != got {
+		t.Errorf("WriteDOT() output changed between two calls")
+	}
+}
@@ -0,0 +1,278 @@
+// Package wheel implements a hierarchical timing wheel, a timer scheduler for very large numbers of timers.
+//
+// Time is divided in ticks. The wheel has several levels of slots: a slot of level 0 holds the timers expiring at one
+// tick, a slot of level i spans Slots^i ticks. Every slot is a linked list of timers, so scheduling and canceling a
+// timer are O(1). When the lower levels wrap around, the timers of the next slot of the level above are cascaded down,
+// and a timer is moved at most Levels times before it fires.
+//
+// A wheel is driven either by Tick, which advances it by one tick, by Advance, which catches up with its Clock, or by
+// the goroutine started with Start. Callbacks are called synchronously by the goroutine that drives the wheel, without
+// holding its lock, so they may schedule or cancel timers.
+//
+// Wheel is safe for concurrent use.
+package wheel
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+const (
+	// DefaultSlots is the default number of slots of a level.
+	DefaultSlots = 64
+	// DefaultLevels is the default number of levels. With the default slots, the wheel spans 2^24 ticks before timers
+	// have to be cascaded more than once.
+	DefaultLevels = 4
+)
+
+// Clock tells the current time. Advance converts it to ticks.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Options configures a Wheel.
+type Options struct {
+	// Clock tells the time Advance catches up with. The default is the system clock.
+	Clock Clock
+	// Slots is the number of slots of every level, rounded up to a power of two. The default is DefaultSlots.
+	Slots int
+	// Levels is the number of levels. The default is DefaultLevels.
+	Levels int
+}
+
+// Timer is the handle of a scheduled callback, used to cancel it.
+type Timer struct {
+	expires uint64 // in ticks
+	f       func()
+	node    *linkedlist.Cursor[*Timer] // node in its slot, invalid once the timer fired or was canceled
+}
+
+// Wheel is a hierarchical timing wheel.
+type Wheel struct {
+	mu      sync.Mutex
+	tick    time.Duration
+	clock   Clock
+	start   time.Time
+	bits    uint   // log2 of the number of slots
+	current uint64 // ticks elapsed since start
+	levels  [][]*linkedlist.List[*Timer]
+	len     int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New returns an empty wheel with the given tick duration and the default options.
+// It panics if tick is not positive.
+func New(tick time.Duration) *Wheel {
+	return NewWithOptions(tick, Options{})
+}
+
+// NewWithOptions is like New but configures the wheel with opts.
+func NewWithOptions(tick time.Duration, opts Options) *Wheel {
+	if tick <= 0 {
+		panic("wheel: tick must be positive")
+	}
+	if opts.Clock == nil {
+		opts.Clock = systemClock{}
+	}
+	if opts.Slots <= 0 {
+		opts.Slots = DefaultSlots
+	}
+	if opts.Levels <= 0 {
+		opts.Levels = DefaultLevels
+	}
+
+	bits := uint(1)
+	for 1<<bits < opts.Slots {
+		bits++
+	}
+	if bits*uint(opts.Levels) > 63 {
+		panic("wheel: the levels span more than 2^63 ticks")
+	}
+
+	w := &Wheel{
+		tick:   tick,
+		clock:  opts.Clock,
+		start:  opts.Clock.Now(),
+		bits:   bits,
+		levels: make([][]*linkedlist.List[*Timer], opts.Levels),
+	}
+	for i := range w.levels {
+		w.levels[i] = make([]*linkedlist.List[*Timer], 1<<bits)
+		for j := range w.levels[i] {
+			w.levels[i][j] = linkedlist.New[*Timer]()
+		}
+	}
+	return w
+}
+
+// Len returns the number of pending timers.
+func (w *Wheel) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.len
+}
+
+// Schedule schedules f to be called after d, rounded up to a whole number of ticks, and at least one tick.
+// The complexity is O(1).
+func (w *Wheel) Schedule(d time.Duration, f func()) *Timer {
+	ticks := uint64(1)
+	if d > w.tick {
+		ticks = uint64((d + w.tick - 1) / w.tick)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	t := &Timer{expires: w.current + ticks, f: f}
+	w.add(t)
+	w.len++
+	return t
+}
+
+// Cancel cancels timer t. Return false if t already fired or was canceled.
+// The complexity is O(1).
+func (w *Wheel) Cancel(t *Timer) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if t.node == nil || t.node.Remove(linkedlist.AdvanceNext) == nil {
+		return false
+	}
+	t.node = nil // the cursor moved to the next timer of the slot
+	w.len--
+	return true
+}
+
+// add links t in the slot of its expiry time. The level is chosen by the number of ticks left: a timer beyond the span
+// of the wheel goes to the last slot of the last level and is cascaded again later.
+func (w *Wheel) add(t *Timer) {
+	mask := uint64(1)<<w.bits - 1
+	delta := t.expires - w.current
+	at := t.expires
+
+	level := 0
+	for ; level < len(w.levels)-1; level++ {
+		if delta < uint64(1)<<(w.bits*uint(level+1)) {
+			break
+		}
+	}
+	if max := uint64(1)<<(w.bits*uint(len(w.levels))) - 1; delta > max {
+		at = w.current + max
+	}
+
+	slot := w.levels[level][(at>>(w.bits*uint(level)))&mask]
+	if t.node == nil {
+		slot.PushBack(t)
+		t.node = slot.BackCursor()
+	} else {
+		slot.AdoptBack(t.node)
+	}
+}
+
+// advance moves the wheel one tick forward, cascades the levels that wrap around, and returns the timers that expire.
+func (w *Wheel) advance() []*Timer {
+	w.current++
+	mask := uint64(1)<<w.bits - 1
+
+	for level := 1; level < len(w.levels); level++ {
+		if w.current&(uint64(1)<<(w.bits*uint(level))-1) != 0 {
+			break
+		}
+		slot := w.levels[level][(w.current>>(w.bits*uint(level)))&mask]
+		for c := slot.FrontCursor(); c.Node() != nil; c = slot.FrontCursor() {
+			w.add(c.Value())
+		}
+	}
+
+	slot := w.levels[0][w.current&mask]
+	var expired []*Timer
+	for n := slot.PopFront(); n != nil; n = slot.PopFront() {
+		expired = append(expired, n.Value)
+	}
+	w.len -= len(expired)
+	return expired
+}
+
+// Tick advances the wheel by one tick, whatever the clock says, and calls the callbacks of the expired timers.
+// It is the way to drive a wheel without goroutines. It returns the number of timers that fired.
+func (w *Wheel) Tick() int {
+	w.mu.Lock()
+	expired := w.advance()
+	w.mu.Unlock()
+
+	for _, t := range expired {
+		t.f()
+	}
+	return len(expired)
+}
+
+// Advance ticks the wheel until it catches up with its clock, and returns the number of timers that fired.
+// Callbacks are called tick by tick, so timers scheduled by a callback fire in order with the others.
+func (w *Wheel) Advance() int {
+	w.mu.Lock()
+	target := uint64(w.clock.Now().Sub(w.start) / w.tick)
+	w.mu.Unlock()
+
+	fired := 0
+	for {
+		w.mu.Lock()
+		if w.current >= target {
+			w.mu.Unlock()
+			return fired
+		}
+		expired := w.advance()
+		w.mu.Unlock()
+
+		for _, t := range expired {
+			t.f()
+		}
+		fired += len(expired)
+	}
+}
+
+// Start starts a goroutine that calls Advance every tick of real time. It does nothing if the wheel is already started.
+func (w *Wheel) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stop != nil {
+		return
+	}
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+
+	go func(stop, done chan struct{}) {
+		defer close(done)
+		t := time.NewTicker(w.tick)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				w.Advance()
+			case <-stop:
+				return
+			}
+		}
+	}(w.stop, w.done)
+}
+
+// Stop stops the goroutine started by Start and waits for it to return. Pending timers are kept.
+// It does nothing if the wheel is not started. It must not be called from a callback, which runs on that goroutine.
+func (w *Wheel) Stop() {
+	w.mu.Lock()
+	stop, done := w.stop, w.done
+	w.stop, w.done = nil, nil
+	w.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,210 @@
+package wheel
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves when advanced.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func TestWheelTick(t *testing.T) {
+	w := New(time.Millisecond)
+
+	var fired []int
+	w.Schedule(3*time.Millisecond, func() { fired = append(fired, 3) })
+	w.Schedule(time.Millisecond, func() { fired = append(fired, 1) })
+	w.Schedule(0, func() { fired = append(fired, 0) }) // at least one tick
+	w.Schedule(2500*time.Microsecond, func() { fired = append(fired, 2) })
+	if w.Len() != 4 {
+		t.Errorf("w.Len() = %d, want 4", w.Len())
+	}
+
+	if n := w.Tick(); n != 2 {
+		t.Errorf("w.Tick() = %d, want 2", n)
+	}
+	w.Tick()
+	w.Tick() // 2.5ms is rounded up to 3 ticks
+
+	// timers expiring at the same tick fire in the order they were scheduled
+	if got, want := fired, []int{1, 0, 3, 2}; len(got) != len(want) {
+		t.Errorf("fired = %v, want %v", got, want)
+	} else {
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("fired = %v, want %v", got, want)
+				break
+			}
+		}
+	}
+	if w.Len() != 0 {
+		t.Errorf("w.Len() = %d after all timers fired, want 0", w.Len())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(0) did not panic")
+		}
+	}()
+	New(0)
+}
+
+func TestWheelCancel(t *testing.T) {
+	w := New(time.Millisecond)
+
+	fired := 0
+	a := w.Schedule(2*time.Millisecond, func() { fired++ })
+	b := w.Schedule(2*time.Millisecond, func() { fired++ })
+	c := w.Schedule(time.Millisecond, func() { fired++ })
+
+	if !w.Cancel(a) {
+		t.Errorf("w.Cancel(a) = false, want true")
+	}
+	if w.Cancel(a) {
+		t.Errorf("w.Cancel(a) twice = true, want false")
+	}
+	w.Tick()
+	if w.Cancel(c) {
+		t.Errorf("w.Cancel(c) = true after it fired")
+	}
+	w.Tick()
+
+	if fired != 2 {
+		t.Errorf("fired = %d, want 2", fired)
+	}
+	if w.Cancel(b) {
+		t.Errorf("w.Cancel(b) = true after it fired")
+	}
+}
+
+func TestWheelCascade(t *testing.T) {
+	// 4 slots and 3 levels span 64 ticks: the delays exercise every level and the overflow
+	w := NewWithOptions(time.Millisecond, Options{Slots: 4, Levels: 3})
+	r := rand.New(rand.NewSource(37))
+
+	const n = 500
+	var timers []*Timer
+	firedAt := make(map[int]uint64)
+	want := make(map[int]uint64)
+	canceled := make(map[int]bool)
+	var tick uint64
+
+	for i := 0; i < n; i++ {
+		i := i
+		ticks := uint64(1 + r.Intn(200))
+		want[i] = ticks
+		timers = append(timers, w.Schedule(time.Duration(ticks)*time.Millisecond, func() {
+			if _, ok := firedAt[i]; ok {
+				t.Errorf("timer %d fired twice", i)
+			}
+			firedAt[i] = tick
+		}))
+	}
+	for i := 0; i < n; i += 7 {
+		canceled[i] = w.Cancel(timers[i])
+	}
+
+	for tick = 1; tick <= 200; tick++ {
+		w.Tick()
+	}
+
+	for i := 0; i < n; i++ {
+		at, ok := firedAt[i]
+		switch {
+		case canceled[i] && ok:
+			t.Errorf("canceled timer %d fired", i)
+		case !canceled[i] && at != want[i]:
+			t.Errorf("timer %d fired at tick %d, want %d", i, at, want[i])
+		}
+	}
+	if w.Len() != 0 {
+		t.Errorf("w.Len() = %d, want 0", w.Len())
+	}
+}
+
+func TestWheelAdvance(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	w := NewWithOptions(10*time.Millisecond, Options{Clock: clock})
+
+	var order []string
+	w.Schedule(50*time.Millisecond, func() {
+		order = append(order, "a")
+		// scheduled by a callback, it fires during the same Advance
+		w.Schedule(10*time.Millisecond, func() { order = append(order, "c") })
+	})
+	w.Schedule(60*time.Millisecond, func() { order = append(order, "b") })
+	w.Schedule(time.Second, func() { order = append(order, "late") })
+
+	clock.Advance(45 * time.Millisecond)
+	if n := w.Advance(); n != 0 {
+		t.Errorf("w.Advance() = %d before any expiry, want 0", n)
+	}
+
+	clock.Advance(20 * time.Millisecond)
+	if n := w.Advance(); n != 3 {
+		t.Errorf("w.Advance() = %d, want 3", n)
+	}
+	if got := len(order); got != 3 || order[0] != "a" || order[1] != "b" || order[2] != "c" {
+		t.Errorf("order = %v, want [a b c]", order)
+	}
+	if w.Len() != 1 {
+		t.Errorf("w.Len() = %d, want 1", w.Len())
+	}
+}
+
+func TestWheelStart(t *testing.T) {
+	w := New(time.Millisecond)
+	done := make(chan struct{})
+	w.Schedule(2*time.Millisecond, func() { close(done) })
+
+	w.Start()
+	w.Start() // no-op
+	defer w.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the timer did not fire")
+	}
+
+	w.Stop()
+	w.Stop() // no-op
+}
+
+func BenchmarkScheduleCancel(b *testing.B) {
+	w := New(time.Millisecond)
+	f := func() {}
+	for i := 0; i < b.N; i++ {
+		w.Cancel(w.Schedule(time.Duration(i%100000)*time.Millisecond, f))
+	}
+}
+
+func BenchmarkTick(b *testing.B) {
+	w := New(time.Millisecond)
+	f := func() {}
+	for i := 0; i < 100000; i++ {
+		w.Schedule(time.Duration(i)*time.Millisecond, f)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Schedule(100*time.Second, f)
+		w.Tick()
+	}
+}
@@ -0,0 +1,485 @@
+// Package btree implements an in-memory B-tree of ordered items.
+//
+// A B-tree stores many items per node in slices, so it uses much less memory per item than a binary tree and walks
+// memory sequentially. Items are compared with a comparator; to use the tree as a map, store key-value pairs and
+// compare their keys.
+//
+// Clone is O(1): the clone and the original share their nodes, and a node is copied the first time either tree
+// modifies it.
+//
+// Cursors follow linkedlist.Cursor, except that MoveNext and MovePrev return the item reached and a boolean instead
+// of a node. A cursor stays valid until its item is deleted.
+//
+// Structure is not thread safe. Clones can be used from different goroutines, as each clone only writes nodes it owns.
+package btree
+
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// owner identifies the tree that may modify a node in place. Nodes owned by another owner are shared with a clone and
+// are copied before being modified.
+type owner[T any] struct {
+	cmp func(a, b T) int
+}
+
+// node is a node of a B-tree. A leaf has no children, an internal node has len(items)+1 children.
+type node[T any] struct {
+	items    []T
+	children []*node[T]
+	owner    *owner[T]
+}
+
+// BTree is a B-tree of items ordered by a comparator. Equal items are the same item: the tree holds one of each.
+type BTree[T any] struct {
+	degree  int
+	len     int
+	root    *node[T]
+	owner   *owner[T]
+	version int // incremented on every modification, for cursors to find their item again
+}
+
+// New returns an empty B-tree of the given degree, ordered by cmp. Every node but the root holds between degree-1 and
+// 2*degree-1 items. Degrees between 16 and 64 work well for small items.
+// cmp(a, b) must return a negative number when a < b, a positive number when a > b and zero when a == b.
+// It panics if degree is less than 2.
+func New[T any](degree int, cmp func(a, b T) int) *BTree[T] {
+	if degree < 2 {
+		panic("btree: degree must be at least 2")
+	}
+	return &BTree[T]{degree: degree, owner: &owner[T]{cmp: cmp}}
+}
+
+// NewOrdered returns an empty B-tree of the given degree for an ordered type, in ascending order.
+func NewOrdered[T constraints.Ordered](degree int) *BTree[T] {
+	return New(degree, compare[T])
+}
+
+func compare[T constraints.Ordered](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Len returns the number of items in the tree.
+func (t *BTree[T]) Len() int {
+	return t.len
+}
+
+// Degree returns the degree of the tree.
+func (t *BTree[T]) Degree() int {
+	return t.degree
+}
+
+func (t *BTree[T]) maxItems() int {
+	return 2*t.degree - 1
+}
+
+func (t *BTree[T]) minItems() int {
+	return t.degree - 1
+}
+
+// Clone returns a copy of the tree. Both trees share their nodes until they are modified.
+// Cursors of t stay valid.
+// The complexity is O(1); the following modifications of either tree copy the nodes they touch.
+func (t *BTree[T]) Clone() *BTree[T] {
+	// both trees get a new owner, so that neither modifies the shared nodes in place
+	c := *t
+	t.owner = &owner[T]{cmp: t.owner.cmp}
+	c.owner = &owner[T]{cmp: t.owner.cmp}
+	c.version = 0
+	return &c
+}
+
+// Clear removes every item from the tree.
+// The complexity is O(1).
+func (t *BTree[T]) Clear() {
+	t.root = nil
+	t.len = 0
+	t.version++
+}
+
+// find returns the index of the first item of n not less than item, and whether it is equal to item.
+func (n *node[T]) find(item T) (int, bool) {
+	cmp := n.owner.cmp
+	i := sort.Search(len(n.items), func(i int) bool { return cmp(item, n.items[i]) <= 0 })
+	return i, i < len(n.items) && cmp(item, n.items[i]) == 0
+}
+
+// mutable returns n if it is owned by o, and a copy of n owned by o otherwise.
+func (n *node[T]) mutable(o *owner[T]) *node[T] {
+	if n.owner == o {
+		return n
+	}
+	c := &node[T]{owner: o}
+	c.items = append(make([]T, 0, cap(n.items)), n.items...)
+	if len(n.children) > 0 {
+		c.children = append(make([]*node[T], 0, cap(n.children)), n.children...)
+	}
+	return c
+}
+
+// mutableChild makes the i-th child of n mutable by the owner of n, and returns it.
+func (n *node[T]) mutableChild(i int) *node[T] {
+	c := n.children[i].mutable(n.owner)
+	n.children[i] = c
+	return c
+}
+
+// split splits n at item i: n keeps the items before i, the returned node gets the items after it.
+func (n *node[T]) split(i int) (T, *node[T]) {
+	item := n.items[i]
+	next := &node[T]{owner: n.owner}
+	next.items = append(next.items, n.items[i+1:]...)
+	n.items = truncate(n.items, i)
+	if len(n.children) > 0 {
+		next.children = append(next.children, n.children[i+1:]...)
+		n.children = truncate(n.children, i+1)
+	}
+	return item, next
+}
+
+// maybeSplitChild splits the i-th child of n if it is full. Return true if it was split.
+func (n *node[T]) maybeSplitChild(i, maxItems int) bool {
+	if len(n.children[i].items) < maxItems {
+		return false
+	}
+	item, second := n.mutableChild(i).split(maxItems / 2)
+	n.items = insertAt(n.items, i, item)
+	n.children = insertAt(n.children, i+1, second)
+	return true
+}
+
+// insert inserts item in the subtree of n, which is not full. It returns the replaced item, if any.
+func (n *node[T]) insert(item T, maxItems int) (T, bool) {
+	i, found := n.find(item)
+	if found {
+		old := n.items[i]
+		n.items[i] = item
+		return old, true
+	}
+	if len(n.children) == 0 {
+		n.items = insertAt(n.items, i, item)
+		var zero T
+		return zero, false
+	}
+	if n.maybeSplitChild(i, maxItems) {
+		switch c := n.owner.cmp(item, n.items[i]); {
+		case c > 0:
+			i++
+		case c == 0:
+			old := n.items[i]
+			n.items[i] = item
+			return old, true
+		}
+	}
+	return n.mutableChild(i).insert(item, maxItems)
+}
+
+// removal selects the item removed by node.remove.
+type removal int
+
+const (
+	removeItem removal = iota
+	removeMin
+	removeMax
+)
+
+// remove removes an item from the subtree of n, whose root holds more than minItems items unless it is the root of
+// the tree. Return false if the item is not in the subtree.
+func (n *node[T]) remove(item T, minItems int, what removal) (T, bool) {
+	var zero T
+	var i int
+	var found bool
+	switch what {
+	case removeMin:
+		if len(n.children) == 0 {
+			out := n.items[0]
+			n.items = removeAt(n.items, 0)
+			return out, true
+		}
+	case removeMax:
+		if len(n.children) == 0 {
+			out := n.items[len(n.items)-1]
+			n.items = removeAt(n.items, len(n.items)-1)
+			return out, true
+		}
+		i = len(n.items)
+	default:
+		i, found = n.find(item)
+		if len(n.children) == 0 {
+			if !found {
+				return zero, false
+			}
+			out := n.items[i]
+			n.items = removeAt(n.items, i)
+			return out, true
+		}
+	}
+
+	// make sure the child we descend to can lose an item
+	if len(n.children[i].items) <= minItems {
+		n.growChild(i, minItems)
+		return n.remove(item, minItems, what)
+	}
+
+	child := n.mutableChild(i)
+	if found {
+		// replace the item by its predecessor, the maximum of the left subtree
+		out := n.items[i]
+		n.items[i], _ = child.remove(zero, minItems, removeMax)
+		return out, true
+	}
+	return child.remove(item, minItems, what)
+}
+
+// growChild gives the i-th child of n one more item, by taking one from a sibling through n, or by merging the child
+// with a sibling.
+func (n *node[T]) growChild(i, minItems int) {
+	switch {
+	case i > 0 && len(n.children[i-1].items) > minItems:
+		child, left := n.mutableChild(i), n.mutableChild(i-1)
+		child.items = insertAt(child.items, 0, n.items[i-1])
+		n.items[i-1] = left.items[len(left.items)-1]
+		left.items = removeAt(left.items, len(left.items)-1)
+		if len(left.children) > 0 {
+			child.children = insertAt(child.children, 0, left.children[len(left.children)-1])
+			left.children = removeAt(left.children, len(left.children)-1)
+		}
+
+	case i < len(n.items) && len(n.children[i+1].items) > minItems:
+		child, right := n.mutableChild(i), n.mutableChild(i+1)
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.items[0]
+		right.items = removeAt(right.items, 0)
+		if len(right.children) > 0 {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+
+	default:
+		if i >= len(n.items) {
+			i--
+		}
+		child, right := n.mutableChild(i), n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		child.items = append(child.items, right.items...)
+		child.children = append(child.children, right.children...)
+		n.items = removeAt(n.items, i)
+		n.children = removeAt(n.children, i+1)
+	}
+}
+
+// Put inserts item in the tree, replacing an equal item. It returns the replaced item, if any.
+// The complexity is O(log n).
+func (t *BTree[T]) Put(item T) (T, bool) {
+	t.version++
+	if t.root == nil {
+		t.root = &node[T]{owner: t.owner, items: []T{item}}
+		t.len++
+		var zero T
+		return zero, false
+	}
+
+	t.root = t.root.mutable(t.owner)
+	if len(t.root.items) >= t.maxItems() {
+		item2, second := t.root.split(t.maxItems() / 2)
+		t.root = &node[T]{owner: t.owner, items: []T{item2}, children: []*node[T]{t.root, second}}
+	}
+	old, replaced := t.root.insert(item, t.maxItems())
+	if !replaced {
+		t.len++
+	}
+	return old, replaced
+}
+
+// delete removes an item from the tree, and shrinks the tree if the root became empty.
+func (t *BTree[T]) delete(item T, what removal) (T, bool) {
+	if t.root == nil || len(t.root.items) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	t.root = t.root.mutable(t.owner)
+	out, ok := t.root.remove(item, t.minItems(), what)
+	if len(t.root.items) == 0 {
+		if len(t.root.children) > 0 {
+			t.root = t.root.children[0]
+		} else {
+			t.root = nil
+		}
+	}
+	if ok {
+		t.len--
+		t.version++
+	}
+	return out, ok
+}
+
+// Delete removes the item equal to item from the tree, and returns it. Return false if there is none.
+// The complexity is O(log n).
+func (t *BTree[T]) Delete(item T) (T, bool) {
+	return t.delete(item, removeItem)
+}
+
+// DeleteMin removes the smallest item from the tree, and returns it. Return false if the tree is empty.
+// The complexity is O(log n).
+func (t *BTree[T]) DeleteMin() (T, bool) {
+	var zero T
+	return t.delete(zero, removeMin)
+}
+
+// DeleteMax removes the largest item from the tree, and returns it. Return false if the tree is empty.
+// The complexity is O(log n).
+func (t *BTree[T]) DeleteMax() (T, bool) {
+	var zero T
+	return t.delete(zero, removeMax)
+}
+
+// Get returns the item equal to item. Return false if there is none.
+// The complexity is O(log n).
+func (t *BTree[T]) Get(item T) (T, bool) {
+	for n := t.root; n != nil; {
+		i, found := n.find(item)
+		if found {
+			return n.items[i], true
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	var zero T
+	return zero, false
+}
+
+// Contains reports whether an item equal to item is in the tree.
+// The complexity is O(log n).
+func (t *BTree[T]) Contains(item T) bool {
+	_, ok := t.Get(item)
+	return ok
+}
+
+// Min returns the smallest item. Return false if the tree is empty.
+// The complexity is O(log n).
+func (t *BTree[T]) Min() (T, bool) {
+	var zero T
+	if t.root == nil {
+		return zero, false
+	}
+	n := t.root
+	for len(n.children) > 0 {
+		n = n.children[0]
+	}
+	return n.items[0], true
+}
+
+// Max returns the largest item. Return false if the tree is empty.
+// The complexity is O(log n).
+func (t *BTree[T]) Max() (T, bool) {
+	var zero T
+	if t.root == nil {
+		return zero, false
+	}
+	n := t.root
+	for len(n.children) > 0 {
+		n = n.children[len(n.children)-1]
+	}
+	return n.items[len(n.items)-1], true
+}
+
+// WalkAscending calls f on every item in ascending order, until f returns false.
+// f must not modify the tree.
+func (t *BTree[T]) WalkAscending(f func(item T) bool) {
+	if t.root != nil {
+		t.root.ascend(nil, nil, f)
+	}
+}
+
+// WalkDescending calls f on every item in descending order, until f returns false.
+// f must not modify the tree.
+func (t *BTree[T]) WalkDescending(f func(item T) bool) {
+	if t.root != nil {
+		t.root.descend(f)
+	}
+}
+
+// Range calls f on the items in [lo, hi), in ascending order, until f returns false.
+// f must not modify the tree.
+// The complexity is O(log n + k), where k is the number of items visited.
+func (t *BTree[T]) Range(lo, hi T, f func(item T) bool) {
+	if t.root != nil {
+		t.root.ascend(&lo, &hi, f)
+	}
+}
+
+// ascend calls f on the items of the subtree of n in [lo, hi) in ascending order, a nil bound being unbounded.
+// Return false if f stopped the walk.
+func (n *node[T]) ascend(lo, hi *T, f func(item T) bool) bool {
+	i := 0
+	if lo != nil {
+		i, _ = n.find(*lo)
+	}
+	for ; i < len(n.items); i++ {
+		if len(n.children) > 0 && !n.children[i].ascend(lo, hi, f) {
+			return false
+		}
+		if hi != nil && n.owner.cmp(n.items[i], *hi) >= 0 {
+			return false
+		}
+		if !f(n.items[i]) {
+			return false
+		}
+		lo = nil // every following item is greater than lo
+	}
+	if len(n.children) > 0 {
+		return n.children[len(n.children)-1].ascend(lo, hi, f)
+	}
+	return true
+}
+
+// descend calls f on the items of the subtree of n in descending order. Return false if f stopped the walk.
+func (n *node[T]) descend(f func(item T) bool) bool {
+	for i := len(n.items) - 1; i >= 0; i-- {
+		if len(n.children) > 0 && !n.children[i+1].descend(f) {
+			return false
+		}
+		if !f(n.items[i]) {
+			return false
+		}
+	}
+	if len(n.children) > 0 {
+		return n.children[0].descend(f)
+	}
+	return true
+}
+
+func insertAt[E any](s []E, i int, e E) []E {
+	var zero E
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = e
+	return s
+}
+
+func removeAt[E any](s []E, i int) []E {
+	copy(s[i:], s[i+1:])
+	var zero E
+	s[len(s)-1] = zero
+	return s[:len(s)-1]
+}
+
+// truncate shortens s to n elements, clearing the dropped ones so that they can be garbage collected.
+func truncate[E any](s []E, n int) []E {
+	var zero E
+	for i := n; i < len(s); i++ {
+		s[i] = zero
+	}
+	return s[:n]
+}
@@ -0,0 +1,276 @@
+package btree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkTree verifies the B-tree invariants of t: node fill, children count, uniform leaf depth, item order and length.
+func checkTree[T any](t *testing.T, tr *BTree[T]) {
+	t.Helper()
+
+	if tr.root == nil {
+		if tr.len != 0 {
+			t.Errorf("empty tree has length %d", tr.len)
+		}
+		return
+	}
+
+	count := 0
+	leafDepth := -1
+	var last *T
+	var walk func(n *node[T], depth int)
+	walk = func(n *node[T], depth int) {
+		if n != tr.root && len(n.items) < tr.minItems() || len(n.items) > tr.maxItems() || len(n.items) == 0 {
+			t.Errorf("node at depth %d holds %d items", depth, len(n.items))
+		}
+		if len(n.children) == 0 {
+			if leafDepth < 0 {
+				leafDepth = depth
+			} else if depth != leafDepth {
+				t.Errorf("leaf at depth %d, want %d", depth, leafDepth)
+			}
+		} else if len(n.children) != len(n.items)+1 {
+			t.Errorf("node with %d items has %d children", len(n.items), len(n.children))
+		}
+
+		for i := range n.items {
+			if len(n.children) > 0 {
+				walk(n.children[i], depth+1)
+			}
+			if last != nil && tr.owner.cmp(*last, n.items[i]) >= 0 {
+				t.Errorf("item %v follows %v", n.items[i], *last)
+			}
+			last = &n.items[i]
+			count++
+		}
+		if len(n.children) > 0 {
+			walk(n.children[len(n.children)-1], depth+1)
+		}
+	}
+	walk(tr.root, 0)
+
+	if count != tr.Len() {
+		t.Errorf("tree holds %d items, tr.Len() = %d", count, tr.Len())
+	}
+}
+
+// items returns the items of t in ascending order.
+func items[T any](tr *BTree[T]) []T {
+	var out []T
+	tr.WalkAscending(func(item T) bool {
+		out = append(out, item)
+		return true
+	})
+	return out
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBTree(t *testing.T) {
+	tr := NewOrdered[int](2)
+	for _, v := range []int{5, 3, 8, 1, 4, 7, 9, 2, 6} {
+		if _, replaced := tr.Put(v); replaced {
+			t.Errorf("tr.Put(%d) replaced an item", v)
+		}
+		checkTree(t, tr)
+	}
+	if _, replaced := tr.Put(4); !replaced || tr.Len() != 9 {
+		t.Errorf("tr.Put(4) twice did not replace the item")
+	}
+
+	if !tr.Contains(6) || tr.Contains(10) {
+		t.Errorf("tr.Contains() is wrong")
+	}
+	if v, ok := tr.Min(); !ok || v != 1 {
+		t.Errorf("tr.Min() = %d, %v, want 1, true", v, ok)
+	}
+	if v, ok := tr.Max(); !ok || v != 9 {
+		t.Errorf("tr.Max() = %d, %v, want 9, true", v, ok)
+	}
+
+	if v, ok := tr.DeleteMin(); !ok || v != 1 {
+		t.Errorf("tr.DeleteMin() = %d, %v, want 1, true", v, ok)
+	}
+	if v, ok := tr.DeleteMax(); !ok || v != 9 {
+		t.Errorf("tr.DeleteMax() = %d, %v, want 9, true", v, ok)
+	}
+	if _, ok := tr.Delete(5); !ok {
+		t.Errorf("tr.Delete(5) = false, want true")
+	}
+	if _, ok := tr.Delete(5); ok {
+		t.Errorf("tr.Delete(5) twice = true, want false")
+	}
+	checkTree(t, tr)
+	if got := items(tr); !equalInts(got, []int{2, 3, 4, 6, 7, 8}) {
+		t.Errorf("items = %v, want [2 3 4 6 7 8]", got)
+	}
+
+	tr.Clear()
+	if _, ok := tr.DeleteMin(); ok || tr.Len() != 0 {
+		t.Errorf("tr is not empty after Clear")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(1) did not panic")
+		}
+	}()
+	NewOrdered[int](1)
+}
+
+func TestBTreeMap(t *testing.T) {
+	type kv struct {
+		k string
+		v int
+	}
+	tr := New(4, func(a, b kv) int {
+		switch {
+		case a.k < b.k:
+			return -1
+		case a.k > b.k:
+			return 1
+		}
+		return 0
+	})
+	tr.Put(kv{"b", 2})
+	tr.Put(kv{"a", 1})
+	old, _ := tr.Put(kv{"b", 20})
+	if old.v != 2 {
+		t.Errorf("tr.Put(b) replaced %v, want {b 2}", old)
+	}
+	if got, _ := tr.Get(kv{k: "b"}); got.v != 20 {
+		t.Errorf("tr.Get(b) = %v, want {b 20}", got)
+	}
+}
+
+func TestBTreeRange(t *testing.T) {
+	tr := NewOrdered[int](3)
+	for v := 0; v < 100; v++ {
+		tr.Put(v)
+	}
+
+	var got []int
+	tr.Range(17, 42, func(v int) bool {
+		got = append(got, v)
+		return true
+	})
+	if len(got) != 25 || got[0] != 17 || got[24] != 41 {
+		t.Errorf("tr.Range(17, 42) = %v, want [17..41]", got)
+	}
+
+	got = got[:0]
+	tr.Range(90, 200, func(v int) bool {
+		got = append(got, v)
+		return v < 95
+	})
+	if len(got) != 6 || got[5] != 95 {
+		t.Errorf("tr.Range(90, 200) stopped at %v, want [90..95]", got)
+	}
+
+	got = got[:0]
+	tr.WalkDescending(func(v int) bool {
+		got = append(got, v)
+		return len(got) < 3
+	})
+	if !equalInts(got, []int{99, 98, 97}) {
+		t.Errorf("tr.WalkDescending() = %v, want [99 98 97]", got)
+	}
+}
+
+func TestBTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(39))
+	for _, degree := range []int{2, 3, 8} {
+		tr := NewOrdered[int](degree)
+		model := make(map[int]bool)
+
+		for i := 0; i < 3000; i++ {
+			v := r.Intn(500)
+			switch r.Intn(5) {
+			case 0:
+				_, ok := tr.Delete(v)
+				if ok != model[v] {
+					t.Fatalf("degree %d: tr.Delete(%d) = %v, want %v", degree, v, ok, model[v])
+				}
+				delete(model, v)
+			case 1:
+				want := make([]int, 0, len(model))
+				for k := range model {
+					want = append(want, k)
+				}
+				sort.Ints(want)
+				got, ok := tr.DeleteMin()
+				if ok != (len(want) > 0) || ok && got != want[0] {
+					t.Fatalf("degree %d: tr.DeleteMin() = %d, %v", degree, got, ok)
+				}
+				delete(model, got)
+			default:
+				tr.Put(v)
+				model[v] = true
+			}
+			if i%300 == 0 {
+				checkTree(t, tr)
+			}
+		}
+		checkTree(t, tr)
+
+		want := make([]int, 0, len(model))
+		for k := range model {
+			want = append(want, k)
+		}
+		sort.Ints(want)
+		if got := items(tr); !equalInts(got, want) {
+			t.Errorf("degree %d: items differ from the model", degree)
+		}
+	}
+}
+
+func TestBTreeClone(t *testing.T) {
+	tr := NewOrdered[int](2)
+	for v := 0; v < 50; v++ {
+		tr.Put(v)
+	}
+	c := tr.MinCursor()
+
+	snap := tr.Clone()
+	if !c.IsValid() || c.Value() != 0 {
+		t.Errorf("Clone invalidated the cursors of the tree")
+	}
+
+	for v := 0; v < 50; v += 2 {
+		tr.Delete(v)
+	}
+	if c.IsValid() {
+		t.Errorf("the cursor is still valid after its item was deleted")
+	}
+	tr.Put(100)
+	snap.Put(-1)
+	checkTree(t, tr)
+	checkTree(t, snap)
+
+	if tr.Len() != 26 || snap.Len() != 51 {
+		t.Errorf("tr.Len(), snap.Len() = %d, %d, want 26, 51", tr.Len(), snap.Len())
+	}
+	if tr.Contains(-1) || snap.Contains(100) || !snap.Contains(10) || tr.Contains(10) {
+		t.Errorf("a modification leaked between a tree and its clone")
+	}
+
+	// a clone of a clone
+	snap2 := snap.Clone()
+	snap.Clear()
+	if snap2.Len() != 51 || snap2.Contains(100) {
+		t.Errorf("snap2 changed with snap")
+	}
+	checkTree(t, snap2)
+}
@@ -0,0 +1,51 @@
+package btree_test
+
+import (
+	"testing"
+
+	"github.com/nnhatnam/skale/container"
+	"github.com/nnhatnam/skale/container/containertest"
+	"github.com/nnhatnam/skale/tree/btree"
+)
+
+var (
+	_ container.OrderedSet[int]       = (*btree.BTree[int])(nil)
+	_ container.Iterable[int]         = (*btree.BTree[int])(nil)
+	_ container.BidiCursor[int, bool] = boolCursor{}
+)
+
+// boolCursor adapts a btree.Cursor to container.BidiCursor, with the boolean returned by MoveNext and MovePrev as its
+// element.
+type boolCursor struct {
+	*btree.Cursor[int]
+}
+
+func (c boolCursor) MoveNext() bool {
+	_, ok := c.Cursor.MoveNext()
+	return ok
+}
+
+func (c boolCursor) MovePrev() bool {
+	_, ok := c.Cursor.MovePrev()
+	return ok
+}
+
+func TestBTreeConformance(t *testing.T) {
+	containertest.TestOrderedSet(t, func() container.OrderedSet[int] {
+		return btree.NewOrdered[int](2)
+	})
+
+	containertest.TestIterable(t, func(values []int) container.Iterable[int] {
+		return btree.FromSorted(2, func(a, b int) int { return a - b }, values)
+	}, func(v int) int { return v })
+}
+
+func TestCursorConformance(t *testing.T) {
+	containertest.TestBidiCursor(t, func(values []int) container.BidiCursor[int, bool] {
+		tr := btree.NewOrdered[int](2)
+		for _, v := range values {
+			tr.Put(v)
+		}
+		return boolCursor{tr.Cursor()}
+	})
+}
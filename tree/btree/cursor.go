@@ -0,0 +1,250 @@
+package btree
+
+// frame is a level of the path of a cursor. In the last frame, i is the index of the item the cursor points to;
+// in the other frames, i is the index of the child the path goes through.
+type frame[T any] struct {
+	n *node[T]
+	i int
+}
+
+// Cursor points to an item of a BTree, or to the sentinel position that stands before the smallest item and after the
+// largest one. It mirrors linkedlist.Cursor: MoveNext and MovePrev walk the items in order and return the item they
+// reach, or false when they reach the sentinel position, and Value panics if the cursor is not valid. Items are not
+// nodes, so MoveNext and MovePrev return the item itself and a boolean, rather than a nil node at the sentinel
+// position.
+//
+// Items move between nodes when the tree is modified, so a cursor keeps a copy of its item and finds it again after
+// the tree changes, in O(log n). A cursor becomes invalid when its item is deleted.
+type Cursor[T any] struct {
+	tree    *BTree[T]
+	version int        // version of tree when path was computed
+	path    []frame[T] // empty at the sentinel position
+	item    T          // the item of the last frame of path
+}
+
+// Cursor returns a cursor pointing to the sentinel position: MoveNext moves it to the smallest item, MovePrev to the
+// largest.
+func (t *BTree[T]) Cursor() *Cursor[T] {
+	return &Cursor[T]{tree: t, version: t.version}
+}
+
+// MinCursor returns a cursor pointing to the smallest item, or to the sentinel position if the tree is empty.
+func (t *BTree[T]) MinCursor() *Cursor[T] {
+	c := t.Cursor()
+	c.MoveNext()
+	return c
+}
+
+// MaxCursor returns a cursor pointing to the largest item, or to the sentinel position if the tree is empty.
+func (t *BTree[T]) MaxCursor() *Cursor[T] {
+	c := t.Cursor()
+	c.MovePrev()
+	return c
+}
+
+// Seek returns a cursor pointing to the smallest item greater than or equal to item, or to the sentinel position if
+// there is none.
+// The complexity is O(log n).
+func (t *BTree[T]) Seek(item T) *Cursor[T] {
+	c := t.Cursor()
+	c.seek(item)
+	return c
+}
+
+// seek moves the cursor to the smallest item greater than or equal to item, or to the sentinel position if there is
+// none. Return true if the item is equal to item.
+func (c *Cursor[T]) seek(item T) bool {
+	c.path = c.path[:0]
+	for n := c.tree.root; n != nil; {
+		i, found := n.find(item)
+		c.path = append(c.path, frame[T]{n, i})
+		if found {
+			c.sync()
+			return true
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	if len(c.path) > 0 {
+		// the search ended past the last item of a leaf: the item is the next one up the path
+		c.path[len(c.path)-1].i--
+		c.next()
+	}
+	c.sync()
+	return false
+}
+
+// sync copies the item the path leads to into c.item, or the zero value at the sentinel position.
+func (c *Cursor[T]) sync() {
+	if len(c.path) == 0 {
+		var zero T
+		c.item = zero
+		return
+	}
+	top := c.path[len(c.path)-1]
+	c.item = top.n.items[top.i]
+}
+
+// Equal returns true if the two cursors point to the same item of the same tree.
+// If either cursor is not valid, it returns false.
+func (c *Cursor[T]) Equal(c2 *Cursor[T]) bool {
+	if !c.IsValid() || !c2.IsValid() || c.tree != c2.tree || len(c.path) != len(c2.path) {
+		return false
+	}
+	if len(c.path) == 0 {
+		return true
+	}
+	top, top2 := c.path[len(c.path)-1], c2.path[len(c2.path)-1]
+	return top.n == top2.n && top.i == top2.i
+}
+
+// Value returns the item that the cursor points to, the zero value if it points to the sentinel position.
+// If the cursor is not valid, it will panic.
+func (c *Cursor[T]) Value() T {
+	if !c.IsValid() {
+		panic("btree: cursor is not valid when calling Value()")
+	}
+	return c.item
+}
+
+// Clone creates a new cursor that points to the same item as the current cursor.
+// Return nil if the current cursor is not valid.
+func (c *Cursor[T]) Clone() *Cursor[T] {
+	if !c.IsValid() {
+		return nil
+	}
+	c2 := *c
+	c2.path = append([]frame[T](nil), c.path...)
+	return &c2
+}
+
+// descend appends to the path the leftmost (first) or rightmost (last) path of the subtree of n.
+func (c *Cursor[T]) descend(n *node[T], first bool) {
+	for {
+		i := 0
+		if !first {
+			i = len(n.items) - 1
+			if len(n.children) > 0 {
+				i = len(n.children) - 1
+			}
+		}
+		c.path = append(c.path, frame[T]{n, i})
+		if len(n.children) == 0 {
+			return
+		}
+		n = n.children[i]
+	}
+}
+
+// MoveNext moves the cursor to the next item and returns it. Move to the sentinel position and return false if the
+// cursor is pointing to the largest item. From the sentinel position, it moves to the smallest item.
+// Return false if the cursor is not valid.
+// The complexity is O(log n), and O(1) amortized over a full iteration.
+func (c *Cursor[T]) MoveNext() (T, bool) {
+	if !c.IsValid() {
+		var zero T
+		return zero, false
+	}
+	ok := c.next()
+	c.sync()
+	return c.item, ok
+}
+
+// next moves the path to the next item. Return false if it reaches the sentinel position.
+func (c *Cursor[T]) next() bool {
+	if len(c.path) == 0 {
+		if c.tree.root == nil {
+			return false
+		}
+		c.descend(c.tree.root, true)
+		return true
+	}
+
+	top := &c.path[len(c.path)-1]
+	if len(top.n.children) > 0 {
+		top.i++
+		c.descend(top.n.children[top.i], true)
+		return true
+	}
+	if top.i++; top.i < len(top.n.items) {
+		return true
+	}
+	// climb until a node has an item after the child the path goes through
+	for c.path = c.path[:len(c.path)-1]; len(c.path) > 0; c.path = c.path[:len(c.path)-1] {
+		if top := c.path[len(c.path)-1]; top.i < len(top.n.items) {
+			return true
+		}
+	}
+	return false
+}
+
+// MovePrev moves the cursor to the previous item and returns it. Move to the sentinel position and return false if
+// the cursor is pointing to the smallest item. From the sentinel position, it moves to the largest item.
+// Return false if the cursor is not valid.
+// The complexity is O(log n), and O(1) amortized over a full iteration.
+func (c *Cursor[T]) MovePrev() (T, bool) {
+	if !c.IsValid() {
+		var zero T
+		return zero, false
+	}
+	ok := c.prev()
+	c.sync()
+	return c.item, ok
+}
+
+// prev moves the path to the previous item. Return false if it reaches the sentinel position.
+func (c *Cursor[T]) prev() bool {
+	if len(c.path) == 0 {
+		if c.tree.root == nil {
+			return false
+		}
+		c.descend(c.tree.root, false)
+		return true
+	}
+
+	top := &c.path[len(c.path)-1]
+	if len(top.n.children) > 0 {
+		c.descend(top.n.children[top.i], false)
+		return true
+	}
+	if top.i--; top.i >= 0 {
+		return true
+	}
+	// climb until a node has an item before the child the path goes through
+	for c.path = c.path[:len(c.path)-1]; len(c.path) > 0; c.path = c.path[:len(c.path)-1] {
+		if top := &c.path[len(c.path)-1]; top.i > 0 {
+			top.i--
+			return true
+		}
+	}
+	return false
+}
+
+// Close closes the cursor. A closed cursor is not valid.
+func (c *Cursor[T]) Close() {
+	var zero T
+	c.tree = nil
+	c.path = nil
+	c.item = zero
+}
+
+// IsValid detects if the cursor is valid.
+// A cursor is not valid if it is closed, or if its item has been deleted from the tree. After the tree is modified,
+// the cursor finds its item again, or the equal item that replaced it.
+// If the cursor is not valid, Close() will be called automatically.
+func (c *Cursor[T]) IsValid() bool {
+	if c.tree == nil {
+		c.Close()
+		return false
+	}
+	if c.version != c.tree.version {
+		c.version = c.tree.version
+		if len(c.path) > 0 && !c.seek(c.item) {
+			c.Close()
+			return false
+		}
+	}
+	return true
+}
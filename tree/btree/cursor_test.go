@@ -0,0 +1,125 @@
+package btree
+
+import "testing"
+
+func TestCursor(t *testing.T) {
+	for _, n := range []int{0, 1, 7, 100} {
+		tr := NewOrdered[int](2)
+		for v := 0; v < n; v++ {
+			tr.Put(v)
+		}
+
+		c := tr.Cursor()
+		i := 0
+		for v, ok := c.MoveNext(); ok; v, ok = c.MoveNext() {
+			if v != i || c.Value() != i {
+				t.Fatalf("n %d: ascending value %d, %d, want %d", n, v, c.Value(), i)
+			}
+			i++
+		}
+		if i != n || !c.IsValid() {
+			t.Errorf("n %d: ascending iteration visited %d items", n, i)
+		}
+
+		// from the sentinel position, MovePrev wraps to the largest item
+		for v, ok := c.MovePrev(); ok; v, ok = c.MovePrev() {
+			i--
+			if v != i || c.Value() != i {
+				t.Fatalf("n %d: descending value %d, %d, want %d", n, v, c.Value(), i)
+			}
+		}
+		if i != 0 {
+			t.Errorf("n %d: descending iteration stopped at %d", n, i)
+		}
+	}
+}
+
+func TestCursorSeek(t *testing.T) {
+	tr := NewOrdered[int](2)
+	for v := 0; v < 100; v += 5 {
+		tr.Put(v)
+	}
+
+	for v := -1; v <= 100; v++ {
+		c := tr.Seek(v)
+		want := (v + 4) / 5 * 5
+		if v < 0 {
+			want = 0
+		}
+		if want >= 100 {
+			if v, _ := c.MovePrev(); v != 95 {
+				t.Errorf("tr.Seek(%d) is not at the sentinel position", v)
+			}
+			continue
+		}
+		if c.Value() != want {
+			t.Errorf("tr.Seek(%d) = %d, want %d", v, c.Value(), want)
+		}
+		if prev, ok := c.MovePrev(); ok && prev != want-5 {
+			t.Errorf("tr.Seek(%d).MovePrev() = %d, want %d", v, prev, want-5)
+		}
+	}
+
+	c, c2 := tr.Seek(50), tr.Seek(48)
+	if !c.Equal(c2) || !c.Equal(c.Clone()) {
+		t.Errorf("cursors to the same item are not equal")
+	}
+	c2.MoveNext()
+	if c.Equal(c2) {
+		t.Errorf("cursors to different items are equal")
+	}
+	if tr.MinCursor().Value() != 0 || tr.MaxCursor().Value() != 95 {
+		t.Errorf("tr.MinCursor(), tr.MaxCursor() = %d, %d, want 0, 95", tr.MinCursor().Value(), tr.MaxCursor().Value())
+	}
+
+	if v := tr.Cursor().Value(); v != 0 {
+		t.Errorf("Value() at the sentinel position = %d, want 0", v)
+	}
+}
+
+func TestCursorModification(t *testing.T) {
+	tr := NewOrdered[int](2)
+	for v := 0; v < 100; v += 5 {
+		tr.Put(v)
+	}
+	c, sentinel := tr.Seek(50), tr.Cursor()
+
+	// the cursor finds its item again after changes that do not delete it, splits and merges of nodes included
+	for v := 1; v < 100; v += 5 {
+		tr.Put(v)
+	}
+	for v := 0; v < 50; v += 5 {
+		tr.Delete(v)
+	}
+	clone := tr.Clone()
+	clone.Delete(50)
+	if !c.IsValid() || c.Value() != 50 {
+		t.Fatalf("after unrelated changes, the cursor is at %d (valid %v), want 50", c.Value(), c.IsValid())
+	}
+	if v, ok := c.MoveNext(); !ok || v != 51 {
+		t.Errorf("c.MoveNext() = %d, %v, want 51", v, ok)
+	}
+	if v, ok := c.MovePrev(); !ok || v != 50 {
+		t.Errorf("c.MovePrev() = %d, %v, want 50", v, ok)
+	}
+
+	// deleting its item invalidates the cursor, not the ones at the sentinel position
+	tr.Delete(50)
+	if c.IsValid() || c.Clone() != nil {
+		t.Errorf("the cursor is still valid after its item was deleted")
+	}
+	if v, ok := c.MoveNext(); ok || v != 0 {
+		t.Errorf("c.MoveNext() of an invalid cursor = %d, %v, want 0, false", v, ok)
+	}
+	tr.Clear()
+	if !sentinel.IsValid() || sentinel.Value() != 0 {
+		t.Errorf("the cursor at the sentinel position is not valid after Clear")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Value() of an invalid cursor did not panic")
+		}
+	}()
+	c.Value()
+}
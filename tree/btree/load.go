@@ -0,0 +1,81 @@
+package btree
+
+import "github.com/nnhatnam/skale/list/linkedlist"
+
+// FromSorted returns a B-tree of the given degree holding items, which must be in strictly ascending order.
+// The tree is built bottom-up with evenly filled nodes, without comparing items again.
+// It panics if degree is less than 2 or if items are not strictly ascending.
+// The complexity is O(n).
+func FromSorted[T any](degree int, cmp func(a, b T) int, items []T) *BTree[T] {
+	t := New(degree, cmp)
+	for i := 1; i < len(items); i++ {
+		if cmp(items[i-1], items[i]) >= 0 {
+			panic("btree: items are not sorted")
+		}
+	}
+	if len(items) == 0 {
+		return t
+	}
+
+	height := 1
+	for capacity(degree, height) < len(items) {
+		height++
+	}
+	t.root = t.build(items, height, true)
+	t.len = len(items)
+	return t
+}
+
+// FromSortedList is like FromSorted but reads the items from list l, which is left unchanged.
+// The complexity is O(n).
+func FromSortedList[T any](degree int, cmp func(a, b T) int, l *linkedlist.List[T]) *BTree[T] {
+	items := make([]T, 0, l.Len())
+	c := l.Cursor()
+	for n := c.MoveNext(); n != nil; n = c.MoveNext() {
+		items = append(items, n.Value)
+	}
+	return FromSorted(degree, cmp, items)
+}
+
+// capacity returns the number of items of a full subtree of the given height.
+func capacity(degree, height int) int {
+	c := 1
+	for i := 0; i < height; i++ {
+		c *= 2 * degree
+	}
+	return c - 1
+}
+
+// build returns a subtree of the given height holding items. The children of a node share its items evenly; the root
+// has as few children as possible, other nodes at least degree children, so that every node holds enough items.
+func (t *BTree[T]) build(items []T, height int, root bool) *node[T] {
+	n := &node[T]{owner: t.owner}
+	if height == 1 {
+		n.items = append(make([]T, 0, t.maxItems()), items...)
+		return n
+	}
+
+	sub := capacity(t.degree, height-1)
+	k := (len(items) + sub + 1) / (sub + 1) // ceil((len(items)+1) / (sub+1))
+	if !root && k < t.degree {
+		k = t.degree
+	}
+
+	n.items = make([]T, 0, t.maxItems())
+	n.children = make([]*node[T], 0, t.maxItems()+1)
+	rest := len(items) - (k - 1)
+	pos := 0
+	for i := 0; i < k; i++ {
+		size := rest / k
+		if i < rest%k {
+			size++
+		}
+		n.children = append(n.children, t.build(items[pos:pos+size], height-1, false))
+		pos += size
+		if i < k-1 {
+			n.items = append(n.items, items[pos])
+			pos++
+		}
+	}
+	return n
+}
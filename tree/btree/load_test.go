@@ -0,0 +1,48 @@
+package btree
+
+import (
+	"testing"
+
+	"github.com/nnhatnam/skale/list/linkedlist"
+)
+
+func TestFromSorted(t *testing.T) {
+	for _, degree := range []int{2, 3, 5} {
+		for n := 0; n < 400; n++ {
+			vs := make([]int, n)
+			for i := range vs {
+				vs[i] = 2 * i
+			}
+			tr := FromSorted(degree, compare[int], vs)
+			checkTree(t, tr)
+			if !equalInts(items(tr), vs) && n > 0 {
+				t.Fatalf("degree %d, n %d: items differ", degree, n)
+			}
+
+			// the loaded tree supports every operation
+			tr.Put(1)
+			tr.Delete(0)
+			tr.DeleteMax()
+			checkTree(t, tr)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("FromSorted() of unsorted items did not panic")
+		}
+	}()
+	FromSorted(2, compare[int], []int{1, 3, 2})
+}
+
+func TestFromSortedList(t *testing.T) {
+	l := linkedlist.From(1, 2, 3, 5, 8, 13)
+	tr := FromSortedList(2, compare[int], l)
+	checkTree(t, tr)
+	if got := items(tr); !equalInts(got, []int{1, 2, 3, 5, 8, 13}) {
+		t.Errorf("items = %v, want [1 2 3 5 8 13]", got)
+	}
+	if l.Len() != 6 {
+		t.Errorf("l.Len() = %d, want 6", l.Len())
+	}
+}
@@ -0,0 +1,62 @@
+package radix_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nnhatnam/skale/container"
+	"github.com/nnhatnam/skale/container/containertest"
+	"github.com/nnhatnam/skale/tree/radix"
+)
+
+var (
+	_ container.Iterable[*radix.Leaf[int]]        = (*radix.Radix[int])(nil)
+	_ container.Iterable[*radix.Leaf[int]]        = (*radix.Tree[int])(nil)
+	_ container.BidiCursor[int, *radix.Leaf[int]] = (*radix.Cursor[int])(nil)
+)
+
+// key returns a key for v, in the order of the non-negative values.
+func key(v int) string {
+	return fmt.Sprintf("%08d", v)
+}
+
+// fromValues returns a tree mapping a key of every value to the value.
+func fromValues(values []int) *radix.Radix[int] {
+	r := radix.New[int]()
+	for _, v := range values {
+		r.Insert(key(v), v)
+	}
+	return r
+}
+
+// treeFromValues is like fromValues, for an immutable tree.
+func treeFromValues(values []int) *radix.Tree[int] {
+	txn := radix.NewTree[int]().Txn()
+	for _, v := range values {
+		txn.Insert(key(v), v)
+	}
+	return txn.Commit()
+}
+
+func leafValue(l *radix.Leaf[int]) int { return l.Value }
+
+func TestRadixConformance(t *testing.T) {
+	containertest.TestIterable(t, func(values []int) container.Iterable[*radix.Leaf[int]] {
+		return fromValues(values)
+	}, leafValue)
+}
+
+func TestTreeConformance(t *testing.T) {
+	containertest.TestIterable(t, func(values []int) container.Iterable[*radix.Leaf[int]] {
+		return treeFromValues(values)
+	}, leafValue)
+}
+
+func TestCursorConformance(t *testing.T) {
+	containertest.TestBidiCursor(t, func(values []int) container.BidiCursor[int, *radix.Leaf[int]] {
+		return fromValues(values).Cursor()
+	})
+	containertest.TestBidiCursor(t, func(values []int) container.BidiCursor[int, *radix.Leaf[int]] {
+		return treeFromValues(values).Cursor()
+	})
+}
@@ -0,0 +1,144 @@
+package radix
+
+// Cursor points to a key of a Radix or a Tree, or to the sentinel node that stands before the smallest key and after
+// the largest one. It follows the contract of linkedlist.Cursor: MoveNext and MovePrev walk the keys in order and
+// return nil when they reach the sentinel node, a cursor becomes invalid when its key is deleted, and Value panics on
+// an invalid cursor. So does Key, and both panic at the sentinel node too: the empty string is a key like the others.
+//
+// A cursor of a Radix keeps the path to its key, and finds it again after the keys of the tree change, in O(k) for a
+// key of length k.
+type Cursor[V any] struct {
+	radix   *Radix[V] // nil for a cursor of a Tree, which never changes
+	root    *node[V]  // nil once the cursor is closed
+	version int       // version of radix when path was computed
+	path    []*node[V]
+	leaf    *Leaf[V] // nil at the sentinel node
+}
+
+// Equal returns true if the two cursors point to the same key in the same tree.
+// If either cursor is not valid, it returns false.
+func (c *Cursor[V]) Equal(c2 *Cursor[V]) bool {
+	if !c.IsValid() || !c2.IsValid() {
+		return false
+	}
+	return c.radix == c2.radix && c.root == c2.root && c.leaf == c2.leaf
+}
+
+// Key returns the key that the cursor points to.
+// If the cursor points to the sentinel node or is not valid, it will panic.
+func (c *Cursor[V]) Key() string {
+	l := c.Leaf()
+	if l == nil {
+		panic("radix: cursor is not valid or at the sentinel node when calling Key()")
+	}
+	return l.Key
+}
+
+// Value returns the value of the key that the cursor points to.
+// If the cursor points to the sentinel node or is not valid, it will panic.
+func (c *Cursor[V]) Value() V {
+	l := c.Leaf()
+	if l == nil {
+		panic("radix: cursor is not valid or at the sentinel node when calling Value()")
+	}
+	return l.Value
+}
+
+// Set sets the value of the key that the cursor points to. Return false if the cursor points to the sentinel node, is
+// not valid, or is a cursor of a Tree, which cannot be modified.
+func (c *Cursor[V]) Set(v V) bool {
+	if l := c.Leaf(); l != nil && c.radix != nil {
+		l.Value = v
+		return true
+	}
+	return false
+}
+
+// Leaf returns the leaf that the cursor points to. Return nil if the cursor points to the sentinel node or is not valid.
+func (c *Cursor[V]) Leaf() *Leaf[V] {
+	if c.IsValid() {
+		return c.leaf
+	}
+	return nil
+}
+
+// Clone creates a new cursor that points to the same key as the current cursor.
+// Return nil if the current cursor is not valid.
+func (c *Cursor[V]) Clone() *Cursor[V] {
+	if !c.IsValid() {
+		return nil
+	}
+	c2 := *c
+	c2.path = append([]*node[V](nil), c.path...)
+	return &c2
+}
+
+// MoveNext moves the cursor to the next key and return its leaf.
+// Move to the sentinel node and return nil if the cursor is pointing to the largest key.
+// From the sentinel node, it moves to the smallest key. Return nil if the cursor is not valid.
+// The complexity is O(k), and O(1) amortized over a full iteration.
+func (c *Cursor[V]) MoveNext() *Leaf[V] {
+	if !c.IsValid() {
+		return nil
+	}
+	if c.leaf == nil {
+		return c.moveTo(first([]*node[V]{c.root}))
+	}
+	return c.moveTo(next(c.path))
+}
+
+// MovePrev moves the cursor to the previous key and return its leaf.
+// Move to the sentinel node and return nil if the cursor is pointing to the smallest key.
+// From the sentinel node, it moves to the largest key. Return nil if the cursor is not valid.
+// The complexity is O(k), and O(1) amortized over a full iteration.
+func (c *Cursor[V]) MovePrev() *Leaf[V] {
+	if !c.IsValid() {
+		return nil
+	}
+	if c.leaf == nil {
+		return c.moveTo(last([]*node[V]{c.root}))
+	}
+	return c.moveTo(prev(c.path))
+}
+
+// moveTo moves the cursor to the key at the end of path, to the sentinel node if path is nil, and returns its leaf.
+func (c *Cursor[V]) moveTo(path []*node[V]) *Leaf[V] {
+	c.path, c.leaf = path, nil
+	if len(path) > 0 {
+		c.leaf = path[len(path)-1].leaf
+	}
+	return c.leaf
+}
+
+// Close closes the cursor. A closed cursor is not valid.
+func (c *Cursor[V]) Close() {
+	c.radix = nil
+	c.root = nil
+	c.path = nil
+	c.leaf = nil
+}
+
+// IsValid detects if the cursor is valid.
+// A cursor is not valid if it is closed, or it is pointing to a key that has been deleted.
+// If the cursor is not valid, Close() will be called automatically.
+func (c *Cursor[V]) IsValid() bool {
+	if c.root == nil {
+		return false
+	}
+	if c.radix == nil || c.version == c.radix.version {
+		return true
+	}
+
+	// the keys of the tree changed: find the key again
+	c.root, c.version = c.radix.root, c.radix.version
+	if c.leaf == nil {
+		c.path = nil
+		return true
+	}
+	key := c.leaf.Key
+	if c.moveTo(seek(c.root, key)) == nil || c.leaf.Key != key {
+		c.Close()
+		return false
+	}
+	return true
+}
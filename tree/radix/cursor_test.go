@@ -0,0 +1,187 @@
+package radix
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ascending returns the keys visited by moving c forward until the sentinel node.
+func ascending(c *Cursor[int]) []string {
+	keys := []string{}
+	for l := c.MoveNext(); l != nil; l = c.MoveNext() {
+		keys = append(keys, l.Key)
+	}
+	return keys
+}
+
+func TestCursor(t *testing.T) {
+	r := New[int]()
+	keys := []string{"", "a", "ab", "abc", "abd", "b", "ba"}
+	for i, k := range keys {
+		r.Insert(k, i)
+	}
+
+	c := r.Cursor()
+	if got := ascending(c); !reflect.DeepEqual(got, keys) {
+		t.Errorf("ascending iteration = %q, want %q", got, keys)
+	}
+	if c.Leaf() != nil || !c.IsValid() {
+		t.Errorf("the cursor is not at the sentinel node after the iteration")
+	}
+
+	var got []string
+	for l := c.MovePrev(); l != nil; l = c.MovePrev() {
+		got = append(got, l.Key)
+	}
+	for i, j := 0, len(got)-1; i < j; i, j = i+1, j-1 {
+		got[i], got[j] = got[j], got[i]
+	}
+	if !reflect.DeepEqual(got, keys) {
+		t.Errorf("descending iteration reversed = %q, want %q", got, keys)
+	}
+
+	c = r.MaxCursor()
+	if c.Key() != "ba" || c.Value() != 6 {
+		t.Errorf("MaxCursor() = %q, %d, want ba, 6", c.Key(), c.Value())
+	}
+	if !c.Set(60) {
+		t.Errorf("c.Set(60) = false")
+	}
+	if v, _ := r.Get("ba"); v != 60 {
+		t.Errorf("Get(ba) = %d after c.Set(60)", v)
+	}
+
+	c2 := c.Clone()
+	if !c.Equal(c2) {
+		t.Errorf("c.Equal(c.Clone()) = false")
+	}
+	c2.MovePrev()
+	if c.Equal(c2) || c2.Key() != "b" {
+		t.Errorf("c2.MovePrev() = %q, want b", c2.Key())
+	}
+
+	if c := r.MinCursor(); c.Key() != "" || c.Leaf() == nil {
+		t.Errorf("MinCursor() = %q, want the empty key", c.Key())
+	}
+	if c := New[int]().MinCursor(); c.Leaf() != nil || !c.IsValid() {
+		t.Errorf("MinCursor() of an empty tree is not at the sentinel node")
+	}
+}
+
+func TestSeek(t *testing.T) {
+	r := New[int]()
+	for i, k := range []string{"apple", "apricot", "banana", "band", "bandana", "cherry"} {
+		r.Insert(k, i)
+	}
+	tests := []struct {
+		key, want string
+	}{
+		{"", "apple"},
+		{"apple", "apple"},
+		{"applf", "apricot"},
+		{"ap", "apple"},
+		{"aq", "banana"},
+		{"ban", "banana"},
+		{"banb", "band"},
+		{"band", "band"},
+		{"banda", "bandana"},
+		{"bandanas", "cherry"},
+		{"c", "cherry"},
+		{"d", ""},
+	}
+	for _, tt := range tests {
+		got := "" // at the sentinel node
+		l := r.Seek(tt.key).Leaf()
+		if l != nil {
+			got = l.Key
+		}
+		if got != tt.want || (tt.want == "") != (l == nil) {
+			t.Errorf("Seek(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	c := r.Seek("band")
+	if got, want := ascending(c), []string{"bandana", "cherry"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("iteration from Seek(band) = %q, want %q", got, want)
+	}
+}
+
+func TestCursorInvalidation(t *testing.T) {
+	r := New[int]()
+	for i, k := range []string{"a", "ab", "abc", "b"} {
+		r.Insert(k, i)
+	}
+	c := r.Seek("ab")
+	sentinel := r.Cursor()
+
+	// the cursor follows its key through changes of the tree
+	r.Insert("aa", 10)
+	r.Delete("a")
+	r.Delete("abc")
+	if !c.IsValid() || c.Key() != "ab" || c.Value() != 1 {
+		t.Errorf("after unrelated changes, the cursor is at %q (valid %v), want ab", c.Key(), c.IsValid())
+	}
+	if l := c.MovePrev(); l == nil || l.Key != "aa" {
+		t.Errorf("c.MovePrev() = %v, want aa", l)
+	}
+	if l := c.MoveNext(); l == nil || l.Key != "ab" {
+		t.Errorf("c.MoveNext() = %v, want ab", l)
+	}
+
+	r.Delete("ab")
+	if c.IsValid() || c.MoveNext() != nil {
+		t.Errorf("the cursor is still valid after its key was deleted")
+	}
+	if !sentinel.IsValid() {
+		t.Errorf("the cursor at the sentinel node is not valid")
+	}
+	if got, want := ascending(sentinel), []string{"aa", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ascending iteration = %q, want %q", got, want)
+	}
+
+	c = r.MinCursor()
+	c.Close()
+	if c.IsValid() || c.MoveNext() != nil || c.Clone() != nil {
+		t.Errorf("a closed cursor is valid")
+	}
+
+	// Key and Value panic on an invalid cursor and at the sentinel node
+	for _, tt := range []struct {
+		name string
+		f    func()
+	}{
+		{"Key() of a closed cursor", func() { c.Key() }},
+		{"Value() of a closed cursor", func() { c.Value() }},
+		{"Key() at the sentinel node", func() { r.Cursor().Key() }},
+		{"Value() at the sentinel node", func() { r.Cursor().Value() }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", tt.name)
+				}
+			}()
+			tt.f()
+		}()
+	}
+}
+
+func TestTreeCursor(t *testing.T) {
+	t1, _, _ := NewTree[int]().Insert("a", 1)
+	t2, _, _ := t1.Insert("b", 2)
+	c := t1.MinCursor()
+	t3, _, _ := t2.Delete("a")
+
+	if !c.IsValid() || c.Key() != "a" || c.Set(10) {
+		t.Errorf("a cursor of a Tree is at %q (valid %v), or can set values", c.Key(), c.IsValid())
+	}
+	if got, want := ascending(t2.Cursor()), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ascending iteration of t2 = %q, want %q", got, want)
+	}
+	if c := t3.Seek("a"); c.Key() != "b" {
+		t.Errorf("t3.Seek(a) = %q, want b", c.Key())
+	}
+	if c := t2.MaxCursor(); c.Key() != "b" {
+		t.Errorf("t2.MaxCursor() = %q, want b", c.Key())
+	}
+}
@@ -0,0 +1,343 @@
+package radix
+
+import (
+	"sort"
+	"strings"
+)
+
+// Leaf is a key of a tree and its value. Key must not be modified, and neither must Value in a Tree, whose leaves are
+// shared between versions.
+type Leaf[V any] struct {
+	Key   string
+	Value V
+
+	owner *owner
+}
+
+// owner identifies the writer that created a node, which is the only one allowed to modify it in place.
+type owner struct {
+	_ byte // distinct owners must have distinct addresses
+}
+
+// node is a node of a tree. The edge leading to a node is labeled with its prefix, and the children of a node start
+// with distinct bytes, in ascending order. Every node but the root has a non-empty prefix, and either a leaf or at
+// least two children.
+type node[V any] struct {
+	prefix string
+	leaf   *Leaf[V] // the key ending at the node, if any
+	edges  []*node[V]
+	owner  *owner
+}
+
+// index returns the index of the child of n starting with b, or the index where it would be inserted.
+func (n *node[V]) index(b byte) (int, bool) {
+	i := sort.Search(len(n.edges), func(i int) bool { return n.edges[i].prefix[0] >= b })
+	return i, i < len(n.edges) && n.edges[i].prefix[0] == b
+}
+
+// key is the type of the keys accepted by the lookups.
+type key interface {
+	~string | ~[]byte
+}
+
+// hasPrefixAt reports whether k[i:] starts with p.
+func hasPrefixAt[K key](k K, i int, p string) bool {
+	if len(k)-i < len(p) {
+		return false
+	}
+	for j := 0; j < len(p); j++ {
+		if k[i+j] != p[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// lcp returns the length of the longest common prefix of a and b.
+func lcp(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// get returns the leaf of key k below root n. Return nil if there is none.
+func get[V any, K key](n *node[V], k K) *Leaf[V] {
+	for i := 0; n != nil; {
+		if i == len(k) {
+			return n.leaf
+		}
+		j, ok := n.index(k[i])
+		if !ok {
+			return nil
+		}
+		n = n.edges[j]
+		if !hasPrefixAt(k, i, n.prefix) {
+			return nil
+		}
+		i += len(n.prefix)
+	}
+	return nil
+}
+
+// longestPrefix returns the leaf of the longest key below root n that is a prefix of k. Return nil if there is none.
+func longestPrefix[V any, K key](n *node[V], k K) *Leaf[V] {
+	var last *Leaf[V]
+	for i := 0; n != nil; {
+		if n.leaf != nil {
+			last = n.leaf
+		}
+		if i == len(k) {
+			break
+		}
+		j, ok := n.index(k[i])
+		if !ok || !hasPrefixAt(k, i, n.edges[j].prefix) {
+			break
+		}
+		n = n.edges[j]
+		i += len(n.prefix)
+	}
+	return last
+}
+
+// seekPrefix returns the node below root n whose subtree holds the keys starting with p. Return nil if there is none.
+func seekPrefix[V any](n *node[V], p string) *node[V] {
+	for i := 0; n != nil; {
+		if i == len(p) {
+			return n
+		}
+		j, ok := n.index(p[i])
+		if !ok {
+			return nil
+		}
+		c := n.edges[j]
+		switch rest := p[i:]; {
+		case strings.HasPrefix(c.prefix, rest):
+			return c
+		case !strings.HasPrefix(rest, c.prefix):
+			return nil
+		}
+		n = c
+		i += len(c.prefix)
+	}
+	return nil
+}
+
+// walk calls f on the leaves of the subtree of n, in ascending or descending key order, until f returns false.
+// Return false if f did.
+func walk[V any](n *node[V], descending bool, f func(l *Leaf[V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	if !descending && n.leaf != nil && !f(n.leaf) {
+		return false
+	}
+	for i := range n.edges {
+		c := n.edges[i]
+		if descending {
+			c = n.edges[len(n.edges)-1-i]
+		}
+		if !walk(c, descending, f) {
+			return false
+		}
+	}
+	return !descending || n.leaf == nil || f(n.leaf)
+}
+
+// The functions below move along a path, the nodes from the root to the node of a key. They return the path to the key
+// they reach, or nil when there is none.
+
+// first returns the path to the smallest key of the subtree at the end of path.
+func first[V any](path []*node[V]) []*node[V] {
+	for n := path[len(path)-1]; n.leaf == nil; path = append(path, n) {
+		if len(n.edges) == 0 {
+			return nil // the root of an empty tree
+		}
+		n = n.edges[0]
+	}
+	return path
+}
+
+// last returns the path to the largest key of the subtree at the end of path.
+func last[V any](path []*node[V]) []*node[V] {
+	for n := path[len(path)-1]; len(n.edges) > 0; path = append(path, n) {
+		n = n.edges[len(n.edges)-1]
+	}
+	if path[len(path)-1].leaf == nil {
+		return nil // the root of an empty tree
+	}
+	return path
+}
+
+// after returns the path to the smallest key after the subtree at the end of path.
+func after[V any](path []*node[V]) []*node[V] {
+	for len(path) > 1 {
+		c, p := path[len(path)-1], path[len(path)-2]
+		path = path[:len(path)-1]
+		if i, _ := p.index(c.prefix[0]); i+1 < len(p.edges) {
+			return first(append(path, p.edges[i+1]))
+		}
+	}
+	return nil
+}
+
+// next returns the path to the key following the key at the end of path.
+func next[V any](path []*node[V]) []*node[V] {
+	if n := path[len(path)-1]; len(n.edges) > 0 {
+		return first(append(path, n.edges[0]))
+	}
+	return after(path)
+}
+
+// prev returns the path to the key preceding the key at the end of path.
+func prev[V any](path []*node[V]) []*node[V] {
+	for len(path) > 1 {
+		c, p := path[len(path)-1], path[len(path)-2]
+		path = path[:len(path)-1]
+		if i, _ := p.index(c.prefix[0]); i > 0 {
+			return last(append(path, p.edges[i-1]))
+		}
+		if p.leaf != nil {
+			return path
+		}
+	}
+	return nil
+}
+
+// seek returns the path to the smallest key greater than or equal to key below root.
+func seek[V any](root *node[V], key string) []*node[V] {
+	path := []*node[V]{root}
+	for n, i := root, 0; ; {
+		if i == len(key) {
+			return first(path)
+		}
+		j, _ := n.index(key[i])
+		if j == len(n.edges) {
+			return after(path)
+		}
+		c := n.edges[j]
+		path = append(path, c)
+		rest := key[i:]
+		l := lcp(c.prefix, rest)
+		switch {
+		case l == len(c.prefix):
+			n, i = c, i+l
+		case l == len(rest) || c.prefix[l] > rest[l]:
+			return first(path)
+		default:
+			return after(path)
+		}
+	}
+}
+
+// writer modifies trees. Nodes and leaves it does not own are copied before being modified, so that a writer with a
+// new owner leaves the trees it starts from untouched, while a writer keeping the owner of its nodes works in place.
+type writer[V any] struct {
+	owner *owner
+	size  int
+}
+
+// writable returns n if w owns it, and a copy of n owned by w otherwise.
+func (w *writer[V]) writable(n *node[V]) *node[V] {
+	if n.owner == w.owner {
+		return n
+	}
+	return &node[V]{
+		prefix: n.prefix,
+		leaf:   n.leaf,
+		edges:  append([]*node[V](nil), n.edges...),
+		owner:  w.owner,
+	}
+}
+
+// insert sets the value of key to v in the subtree of n, the node of key[:depth], and returns the new n.
+// Return the previous value and true if key was already in the subtree.
+func (w *writer[V]) insert(n *node[V], key string, depth int, v V) (*node[V], V, bool) {
+	var zero V
+	n = w.writable(n)
+	if depth == len(key) {
+		switch l := n.leaf; {
+		case l == nil:
+			n.leaf = &Leaf[V]{Key: key, Value: v, owner: w.owner}
+			w.size++
+			return n, zero, false
+		case l.owner == w.owner:
+			old := l.Value
+			l.Value = v
+			return n, old, true
+		default:
+			n.leaf = &Leaf[V]{Key: key, Value: v, owner: w.owner}
+			return n, l.Value, true
+		}
+	}
+
+	i, ok := n.index(key[depth])
+	if !ok {
+		c := &node[V]{prefix: key[depth:], leaf: &Leaf[V]{Key: key, Value: v, owner: w.owner}, owner: w.owner}
+		n.edges = append(n.edges, nil)
+		copy(n.edges[i+1:], n.edges[i:])
+		n.edges[i] = c
+		w.size++
+		return n, zero, false
+	}
+
+	c := n.edges[i]
+	l := lcp(c.prefix, key[depth:])
+	if l < len(c.prefix) {
+		// split the edge to c
+		mid := &node[V]{prefix: c.prefix[:l], owner: w.owner}
+		c = w.writable(c)
+		c.prefix = c.prefix[l:]
+		mid.edges = []*node[V]{c}
+		c = mid
+	}
+	c, old, replaced := w.insert(c, key, depth+l, v)
+	n.edges[i] = c
+	return n, old, replaced
+}
+
+// delete removes key from the subtree of n, the node of key[:depth], and returns the new n, nil if the subtree is
+// empty. Nodes left with a single child and no leaf are merged with their child, unless root is set.
+// Return the removed leaf, nil if key was not in the subtree.
+func (w *writer[V]) delete(n *node[V], key string, depth int, root bool) (*node[V], *Leaf[V]) {
+	var removed *Leaf[V]
+	if depth == len(key) {
+		if n.leaf == nil {
+			return n, nil
+		}
+		removed = n.leaf
+		n = w.writable(n)
+		n.leaf = nil
+		w.size--
+	} else {
+		i, ok := n.index(key[depth])
+		if !ok || !hasPrefixAt(key, depth, n.edges[i].prefix) {
+			return n, nil
+		}
+		var c *node[V]
+		c, removed = w.delete(n.edges[i], key, depth+len(n.edges[i].prefix), false)
+		if removed == nil {
+			return n, nil
+		}
+		n = w.writable(n)
+		if c != nil {
+			n.edges[i] = c
+		} else {
+			n.edges = append(n.edges[:i], n.edges[i+1:]...)
+		}
+	}
+
+	if root || n.leaf != nil {
+		return n, removed
+	}
+	switch len(n.edges) {
+	case 0:
+		return nil, removed
+	case 1:
+		c := w.writable(n.edges[0])
+		c.prefix = n.prefix + c.prefix
+		return c, removed
+	}
+	return n, removed
+}
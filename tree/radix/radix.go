@@ -0,0 +1,163 @@
+// Package radix implements radix trees, tries with compressed edges, mapping string keys to values.
+//
+// Keys are kept in ascending byte order, and besides the usual map operations the trees answer prefix queries:
+// LongestPrefix finds the longest key that is a prefix of a string, as a router matching a path does, and WalkPrefix
+// visits the keys starting with a prefix, as an autocompletion does. The lookups also accept byte slices, without
+// converting them to strings.
+//
+// Radix is a mutable tree. Tree is an immutable one: it is modified through a transaction, Txn, which copies the
+// nodes it changes and commits a new Tree, leaving the previous one untouched. A Tree can thus be read by any number of
+// goroutines while a writer prepares the next version, and published with an atomic pointer.
+//
+// Radix is not thread safe.
+// To iterate over a tree (where t is a *Radix or a *Tree), in ascending key order:
+//
+//	cursor := t.Cursor() // create a cursor point to the sentinel node
+//	for l := cursor.MoveNext(); l != nil; l = cursor.MoveNext() {
+//		// do something with l.Key and l.Value
+//	}
+package radix
+
+// Radix is a mutable radix tree. The zero value is an empty tree ready to use.
+type Radix[V any] struct {
+	root    *node[V]
+	w       writer[V]
+	version int // incremented when a key is added or removed, for the cursors
+}
+
+// New returns an empty tree.
+func New[V any]() *Radix[V] {
+	return new(Radix[V]).lazyInit()
+}
+
+func (r *Radix[V]) lazyInit() *Radix[V] {
+	if r.root == nil {
+		r.w.owner = &owner{}
+		r.root = &node[V]{owner: r.w.owner}
+	}
+	return r
+}
+
+// Len returns the number of keys in the tree.
+// The complexity is O(1).
+func (r *Radix[V]) Len() int {
+	return r.w.size
+}
+
+// Clear removes all the keys of the tree.
+func (r *Radix[V]) Clear() {
+	r.root = nil
+	r.w.size = 0
+	r.version++
+	r.lazyInit()
+}
+
+// Insert sets the value of key to v. Return the previous value and true if key was already in the tree.
+// The complexity is O(k), where k is the length of key.
+func (r *Radix[V]) Insert(key string, v V) (V, bool) {
+	r.lazyInit()
+	root, old, replaced := r.w.insert(r.root, key, 0, v)
+	r.root = root
+	if !replaced {
+		r.version++
+	}
+	return old, replaced
+}
+
+// Get returns the value of key. Return false if key is not in the tree.
+// The complexity is O(k), where k is the length of key.
+func (r *Radix[V]) Get(key string) (V, bool) {
+	return value(get(r.root, key))
+}
+
+// GetBytes is like Get, for a key given as a byte slice.
+func (r *Radix[V]) GetBytes(key []byte) (V, bool) {
+	return value(get(r.root, key))
+}
+
+// Delete removes key from the tree. Return its value and true if key was in the tree.
+// The complexity is O(k), where k is the length of key.
+func (r *Radix[V]) Delete(key string) (V, bool) {
+	r.lazyInit()
+	root, l := r.w.delete(r.root, key, 0, true)
+	r.root = root
+	if l != nil {
+		r.version++
+	}
+	return value(l)
+}
+
+// LongestPrefix returns the longest key of the tree that is a prefix of s, and its value. Return false if there is none.
+// The complexity is O(k), where k is the length of s.
+func (r *Radix[V]) LongestPrefix(s string) (string, V, bool) {
+	return keyValue(longestPrefix(r.root, s))
+}
+
+// LongestPrefixBytes is like LongestPrefix, for s given as a byte slice.
+func (r *Radix[V]) LongestPrefixBytes(s []byte) (string, V, bool) {
+	return keyValue(longestPrefix(r.root, s))
+}
+
+// WalkPrefix calls f on the leaves whose key starts with prefix, in ascending key order, until f returns false.
+// f must not modify the tree.
+// The complexity is O(k + m), where k is the length of prefix and m the number of nodes visited.
+func (r *Radix[V]) WalkPrefix(prefix string, f func(l *Leaf[V]) bool) {
+	walk(seekPrefix(r.root, prefix), false, f)
+}
+
+// WalkAscending calls f on every leaf, in ascending key order, until f returns false. f must not modify the tree.
+func (r *Radix[V]) WalkAscending(f func(l *Leaf[V]) bool) {
+	walk(r.root, false, f)
+}
+
+// WalkDescending calls f on every leaf, in descending key order, until f returns false. f must not modify the tree.
+func (r *Radix[V]) WalkDescending(f func(l *Leaf[V]) bool) {
+	walk(r.root, true, f)
+}
+
+// Cursor returns a cursor pointing to the sentinel node: MoveNext moves it to the smallest key, MovePrev to the largest.
+func (r *Radix[V]) Cursor() *Cursor[V] {
+	r.lazyInit()
+	return &Cursor[V]{radix: r, root: r.root, version: r.version}
+}
+
+// MinCursor returns a cursor pointing to the smallest key, or to the sentinel node if the tree is empty.
+func (r *Radix[V]) MinCursor() *Cursor[V] {
+	c := r.Cursor()
+	c.MoveNext()
+	return c
+}
+
+// MaxCursor returns a cursor pointing to the largest key, or to the sentinel node if the tree is empty.
+func (r *Radix[V]) MaxCursor() *Cursor[V] {
+	c := r.Cursor()
+	c.MovePrev()
+	return c
+}
+
+// Seek returns a cursor pointing to the smallest key greater than or equal to key, or to the sentinel node if there
+// is none.
+// The complexity is O(k), where k is the length of key.
+func (r *Radix[V]) Seek(key string) *Cursor[V] {
+	c := r.Cursor()
+	c.moveTo(seek(c.root, key))
+	return c
+}
+
+// value returns the value of leaf l, false if l is nil.
+func value[V any](l *Leaf[V]) (V, bool) {
+	if l == nil {
+		var zero V
+		return zero, false
+	}
+	return l.Value, true
+}
+
+// keyValue returns the key and the value of leaf l, false if l is nil.
+func keyValue[V any](l *Leaf[V]) (string, V, bool) {
+	if l == nil {
+		var zero V
+		return "", zero, false
+	}
+	return l.Key, l.Value, true
+}
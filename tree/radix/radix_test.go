@@ -0,0 +1,252 @@
+package radix
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// checkNode verifies the invariants of the subtree of n, the node of key, and returns its number of keys.
+func checkNode[V any](t *testing.T, n *node[V], key string, root bool) int {
+	t.Helper()
+	if !root && (n.prefix == "" || n.leaf == nil && len(n.edges) < 2) {
+		t.Errorf("node %q: prefix %q, %d edges and leaf %v", key, n.prefix, len(n.edges), n.leaf != nil)
+	}
+	size := 0
+	if n.leaf != nil {
+		if n.leaf.Key != key {
+			t.Errorf("leaf of node %q has key %q", key, n.leaf.Key)
+		}
+		size++
+	}
+	for i, c := range n.edges {
+		if i > 0 && n.edges[i-1].prefix[0] >= c.prefix[0] {
+			t.Errorf("node %q: edges %q and %q are not in order", key, n.edges[i-1].prefix, c.prefix)
+		}
+		size += checkNode(t, c, key+c.prefix, false)
+	}
+	return size
+}
+
+// checkRadix verifies the invariants of r and compares its keys with the sorted keys of model.
+func checkRadix(t *testing.T, r *Radix[int], model map[string]int) {
+	t.Helper()
+	if n := checkNode(t, r.root, "", true); n != r.Len() || n != len(model) {
+		t.Errorf("the tree has %d keys, Len() = %d, want %d", n, r.Len(), len(model))
+	}
+	if got, want := leaves(r.WalkAscending), sortedKeys(model); !reflect.DeepEqual(got, want) {
+		t.Errorf("WalkAscending() = %v, want %v", got, want)
+	}
+	if got, want := ascending(r.Cursor()), sortedKeys(model); !reflect.DeepEqual(got, want) {
+		t.Errorf("ascending iteration = %v, want %v", got, want)
+	}
+
+	want := sortedKeys(model)
+	c := r.Cursor()
+	for i := len(want) - 1; i >= 0; i-- {
+		if l := c.MovePrev(); l == nil || l.Key != want[i] {
+			t.Errorf("descending iteration reached %v, want %q", l, want[i])
+			return
+		}
+	}
+	if l := c.MovePrev(); l != nil {
+		t.Errorf("descending iteration reached %q after the smallest key", l.Key)
+	}
+}
+
+// leaves returns the keys visited by walk.
+func leaves(walk func(f func(l *Leaf[int]) bool)) []string {
+	keys := []string{}
+	walk(func(l *Leaf[int]) bool {
+		keys = append(keys, l.Key)
+		return true
+	})
+	return keys
+}
+
+func sortedKeys(model map[string]int) []string {
+	keys := []string{}
+	for k := range model {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestRadix(t *testing.T) {
+	r := New[int]()
+	model := make(map[string]int)
+	for i, k := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "rom", ""} {
+		if _, replaced := r.Insert(k, i); replaced {
+			t.Errorf("Insert(%q) replaced a value", k)
+		}
+		model[k] = i
+		checkRadix(t, r, model)
+	}
+
+	if old, replaced := r.Insert("rubens", 100); !replaced || old != 3 {
+		t.Errorf("Insert(rubens) = %d, %v, want 3, true", old, replaced)
+	}
+	model["rubens"] = 100
+	for k, want := range model {
+		if v, ok := r.Get(k); !ok || v != want {
+			t.Errorf("Get(%q) = %d, %v, want %d, true", k, v, ok, want)
+		}
+		if v, ok := r.GetBytes([]byte(k)); !ok || v != want {
+			t.Errorf("GetBytes(%q) = %d, %v, want %d, true", k, v, ok, want)
+		}
+	}
+	for _, k := range []string{"r", "ro", "roman", "romanes", "rubi", "x"} {
+		if v, ok := r.Get(k); ok {
+			t.Errorf("Get(%q) = %d, true, want false", k, v)
+		}
+	}
+
+	for _, k := range []string{"rom", "x", "romane", "roman", "rub", "rubicon"} {
+		v, ok := r.Delete(k)
+		want, wantOK := model[k]
+		if v != want || ok != wantOK {
+			t.Errorf("Delete(%q) = %d, %v, want %d, %v", k, v, ok, want, wantOK)
+		}
+		delete(model, k)
+		checkRadix(t, r, model)
+	}
+
+	r.Clear()
+	checkRadix(t, r, map[string]int{})
+}
+
+func TestRadixZeroValue(t *testing.T) {
+	var r Radix[int]
+	if _, ok := r.Get("a"); ok || r.Len() != 0 {
+		t.Errorf("Get(a) found a key in an empty tree")
+	}
+	if _, _, ok := r.LongestPrefix("a"); ok {
+		t.Errorf("LongestPrefix(a) found a key in an empty tree")
+	}
+	if _, ok := r.Delete("a"); ok {
+		t.Errorf("Delete(a) found a key in an empty tree")
+	}
+	r.Insert("a", 1)
+	checkRadix(t, &r, map[string]int{"a": 1})
+}
+
+func TestLongestPrefix(t *testing.T) {
+	r := New[int]()
+	for i, k := range []string{"/", "/api/", "/api/v1/", "/api/v1/users", "/static/"} {
+		r.Insert(k, i)
+	}
+	tests := []struct {
+		s, key string
+		ok     bool
+	}{
+		{"/api/v1/users/42", "/api/v1/users", true},
+		{"/api/v1/user", "/api/v1/", true},
+		{"/api/v2/", "/api/", true},
+		{"/api", "/", true},
+		{"/static/css/main.css", "/static/", true},
+		{"/", "/", true},
+		{"api", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		key, _, ok := r.LongestPrefix(tt.s)
+		if key != tt.key || ok != tt.ok {
+			t.Errorf("LongestPrefix(%q) = %q, %v, want %q, %v", tt.s, key, ok, tt.key, tt.ok)
+		}
+		key, _, ok = r.LongestPrefixBytes([]byte(tt.s))
+		if key != tt.key || ok != tt.ok {
+			t.Errorf("LongestPrefixBytes(%q) = %q, %v, want %q, %v", tt.s, key, ok, tt.key, tt.ok)
+		}
+	}
+}
+
+func TestWalkPrefix(t *testing.T) {
+	r := New[int]()
+	for i, k := range []string{"car", "card", "care", "careful", "cat", "dog", "ca"} {
+		r.Insert(k, i)
+	}
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"car", []string{"car", "card", "care", "careful"}},
+		{"care", []string{"care", "careful"}},
+		{"caref", []string{"careful"}},
+		{"ca", []string{"ca", "car", "card", "care", "careful", "cat"}},
+		{"c", []string{"ca", "car", "card", "care", "careful", "cat"}},
+		{"", []string{"ca", "car", "card", "care", "careful", "cat", "dog"}},
+		{"cart", []string{}},
+		{"e", []string{}},
+	}
+	for _, tt := range tests {
+		got := leaves(func(f func(l *Leaf[int]) bool) { r.WalkPrefix(tt.prefix, f) })
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("WalkPrefix(%q) = %v, want %v", tt.prefix, got, tt.want)
+		}
+	}
+
+	// the walk stops when f returns false
+	n := 0
+	r.WalkPrefix("ca", func(l *Leaf[int]) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Errorf("WalkPrefix stopped after %d leaves, want 2", n)
+	}
+}
+
+// randomKey returns a short key over a small alphabet, so that keys share prefixes.
+func randomKey(r *rand.Rand) string {
+	var b strings.Builder
+	for n := r.Intn(6); n > 0; n-- {
+		b.WriteByte("abc"[r.Intn(3)])
+	}
+	return b.String()
+}
+
+func TestRadixRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	r := New[int]()
+	model := make(map[string]int)
+	for step := 0; step < 3000; step++ {
+		k := randomKey(rnd)
+		if rnd.Intn(3) > 0 {
+			old, replaced := r.Insert(k, step)
+			if want, ok := model[k]; old != want || replaced != ok {
+				t.Fatalf("step %d: Insert(%q) = %d, %v, want %d, %v", step, k, old, replaced, want, ok)
+			}
+			model[k] = step
+		} else {
+			v, ok := r.Delete(k)
+			if want, wantOK := model[k]; v != want || ok != wantOK {
+				t.Fatalf("step %d: Delete(%q) = %d, %v, want %d, %v", step, k, v, ok, want, wantOK)
+			}
+			delete(model, k)
+		}
+
+		s := randomKey(rnd)
+		want := ""
+		for k := range model {
+			if strings.HasPrefix(s, k) && len(k) >= len(want) {
+				want = k
+			}
+		}
+		_, wantOK := model[want]
+		if key, _, ok := r.LongestPrefix(s); key != want || ok != wantOK {
+			t.Fatalf("step %d: LongestPrefix(%q) = %q, %v, want %q, %v", step, s, key, ok, want, wantOK)
+		}
+	}
+	checkRadix(t, r, model)
+
+	for _, k := range sortedKeys(model) {
+		r.Delete(k)
+	}
+	checkRadix(t, r, map[string]int{})
+	if len(r.root.edges) != 0 || r.root.leaf != nil {
+		t.Errorf("the root is not empty after deleting every key")
+	}
+}
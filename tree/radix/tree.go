@@ -0,0 +1,162 @@
+package radix
+
+// Tree is an immutable radix tree. Insert and Delete return a new tree, sharing the nodes it did not change with the
+// previous one; a Txn batches several changes into a single new tree, copying each node once. Trees are safe for
+// concurrent reads. The zero value is an empty tree ready to use.
+type Tree[V any] struct {
+	root *node[V]
+	size int
+}
+
+// NewTree returns an empty tree.
+func NewTree[V any]() *Tree[V] {
+	return &Tree[V]{}
+}
+
+// rootNode returns the root of t, an empty node if t has none.
+func (t *Tree[V]) rootNode() *node[V] {
+	if t.root == nil {
+		return &node[V]{}
+	}
+	return t.root
+}
+
+// Len returns the number of keys in the tree.
+// The complexity is O(1).
+func (t *Tree[V]) Len() int {
+	return t.size
+}
+
+// Txn returns a transaction starting from t.
+func (t *Tree[V]) Txn() *Txn[V] {
+	return &Txn[V]{root: t.rootNode(), w: writer[V]{owner: &owner{}, size: t.size}}
+}
+
+// Insert returns a tree where the value of key is v, with the previous value and true if key was already in t.
+// The complexity is O(k), where k is the length of key.
+func (t *Tree[V]) Insert(key string, v V) (*Tree[V], V, bool) {
+	txn := t.Txn()
+	old, replaced := txn.Insert(key, v)
+	return txn.Commit(), old, replaced
+}
+
+// Delete returns a tree without key, with its value and true if key was in t. Return t itself if key was not in t.
+// The complexity is O(k), where k is the length of key.
+func (t *Tree[V]) Delete(key string) (*Tree[V], V, bool) {
+	txn := t.Txn()
+	old, ok := txn.Delete(key)
+	if !ok {
+		return t, old, false
+	}
+	return txn.Commit(), old, true
+}
+
+// Get returns the value of key. Return false if key is not in the tree.
+// The complexity is O(k), where k is the length of key.
+func (t *Tree[V]) Get(key string) (V, bool) {
+	return value(get(t.root, key))
+}
+
+// GetBytes is like Get, for a key given as a byte slice.
+func (t *Tree[V]) GetBytes(key []byte) (V, bool) {
+	return value(get(t.root, key))
+}
+
+// LongestPrefix returns the longest key of the tree that is a prefix of s, and its value. Return false if there is none.
+// The complexity is O(k), where k is the length of s.
+func (t *Tree[V]) LongestPrefix(s string) (string, V, bool) {
+	return keyValue(longestPrefix(t.root, s))
+}
+
+// LongestPrefixBytes is like LongestPrefix, for s given as a byte slice.
+func (t *Tree[V]) LongestPrefixBytes(s []byte) (string, V, bool) {
+	return keyValue(longestPrefix(t.root, s))
+}
+
+// WalkPrefix calls f on the leaves whose key starts with prefix, in ascending key order, until f returns false.
+// The complexity is O(k + m), where k is the length of prefix and m the number of nodes visited.
+func (t *Tree[V]) WalkPrefix(prefix string, f func(l *Leaf[V]) bool) {
+	walk(seekPrefix(t.root, prefix), false, f)
+}
+
+// WalkAscending calls f on every leaf, in ascending key order, until f returns false.
+func (t *Tree[V]) WalkAscending(f func(l *Leaf[V]) bool) {
+	walk(t.root, false, f)
+}
+
+// WalkDescending calls f on every leaf, in descending key order, until f returns false.
+func (t *Tree[V]) WalkDescending(f func(l *Leaf[V]) bool) {
+	walk(t.root, true, f)
+}
+
+// Cursor returns a cursor pointing to the sentinel node: MoveNext moves it to the smallest key, MovePrev to the largest.
+// The cursor stays valid forever, since t never changes.
+func (t *Tree[V]) Cursor() *Cursor[V] {
+	return &Cursor[V]{root: t.rootNode()}
+}
+
+// MinCursor returns a cursor pointing to the smallest key, or to the sentinel node if the tree is empty.
+func (t *Tree[V]) MinCursor() *Cursor[V] {
+	c := t.Cursor()
+	c.MoveNext()
+	return c
+}
+
+// MaxCursor returns a cursor pointing to the largest key, or to the sentinel node if the tree is empty.
+func (t *Tree[V]) MaxCursor() *Cursor[V] {
+	c := t.Cursor()
+	c.MovePrev()
+	return c
+}
+
+// Seek returns a cursor pointing to the smallest key greater than or equal to key, or to the sentinel node if there
+// is none.
+// The complexity is O(k), where k is the length of key.
+func (t *Tree[V]) Seek(key string) *Cursor[V] {
+	c := t.Cursor()
+	c.moveTo(seek(c.root, key))
+	return c
+}
+
+// Txn is a transaction on a Tree. It copies the nodes it changes the first time, then modifies its copies in place,
+// so that a batch of changes costs about as much as on a Radix. The tree it started from is left untouched.
+// Txn is not thread safe.
+type Txn[V any] struct {
+	root *node[V]
+	w    writer[V]
+}
+
+// Len returns the number of keys in the tree being built.
+func (txn *Txn[V]) Len() int {
+	return txn.w.size
+}
+
+// Insert sets the value of key to v. Return the previous value and true if key was already in the tree.
+// The complexity is O(k), where k is the length of key.
+func (txn *Txn[V]) Insert(key string, v V) (V, bool) {
+	root, old, replaced := txn.w.insert(txn.root, key, 0, v)
+	txn.root = root
+	return old, replaced
+}
+
+// Delete removes key from the tree. Return its value and true if key was in the tree.
+// The complexity is O(k), where k is the length of key.
+func (txn *Txn[V]) Delete(key string) (V, bool) {
+	root, l := txn.w.delete(txn.root, key, 0, true)
+	txn.root = root
+	return value(l)
+}
+
+// Get returns the value of key in the tree being built. Return false if key is not in the tree.
+// The complexity is O(k), where k is the length of key.
+func (txn *Txn[V]) Get(key string) (V, bool) {
+	return value(get(txn.root, key))
+}
+
+// Commit returns the tree built by the transaction. The transaction can go on afterwards: it copies the nodes of the
+// committed tree before changing them, like those of any other tree.
+func (txn *Txn[V]) Commit() *Tree[V] {
+	t := &Tree[V]{root: txn.root, size: txn.w.size}
+	txn.w.owner = &owner{}
+	return t
+}
@@ -0,0 +1,136 @@
+package radix
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// contents returns the keys and values of t.
+func contents(t *Tree[int]) map[string]int {
+	m := make(map[string]int)
+	t.WalkAscending(func(l *Leaf[int]) bool {
+		m[l.Key] = l.Value
+		return true
+	})
+	return m
+}
+
+func TestTree(t *testing.T) {
+	t0 := NewTree[int]()
+	t1, _, _ := t0.Insert("foo", 1)
+	t2, _, _ := t1.Insert("foobar", 2)
+	t3, old, replaced := t2.Insert("foo", 10)
+	if !replaced || old != 1 {
+		t.Errorf("Insert(foo) = %d, %v, want 1, true", old, replaced)
+	}
+	t4, old, ok := t3.Delete("foobar")
+	if !ok || old != 2 {
+		t.Errorf("Delete(foobar) = %d, %v, want 2, true", old, ok)
+	}
+	if t5, _, ok := t4.Delete("bar"); ok || t5 != t4 {
+		t.Errorf("Delete(bar) of a missing key returned a new tree")
+	}
+
+	tests := []struct {
+		tree *Tree[int]
+		want map[string]int
+	}{
+		{t0, map[string]int{}},
+		{t1, map[string]int{"foo": 1}},
+		{t2, map[string]int{"foo": 1, "foobar": 2}},
+		{t3, map[string]int{"foo": 10, "foobar": 2}},
+		{t4, map[string]int{"foo": 10}},
+	}
+	for i, tt := range tests {
+		if got := contents(tt.tree); !reflect.DeepEqual(got, tt.want) || tt.tree.Len() != len(tt.want) {
+			t.Errorf("version %d = %v, Len() = %d, want %v", i, got, tt.tree.Len(), tt.want)
+		}
+	}
+
+	if v, ok := t2.GetBytes([]byte("foobar")); !ok || v != 2 {
+		t.Errorf("t2.GetBytes(foobar) = %d, %v, want 2, true", v, ok)
+	}
+	if key, v, ok := t3.LongestPrefix("foobarbaz"); key != "foobar" || v != 2 || !ok {
+		t.Errorf("t3.LongestPrefix(foobarbaz) = %q, %d, %v, want foobar, 2, true", key, v, ok)
+	}
+}
+
+func TestTreeZeroValue(t *testing.T) {
+	var t0 Tree[int]
+	if _, ok := t0.Get("a"); ok || t0.Len() != 0 || t0.Cursor().MoveNext() != nil {
+		t.Errorf("the zero tree is not empty")
+	}
+	t1, _, _ := t0.Insert("a", 1)
+	if v, ok := t1.Get("a"); !ok || v != 1 || t0.Len() != 0 {
+		t.Errorf("t1.Get(a) = %d, %v, want 1, true", v, ok)
+	}
+}
+
+func TestTxn(t *testing.T) {
+	base := NewTree[int]()
+	txn := base.Txn()
+	for i, k := range []string{"a", "ab", "abc", "b"} {
+		txn.Insert(k, i)
+	}
+	if v, ok := txn.Get("ab"); !ok || v != 1 || txn.Len() != 4 {
+		t.Errorf("txn.Get(ab) = %d, %v, Len() = %d, want 1, true, 4", v, ok, txn.Len())
+	}
+	t1 := txn.Commit()
+
+	// the transaction goes on without changing the committed tree
+	txn.Delete("ab")
+	txn.Insert("a", 10)
+	t2 := txn.Commit()
+
+	if got, want := contents(t1), map[string]int{"a": 0, "ab": 1, "abc": 2, "b": 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("t1 = %v, want %v", got, want)
+	}
+	if got, want := contents(t2), map[string]int{"a": 10, "abc": 2, "b": 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("t2 = %v, want %v", got, want)
+	}
+	if base.Len() != 0 || t1.Len() != 4 || t2.Len() != 3 {
+		t.Errorf("Len() = %d, %d, %d, want 0, 4, 3", base.Len(), t1.Len(), t2.Len())
+	}
+	checkNode(t, t1.root, "", true)
+	checkNode(t, t2.root, "", true)
+}
+
+func TestTreeRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var trees []*Tree[int]
+	var models []map[string]int
+
+	tree := NewTree[int]()
+	model := make(map[string]int)
+	for step := 0; step < 300; step++ {
+		txn := tree.Txn()
+		for n := rnd.Intn(8); n >= 0; n-- {
+			k := randomKey(rnd)
+			if rnd.Intn(3) > 0 {
+				txn.Insert(k, step)
+				model[k] = step
+			} else {
+				txn.Delete(k)
+				delete(model, k)
+			}
+		}
+		tree = txn.Commit()
+
+		saved := make(map[string]int, len(model))
+		for k, v := range model {
+			saved[k] = v
+		}
+		trees, models = append(trees, tree), append(models, saved)
+	}
+
+	// every version still holds the keys it was committed with
+	for i, tree := range trees {
+		if got := contents(tree); !reflect.DeepEqual(got, models[i]) || tree.Len() != len(models[i]) {
+			t.Fatalf("version %d = %v, Len() = %d, want %v", i, got, tree.Len(), models[i])
+		}
+		if n := checkNode(t, tree.root, "", true); n != tree.Len() {
+			t.Fatalf("version %d has %d keys, Len() = %d", i, n, tree.Len())
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package rbtree_test
+
+import (
+	"testing"
+
+	"github.com/nnhatnam/skale/container"
+	"github.com/nnhatnam/skale/container/containertest"
+	"github.com/nnhatnam/skale/tree/rbtree"
+)
+
+var (
+	_ container.Iterable[*rbtree.Node[int, int]]        = (*rbtree.TreeMap[int, int])(nil)
+	_ container.BidiCursor[int, *rbtree.Node[int, int]] = (*rbtree.Cursor[int, int])(nil)
+)
+
+// fromValues returns a tree mapping every value to itself.
+func fromValues(values []int) *rbtree.TreeMap[int, int] {
+	tr := rbtree.NewOrdered[int, int]()
+	for _, v := range values {
+		tr.Put(v, v)
+	}
+	return tr
+}
+
+func TestTreeMapConformance(t *testing.T) {
+	containertest.TestIterable(t, func(values []int) container.Iterable[*rbtree.Node[int, int]] {
+		return fromValues(values)
+	}, func(n *rbtree.Node[int, int]) int { return n.Value })
+}
+
+func TestCursorConformance(t *testing.T) {
+	containertest.TestBidiCursor(t, func(values []int) container.BidiCursor[int, *rbtree.Node[int, int]] {
+		return fromValues(values).Cursor()
+	})
+}
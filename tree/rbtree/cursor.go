@@ -0,0 +1,130 @@
+package rbtree
+
+// Cursor points to a node of a TreeMap, or to the sentinel node that stands before the smallest key and after the
+// largest one. It follows the contract of linkedlist.Cursor: MoveNext and MovePrev walk the keys in order and return
+// nil when they reach the sentinel node, a cursor becomes invalid when its node is deleted, and Value panics on an
+// invalid cursor.
+type Cursor[K, V any] struct {
+	tree    *TreeMap[K, V]
+	current *Node[K, V]
+}
+
+// Equal returns true if the two cursors point to the same node in the same tree.
+// If either cursor is not valid, it returns false.
+func (c *Cursor[K, V]) Equal(c2 *Cursor[K, V]) bool {
+	if !c.IsValid() || !c2.IsValid() {
+		return false
+	}
+	return c.tree == c2.tree && c.current == c2.current
+}
+
+// Key returns the key of the node that the cursor points to.
+// It returns the zero value if the cursor points to the sentinel node or is not valid.
+func (c *Cursor[K, V]) Key() K {
+	if n := c.Node(); n != nil {
+		return n.Key
+	}
+	var zero K
+	return zero
+}
+
+// Value returns the value of the node that the cursor points to, the zero value if it points to the sentinel node.
+// If the cursor is not valid, it will panic, as linkedlist.Cursor.Value does.
+func (c *Cursor[K, V]) Value() V {
+	if !c.IsValid() {
+		panic("rbtree: cursor is not valid when calling Value()")
+	}
+	if c.current == c.tree.sentinel {
+		var zero V
+		return zero
+	}
+	return c.current.Value
+}
+
+// Set sets the value of the node that the cursor points to. Return false if the cursor points to the sentinel node or
+// is not valid.
+func (c *Cursor[K, V]) Set(v V) bool {
+	if n := c.Node(); n != nil {
+		n.Value = v
+		return true
+	}
+	return false
+}
+
+// Node returns the node that the cursor points to. Return nil if the cursor points to the sentinel node or is not valid.
+func (c *Cursor[K, V]) Node() *Node[K, V] {
+	if c.IsValid() && c.current != c.tree.sentinel {
+		return c.current
+	}
+	return nil
+}
+
+// Clone creates a new cursor that points to the same node as the current cursor.
+// Return nil if the current cursor is not valid.
+func (c *Cursor[K, V]) Clone() *Cursor[K, V] {
+	if c.IsValid() {
+		return &Cursor[K, V]{tree: c.tree, current: c.current}
+	}
+	return nil
+}
+
+// MoveNext moves the cursor to the node of the next key and return the node.
+// Move to the sentinel node and return nil if the cursor is pointing to the largest key.
+// From the sentinel node, it moves to the smallest key. Return nil if the cursor is not valid.
+// The complexity is O(log n), and O(1) amortized over a full iteration.
+func (c *Cursor[K, V]) MoveNext() *Node[K, V] {
+	if !c.IsValid() {
+		return nil
+	}
+	var n *Node[K, V]
+	if c.current == c.tree.sentinel {
+		n = c.tree.Min()
+	} else {
+		n = c.tree.next(c.current)
+	}
+	if n == nil {
+		c.current = c.tree.sentinel
+	} else {
+		c.current = n
+	}
+	return n
+}
+
+// MovePrev moves the cursor to the node of the previous key and return the node.
+// Move to the sentinel node and return nil if the cursor is pointing to the smallest key.
+// From the sentinel node, it moves to the largest key. Return nil if the cursor is not valid.
+// The complexity is O(log n), and O(1) amortized over a full iteration.
+func (c *Cursor[K, V]) MovePrev() *Node[K, V] {
+	if !c.IsValid() {
+		return nil
+	}
+	var n *Node[K, V]
+	if c.current == c.tree.sentinel {
+		n = c.tree.Max()
+	} else {
+		n = c.tree.prev(c.current)
+	}
+	if n == nil {
+		c.current = c.tree.sentinel
+	} else {
+		c.current = n
+	}
+	return n
+}
+
+// Close closes the cursor. A closed cursor is not valid.
+func (c *Cursor[K, V]) Close() {
+	c.tree = nil
+	c.current = nil
+}
+
+// IsValid detects if the cursor is valid.
+// A cursor is not valid if it is closed, or it is pointing to a node that has been deleted.
+// If the cursor is not valid, Close() will be called automatically.
+func (c *Cursor[K, V]) IsValid() bool {
+	if c.tree == nil || c.current == nil || c.current != c.tree.sentinel && c.current.tree != c.tree {
+		c.Close()
+		return false
+	}
+	return true
+}
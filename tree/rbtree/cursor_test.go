@@ -0,0 +1,102 @@
+package rbtree
+
+import "testing"
+
+func TestCursor(t *testing.T) {
+	tr := NewOrdered[int, int]()
+	for k := 1; k <= 5; k++ {
+		tr.Put(k, 10*k)
+	}
+
+	c := tr.Cursor()
+	var got []int
+	for n := c.MoveNext(); n != nil; n = c.MoveNext() {
+		got = append(got, n.Key)
+	}
+	if len(got) != 5 || got[0] != 1 || got[4] != 5 {
+		t.Errorf("ascending iteration = %v, want [1 2 3 4 5]", got)
+	}
+	if c.Node() != nil || !c.IsValid() {
+		t.Errorf("the cursor is not at the sentinel node after the iteration")
+	}
+
+	// from the sentinel node, MovePrev wraps to the largest key
+	if n := c.MovePrev(); n == nil || n.Key != 5 {
+		t.Errorf("c.MovePrev() from the sentinel = %v, want 5", n)
+	}
+	if c.Key() != 5 || c.Value() != 50 {
+		t.Errorf("c.Key(), c.Value() = %d, %d, want 5, 50", c.Key(), c.Value())
+	}
+	if !c.Set(55) || tr.Node(5).Value != 55 {
+		t.Errorf("c.Set(55) did not update the node")
+	}
+
+	c2 := c.Clone()
+	if !c.Equal(c2) {
+		t.Errorf("c.Equal(c.Clone()) = false")
+	}
+	c2.MovePrev()
+	if c.Equal(c2) || c2.Key() != 4 {
+		t.Errorf("c2.MovePrev() = %d, want 4", c2.Key())
+	}
+
+	if tr.MinCursor().Key() != 1 || tr.MaxCursor().Key() != 5 {
+		t.Errorf("tr.MinCursor(), tr.MaxCursor() = %d, %d, want 1, 5", tr.MinCursor().Key(), tr.MaxCursor().Key())
+	}
+	if tr.CursorAt(nil) != nil || tr.CursorAt(NewOrdered[int, int]().Put(1, 1)) != nil {
+		t.Errorf("tr.CursorAt() accepted a node of another tree")
+	}
+}
+
+func TestCursorDelete(t *testing.T) {
+	tr := NewOrdered[int, int]()
+	for k := 1; k <= 20; k++ {
+		tr.Put(k, k)
+	}
+
+	// deleting a node with two children relinks its successor: cursors to the successor stay valid
+	root := tr.root.Key
+	succ := tr.CursorAt(tr.Node(root + 1))
+	deleted := tr.CursorAt(tr.root)
+	tr.Delete(root)
+	checkTree(t, tr)
+
+	if deleted.IsValid() || deleted.MoveNext() != nil {
+		t.Errorf("a cursor to a deleted node is still valid")
+	}
+	if !succ.IsValid() || succ.Key() != root+1 {
+		t.Errorf("the cursor to the successor of a deleted node is not valid")
+	}
+	if n := succ.MovePrev(); n == nil || n.Key != root-1 {
+		t.Errorf("succ.MovePrev() = %v, want %d", n, root-1)
+	}
+
+	// RemoveAt moves the cursor to the next key
+	c := tr.MinCursor()
+	for c.Node() != nil {
+		if c.Key()%2 == 0 {
+			tr.RemoveAt(c)
+		} else {
+			c.MoveNext()
+		}
+	}
+	checkTree(t, tr)
+	for _, k := range keys(tr) {
+		if k%2 == 0 {
+			t.Errorf("even key %d was not removed", k)
+		}
+	}
+	if tr.RemoveAt(tr.Cursor()) != nil {
+		t.Errorf("tr.RemoveAt(sentinel) returned a node")
+	}
+	if v := tr.Cursor().Value(); v != 0 {
+		t.Errorf("Value() at the sentinel node = %d, want 0", v)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Value() of a cursor to a deleted node did not panic")
+		}
+	}()
+	deleted.Value()
+}
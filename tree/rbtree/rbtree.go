@@ -0,0 +1,520 @@
+// Package rbtree implements an ordered map on a red-black tree.
+//
+// Every node also stores the size of its subtree, so besides the usual ordered map operations the tree answers
+// Rank and Select queries in O(log n).
+//
+// Structure is not thread safe.
+// To iterate over a tree (where t is a *TreeMap), in ascending key order:
+//
+//	cursor := t.Cursor() // create a cursor point to the sentinel node
+//	for n := cursor.MoveNext(); n != nil; n = cursor.MoveNext() {
+//		// do something with n.Key and n.Value
+//	}
+package rbtree
+
+import "golang.org/x/exp/constraints"
+
+// Node is a node of a TreeMap. Key must not be modified.
+type Node[K, V any] struct {
+	left, right, parent *Node[K, V]
+	red                 bool
+	size                int            // number of nodes in the subtree rooted at the node
+	tree                *TreeMap[K, V] // nil once the node is deleted
+
+	Key   K
+	Value V
+}
+
+// TreeMap is an ordered map backed by a red-black tree.
+type TreeMap[K, V any] struct {
+	root     *Node[K, V]
+	sentinel *Node[K, V] // black sentinel standing for every leaf, size 0
+	cmp      func(a, b K) int
+}
+
+// New returns an empty tree ordered by cmp.
+// cmp(a, b) must return a negative number when a < b, a positive number when a > b and zero when a == b.
+func New[K, V any](cmp func(a, b K) int) *TreeMap[K, V] {
+	t := &TreeMap[K, V]{cmp: cmp, sentinel: &Node[K, V]{}}
+	t.root = t.sentinel
+	return t
+}
+
+// NewOrdered returns an empty tree of an ordered key type, in ascending order.
+func NewOrdered[K constraints.Ordered, V any]() *TreeMap[K, V] {
+	return New[K, V](func(a, b K) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	})
+}
+
+// Len returns the number of keys in the tree.
+// The complexity is O(1).
+func (t *TreeMap[K, V]) Len() int {
+	return t.root.size
+}
+
+// Clear removes every key from the tree. Nodes and cursors of the previous content must not be used anymore.
+func (t *TreeMap[K, V]) Clear() {
+	t.root = t.sentinel
+}
+
+// find returns the node of key, or nil if key is not in the tree.
+func (t *TreeMap[K, V]) find(key K) *Node[K, V] {
+	n := t.root
+	for n != t.sentinel {
+		switch c := t.cmp(key, n.Key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Get returns the value of key.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) Get(key K) (V, bool) {
+	if n := t.find(key); n != nil {
+		return n.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether key is in the tree.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) Contains(key K) bool {
+	return t.find(key) != nil
+}
+
+// Node returns the node of key. Return nil if key is not in the tree.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) Node(key K) *Node[K, V] {
+	return t.find(key)
+}
+
+// Put sets the value of key, and returns its node. An existing node keeps its place, so cursors pointing to it stay valid.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) Put(key K, value V) *Node[K, V] {
+	parent, n := t.sentinel, t.root
+	c := 0
+	for n != t.sentinel {
+		parent = n
+		c = t.cmp(key, n.Key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			n.Value = value
+			return n
+		}
+	}
+
+	z := &Node[K, V]{left: t.sentinel, right: t.sentinel, parent: parent, red: true, size: 1, tree: t, Key: key, Value: value}
+	switch {
+	case parent == t.sentinel:
+		t.root = z
+	case c < 0:
+		parent.left = z
+	default:
+		parent.right = z
+	}
+	for p := parent; p != t.sentinel; p = p.parent {
+		p.size++
+	}
+	t.insertFixup(z)
+	return z
+}
+
+// Delete removes key from the tree. Return false if key is not in the tree.
+// Other nodes are relinked, never copied, so cursors pointing to them stay valid.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) Delete(key K) bool {
+	n := t.find(key)
+	if n == nil {
+		return false
+	}
+	t.delete(n)
+	return true
+}
+
+// RemoveAt removes the node at cursor c, and moves c to the next node. Return the removed node.
+// Return nil if c is invalid, pointing to the sentinel node or not associated with t.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) RemoveAt(c *Cursor[K, V]) *Node[K, V] {
+	if c.tree != t || !c.IsValid() || c.current == t.sentinel {
+		return nil
+	}
+	n := c.current
+	c.MoveNext()
+	t.delete(n)
+	return n
+}
+
+// Min returns the node of the smallest key. Return nil if the tree is empty.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) Min() *Node[K, V] {
+	if t.root == t.sentinel {
+		return nil
+	}
+	return t.min(t.root)
+}
+
+// Max returns the node of the largest key. Return nil if the tree is empty.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) Max() *Node[K, V] {
+	if t.root == t.sentinel {
+		return nil
+	}
+	return t.max(t.root)
+}
+
+// Floor returns the node of the largest key less than or equal to key. Return nil if there is none.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) Floor(key K) *Node[K, V] {
+	var best *Node[K, V]
+	for n := t.root; n != t.sentinel; {
+		c := t.cmp(key, n.Key)
+		if c == 0 {
+			return n
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			best = n
+			n = n.right
+		}
+	}
+	return best
+}
+
+// Ceiling returns the node of the smallest key greater than or equal to key. Return nil if there is none.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) Ceiling(key K) *Node[K, V] {
+	var best *Node[K, V]
+	for n := t.root; n != t.sentinel; {
+		c := t.cmp(key, n.Key)
+		if c == 0 {
+			return n
+		}
+		if c > 0 {
+			n = n.right
+		} else {
+			best = n
+			n = n.left
+		}
+	}
+	return best
+}
+
+// Rank returns the number of keys strictly less than key, whether key is in the tree or not.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) Rank(key K) int {
+	r := 0
+	for n := t.root; n != t.sentinel; {
+		if t.cmp(key, n.Key) <= 0 {
+			n = n.left
+		} else {
+			r += n.left.size + 1
+			n = n.right
+		}
+	}
+	return r
+}
+
+// Select returns the node of the i-th smallest key, starting from 0. Return nil if i is out of range.
+// The complexity is O(log n).
+func (t *TreeMap[K, V]) Select(i int) *Node[K, V] {
+	if i < 0 || i >= t.root.size {
+		return nil
+	}
+	n := t.root
+	for {
+		switch l := n.left.size; {
+		case i < l:
+			n = n.left
+		case i > l:
+			i -= l + 1
+			n = n.right
+		default:
+			return n
+		}
+	}
+}
+
+// WalkAscending calls f on every node in ascending key order, until f returns false.
+// f must not modify the tree.
+func (t *TreeMap[K, V]) WalkAscending(f func(n *Node[K, V]) bool) {
+	for n := t.Min(); n != nil && f(n); n = t.next(n) {
+	}
+}
+
+// WalkDescending calls f on every node in descending key order, until f returns false.
+// f must not modify the tree.
+func (t *TreeMap[K, V]) WalkDescending(f func(n *Node[K, V]) bool) {
+	for n := t.Max(); n != nil && f(n); n = t.prev(n) {
+	}
+}
+
+// Range calls f on the nodes whose key is in [lo, hi), in ascending key order, until f returns false.
+// f must not modify the tree.
+// The complexity is O(log n + k), where k is the number of nodes visited.
+func (t *TreeMap[K, V]) Range(lo, hi K, f func(n *Node[K, V]) bool) {
+	for n := t.Ceiling(lo); n != nil && t.cmp(n.Key, hi) < 0 && f(n); n = t.next(n) {
+	}
+}
+
+// Cursor returns a cursor pointing to the sentinel node: MoveNext moves it to the smallest key, MovePrev to the largest.
+func (t *TreeMap[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{tree: t, current: t.sentinel}
+}
+
+// CursorAt returns a cursor pointing to node n. Return nil if n does not belong to t.
+func (t *TreeMap[K, V]) CursorAt(n *Node[K, V]) *Cursor[K, V] {
+	if n == nil || n.tree != t {
+		return nil
+	}
+	return &Cursor[K, V]{tree: t, current: n}
+}
+
+// MinCursor returns a cursor pointing to the smallest key, or to the sentinel node if the tree is empty.
+func (t *TreeMap[K, V]) MinCursor() *Cursor[K, V] {
+	c := t.Cursor()
+	c.MoveNext()
+	return c
+}
+
+// MaxCursor returns a cursor pointing to the largest key, or to the sentinel node if the tree is empty.
+func (t *TreeMap[K, V]) MaxCursor() *Cursor[K, V] {
+	c := t.Cursor()
+	c.MovePrev()
+	return c
+}
+
+// min returns the leftmost node of the subtree rooted at n.
+func (t *TreeMap[K, V]) min(n *Node[K, V]) *Node[K, V] {
+	for n.left != t.sentinel {
+		n = n.left
+	}
+	return n
+}
+
+// max returns the rightmost node of the subtree rooted at n.
+func (t *TreeMap[K, V]) max(n *Node[K, V]) *Node[K, V] {
+	for n.right != t.sentinel {
+		n = n.right
+	}
+	return n
+}
+
+// next returns the in-order successor of n, or nil if n is the largest key.
+func (t *TreeMap[K, V]) next(n *Node[K, V]) *Node[K, V] {
+	if n.right != t.sentinel {
+		return t.min(n.right)
+	}
+	p := n.parent
+	for p != t.sentinel && n == p.right {
+		n, p = p, p.parent
+	}
+	if p == t.sentinel {
+		return nil
+	}
+	return p
+}
+
+// prev returns the in-order predecessor of n, or nil if n is the smallest key.
+func (t *TreeMap[K, V]) prev(n *Node[K, V]) *Node[K, V] {
+	if n.left != t.sentinel {
+		return t.max(n.left)
+	}
+	p := n.parent
+	for p != t.sentinel && n == p.left {
+		n, p = p, p.parent
+	}
+	if p == t.sentinel {
+		return nil
+	}
+	return p
+}
+
+// resize recomputes the subtree size of n from its children.
+func (t *TreeMap[K, V]) resize(n *Node[K, V]) {
+	n.size = n.left.size + n.right.size + 1
+}
+
+func (t *TreeMap[K, V]) rotateLeft(x *Node[K, V]) {
+	y := x.right
+	x.right = y.left
+	if y.left != t.sentinel {
+		y.left.parent = x
+	}
+	t.replaceChild(x, y)
+	y.left = x
+	x.parent = y
+	t.resize(x)
+	t.resize(y)
+}
+
+func (t *TreeMap[K, V]) rotateRight(x *Node[K, V]) {
+	y := x.left
+	x.left = y.right
+	if y.right != t.sentinel {
+		y.right.parent = x
+	}
+	t.replaceChild(x, y)
+	y.right = x
+	x.parent = y
+	t.resize(x)
+	t.resize(y)
+}
+
+// replaceChild links v in place of u under the parent of u.
+func (t *TreeMap[K, V]) replaceChild(u, v *Node[K, V]) {
+	switch {
+	case u.parent == t.sentinel:
+		t.root = v
+	case u == u.parent.left:
+		u.parent.left = v
+	default:
+		u.parent.right = v
+	}
+	v.parent = u.parent
+}
+
+// insertFixup restores the red-black properties after inserting the red node z.
+func (t *TreeMap[K, V]) insertFixup(z *Node[K, V]) {
+	for z.parent.red {
+		p, g := z.parent, z.parent.parent
+		if p == g.left {
+			if u := g.right; u.red {
+				p.red, u.red, g.red = false, false, true
+				z = g
+				continue
+			}
+			if z == p.right {
+				z = p
+				t.rotateLeft(z)
+				p = z.parent
+			}
+			p.red, g.red = false, true
+			t.rotateRight(g)
+		} else {
+			if u := g.left; u.red {
+				p.red, u.red, g.red = false, false, true
+				z = g
+				continue
+			}
+			if z == p.left {
+				z = p
+				t.rotateRight(z)
+				p = z.parent
+			}
+			p.red, g.red = false, true
+			t.rotateLeft(g)
+		}
+	}
+	t.root.red = false
+}
+
+// delete unlinks node z from the tree. When z has two children, its successor is moved to its place.
+func (t *TreeMap[K, V]) delete(z *Node[K, V]) {
+	// the node leaving its position is z, or its successor y; every subtree above it loses a node
+	y := z
+	if z.left != t.sentinel && z.right != t.sentinel {
+		y = t.min(z.right)
+	}
+	for n := y; n != t.sentinel; n = n.parent {
+		n.size--
+	}
+
+	var x *Node[K, V]
+	yRed := y.red
+	switch {
+	case z.left == t.sentinel:
+		x = z.right
+		t.replaceChild(z, x)
+	case z.right == t.sentinel:
+		x = z.left
+		t.replaceChild(z, x)
+	default:
+		x = y.right
+		if y.parent == z {
+			x.parent = y
+		} else {
+			t.replaceChild(y, x)
+			y.right = z.right
+			y.right.parent = y
+		}
+		t.replaceChild(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.red = z.red
+		y.size = z.size
+	}
+	if !yRed {
+		t.deleteFixup(x)
+	}
+	t.sentinel.parent = nil
+
+	z.left, z.right, z.parent, z.tree = nil, nil, nil, nil
+}
+
+// deleteFixup restores the red-black properties after removing a black node, x carrying the extra black.
+func (t *TreeMap[K, V]) deleteFixup(x *Node[K, V]) {
+	for x != t.root && !x.red {
+		p := x.parent
+		if x == p.left {
+			w := p.right
+			if w.red {
+				w.red, p.red = false, true
+				t.rotateLeft(p)
+				w = p.right
+			}
+			if !w.left.red && !w.right.red {
+				w.red = true
+				x = p
+				continue
+			}
+			if !w.right.red {
+				w.left.red, w.red = false, true
+				t.rotateRight(w)
+				w = p.right
+			}
+			w.red, p.red, w.right.red = p.red, false, false
+			t.rotateLeft(p)
+			x = t.root
+		} else {
+			w := p.left
+			if w.red {
+				w.red, p.red = false, true
+				t.rotateRight(p)
+				w = p.left
+			}
+			if !w.right.red && !w.left.red {
+				w.red = true
+				x = p
+				continue
+			}
+			if !w.left.red {
+				w.right.red, w.red = false, true
+				t.rotateLeft(w)
+				w = p.left
+			}
+			w.red, p.red, w.left.red = p.red, false, false
+			t.rotateRight(p)
+			x = t.root
+		}
+	}
+	x.red = false
+}
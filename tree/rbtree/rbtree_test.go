@@ -0,0 +1,229 @@
+package rbtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkTree verifies the red-black properties of t, the parent links, the subtree sizes and the key order.
+func checkTree[K, V any](t *testing.T, tr *TreeMap[K, V]) {
+	t.Helper()
+
+	if tr.root.red {
+		t.Errorf("the root is red")
+	}
+	if tr.sentinel.red || tr.sentinel.size != 0 {
+		t.Errorf("the sentinel is red or has a size")
+	}
+
+	var walk func(n *Node[K, V]) int
+	walk = func(n *Node[K, V]) int {
+		if n == tr.sentinel {
+			return 1
+		}
+		if n.tree != tr {
+			t.Errorf("node %v does not belong to the tree", n.Key)
+		}
+		for _, ch := range []*Node[K, V]{n.left, n.right} {
+			if ch != tr.sentinel && ch.parent != n {
+				t.Errorf("child %v of %v has a wrong parent", ch.Key, n.Key)
+			}
+			if n.red && ch.red {
+				t.Errorf("red node %v has a red child", n.Key)
+			}
+		}
+		if n.left != tr.sentinel && tr.cmp(n.left.Key, n.Key) >= 0 {
+			t.Errorf("left child %v is not less than %v", n.left.Key, n.Key)
+		}
+		if n.right != tr.sentinel && tr.cmp(n.right.Key, n.Key) <= 0 {
+			t.Errorf("right child %v is not greater than %v", n.right.Key, n.Key)
+		}
+		if n.size != n.left.size+n.right.size+1 {
+			t.Errorf("node %v has size %d, want %d", n.Key, n.size, n.left.size+n.right.size+1)
+		}
+
+		lh, rh := walk(n.left), walk(n.right)
+		if lh != rh {
+			t.Errorf("node %v has black heights %d and %d", n.Key, lh, rh)
+		}
+		if n.red {
+			return lh
+		}
+		return lh + 1
+	}
+	walk(tr.root)
+}
+
+// keys returns the keys of t in ascending order.
+func keys[K, V any](tr *TreeMap[K, V]) []K {
+	var ks []K
+	tr.WalkAscending(func(n *Node[K, V]) bool {
+		ks = append(ks, n.Key)
+		return true
+	})
+	return ks
+}
+
+func TestTreeMap(t *testing.T) {
+	tr := NewOrdered[int, string]()
+	for _, k := range []int{5, 3, 8, 1, 4, 7, 9} {
+		tr.Put(k, string(rune('a'+k)))
+	}
+	checkTree(t, tr)
+
+	if tr.Len() != 7 {
+		t.Errorf("tr.Len() = %d, want 7", tr.Len())
+	}
+	if v, ok := tr.Get(4); !ok || v != "e" {
+		t.Errorf("tr.Get(4) = %q, %v, want e, true", v, ok)
+	}
+	if _, ok := tr.Get(6); ok {
+		t.Errorf("tr.Get(6) = _, true, want false")
+	}
+
+	n := tr.Node(4)
+	if tr.Put(4, "four") != n || n.Value != "four" || tr.Len() != 7 {
+		t.Errorf("tr.Put(4) on an existing key did not update its node")
+	}
+
+	if got := tr.Min(); got == nil || got.Key != 1 {
+		t.Errorf("tr.Min() = %v, want 1", got)
+	}
+	if got := tr.Max(); got == nil || got.Key != 9 {
+		t.Errorf("tr.Max() = %v, want 9", got)
+	}
+
+	if !tr.Delete(5) || tr.Delete(5) {
+		t.Errorf("tr.Delete(5) twice = false/true, want true/false")
+	}
+	checkTree(t, tr)
+	if got := keys(tr); len(got) != 6 || got[2] != 4 || got[3] != 7 {
+		t.Errorf("keys = %v, want [1 3 4 7 8 9]", got)
+	}
+
+	tr.Clear()
+	if tr.Len() != 0 || tr.Min() != nil || tr.Max() != nil {
+		t.Errorf("tr is not empty after Clear")
+	}
+}
+
+func TestTreeMapFloorCeiling(t *testing.T) {
+	tr := NewOrdered[int, int]()
+	for k := 10; k <= 50; k += 10 {
+		tr.Put(k, k)
+	}
+
+	tests := []struct {
+		key            int
+		floor, ceiling int // -1 for none
+	}{
+		{5, -1, 10},
+		{10, 10, 10},
+		{25, 20, 30},
+		{50, 50, 50},
+		{55, 50, -1},
+	}
+	for _, tt := range tests {
+		if got, want := tr.Floor(tt.key), tt.floor; (got == nil) != (want < 0) || got != nil && got.Key != want {
+			t.Errorf("tr.Floor(%d) = %v, want %d", tt.key, got, want)
+		}
+		if got, want := tr.Ceiling(tt.key), tt.ceiling; (got == nil) != (want < 0) || got != nil && got.Key != want {
+			t.Errorf("tr.Ceiling(%d) = %v, want %d", tt.key, got, want)
+		}
+	}
+
+	empty := NewOrdered[int, int]()
+	if empty.Floor(1) != nil || empty.Ceiling(1) != nil || empty.Select(0) != nil {
+		t.Errorf("queries on an empty tree returned a node")
+	}
+}
+
+func TestTreeMapRankSelect(t *testing.T) {
+	tr := NewOrdered[int, int]()
+	for k := 0; k < 100; k += 2 {
+		tr.Put(k, k)
+	}
+
+	for i := 0; i < 50; i++ {
+		if n := tr.Select(i); n == nil || n.Key != 2*i {
+			t.Errorf("tr.Select(%d) = %v, want %d", i, n, 2*i)
+		}
+		if r := tr.Rank(2 * i); r != i {
+			t.Errorf("tr.Rank(%d) = %d, want %d", 2*i, r, i)
+		}
+		if r := tr.Rank(2*i + 1); r != i+1 {
+			t.Errorf("tr.Rank(%d) = %d, want %d", 2*i+1, r, i+1)
+		}
+	}
+	if tr.Select(-1) != nil || tr.Select(50) != nil {
+		t.Errorf("tr.Select() out of range returned a node")
+	}
+}
+
+func TestTreeMapRange(t *testing.T) {
+	tr := NewOrdered[int, int]()
+	for k := 0; k < 10; k++ {
+		tr.Put(k, k)
+	}
+
+	var got []int
+	tr.Range(3, 7, func(n *Node[int, int]) bool {
+		got = append(got, n.Key)
+		return true
+	})
+	if len(got) != 4 || got[0] != 3 || got[3] != 6 {
+		t.Errorf("tr.Range(3, 7) = %v, want [3 4 5 6]", got)
+	}
+
+	got = got[:0]
+	tr.WalkDescending(func(n *Node[int, int]) bool {
+		got = append(got, n.Key)
+		return n.Key > 7
+	})
+	if len(got) != 3 || got[0] != 9 || got[2] != 7 {
+		t.Errorf("tr.WalkDescending() stopped at %v, want [9 8 7]", got)
+	}
+}
+
+func TestTreeMapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(38))
+	tr := NewOrdered[int, int]()
+	model := make(map[int]int)
+
+	for i := 0; i < 4000; i++ {
+		k := r.Intn(300)
+		if r.Intn(3) == 0 {
+			_, ok := model[k]
+			if got := tr.Delete(k); got != ok {
+				t.Fatalf("tr.Delete(%d) = %v, want %v", k, got, ok)
+			}
+			delete(model, k)
+		} else {
+			tr.Put(k, i)
+			model[k] = i
+		}
+		if i%200 == 0 {
+			checkTree(t, tr)
+		}
+	}
+	checkTree(t, tr)
+
+	want := make([]int, 0, len(model))
+	for k := range model {
+		want = append(want, k)
+	}
+	sort.Ints(want)
+	got := keys(tr)
+	if len(got) != len(want) {
+		t.Fatalf("tr has %d keys, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("keys[%d] = %d, want %d", i, got[i], want[i])
+		}
+		if v, _ := tr.Get(want[i]); v != model[want[i]] {
+			t.Errorf("tr.Get(%d) = %d, want %d", want[i], v, model[want[i]])
+		}
+	}
+}